@@ -46,7 +46,7 @@ func attachCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Bac
 	runCmd := &cobra.Command{
 		Use:   "attach [OPTIONS] SERVICE",
 		Short: "Attach local standard input, output, and error streams to a service's running container",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  cobra.ExactArgs(1),
 		PreRunE: Adapt(func(ctx context.Context, args []string) error {
 			opts.service = args[0]
 			return nil
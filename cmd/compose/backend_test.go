@@ -0,0 +1,38 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestOptionalTimeout(t *testing.T) {
+	// not set, so services stop_grace_period applies
+	assert.Assert(t, optionalTimeout(10, false) == nil)
+
+	timeout := optionalTimeout(5, true)
+	assert.Assert(t, timeout != nil)
+	assert.Equal(t, *timeout, 5*time.Second)
+
+	// explicitly set to 0 to stop immediately
+	timeout = optionalTimeout(0, true)
+	assert.Assert(t, timeout != nil)
+	assert.Equal(t, *timeout, time.Duration(0))
+}
@@ -18,17 +18,23 @@ package compose
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/cli"
 	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/distribution/reference"
 	"github.com/docker/cli/cli/command"
 	cliopts "github.com/docker/cli/opts"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v5/cmd/display"
+	"github.com/docker/compose/v5/cmd/formatter"
 	"github.com/docker/compose/v5/pkg/api"
 	"github.com/docker/compose/v5/pkg/compose"
 )
@@ -48,6 +54,9 @@ type buildOptions struct {
 	check      bool
 	sbom       string
 	provenance string
+	summary    string
+	cacheFrom  []string
+	cacheTo    []string
 }
 
 func (opts buildOptions) toAPIBuildOptions(services []string) (api.BuildOptions, error) {
@@ -62,14 +71,28 @@ func (opts buildOptions) toAPIBuildOptions(services []string) (api.BuildOptions,
 			Path: path,
 		})
 	}
+	for _, spec := range opts.cacheFrom {
+		if err := validateCacheSpec(spec); err != nil {
+			return api.BuildOptions{}, fmt.Errorf("invalid --cache-from value %q: %w", spec, err)
+		}
+	}
+	for _, spec := range opts.cacheTo {
+		if err := validateCacheSpec(spec); err != nil {
+			return api.BuildOptions{}, fmt.Errorf("invalid --cache-to value %q: %w", spec, err)
+		}
+	}
 	builderName := opts.builder
 	if builderName == "" {
 		builderName = os.Getenv("BUILDX_BUILDER")
 	}
 
 	uiMode := display.Mode
-	if uiMode == display.ModeJSON {
+	switch uiMode {
+	case display.ModeJSON:
 		uiMode = "rawjson"
+	case display.ModeQuietErrors:
+		// build failures are reported by the returned error
+		uiMode = display.ModeQuiet
 	}
 
 	return api.BuildOptions{
@@ -88,9 +111,42 @@ func (opts buildOptions) toAPIBuildOptions(services []string) (api.BuildOptions,
 		Builder:    builderName,
 		SBOM:       opts.sbom,
 		Provenance: opts.provenance,
+		CacheFrom:  opts.cacheFrom,
+		CacheTo:    opts.cacheTo,
 	}, nil
 }
 
+// validateCacheSpec checks spec is a buildx cache definition, either a comma-separated list of
+// key=value attributes including the cache type, or a registry reference as a shorthand for
+// type=registry,ref=<reference>
+func validateCacheSpec(spec string) error {
+	if !strings.Contains(spec, "=") {
+		_, err := reference.ParseNormalizedNamed(spec)
+		return err
+	}
+	fields, err := csv.NewReader(strings.NewReader(spec)).Read()
+	if err != nil {
+		return err
+	}
+	var hasType bool
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("expected key=value, got %q", field)
+		}
+		if key == "type" {
+			if value == "" {
+				return errors.New("cache type can't be empty")
+			}
+			hasType = true
+		}
+	}
+	if !hasType {
+		return errors.New("cache type is required")
+	}
+	return nil
+}
+
 func buildCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
 	opts := buildOptions{
 		ProjectOptions: p,
@@ -99,6 +155,9 @@ func buildCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Back
 		Use:   "build [OPTIONS] [SERVICE...]",
 		Short: "Build or rebuild services",
 		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			if opts.summary != "" && opts.summary != formatter.JSON {
+				return fmt.Errorf("unsupported summary format %q, must be %s", opts.summary, formatter.JSON)
+			}
 			if opts.quiet {
 				display.Mode = display.ModeQuiet
 				devnull, err := os.Open(os.DevNull)
@@ -145,6 +204,9 @@ func buildCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Back
 	flags.MarkHidden("progress") //nolint:errcheck
 	flags.BoolVar(&opts.print, "print", false, "Print equivalent bake file")
 	flags.BoolVar(&opts.check, "check", false, "Check build configuration")
+	flags.StringArrayVar(&opts.cacheFrom, "cache-from", []string{}, "External cache sources, overriding the services cache_from (e.g. \"type=registry,ref=user/app:cache\")")
+	flags.StringArrayVar(&opts.cacheTo, "cache-to", []string{}, "Cache export destinations, overriding the services cache_to (e.g. \"type=registry,ref=user/app:cache\")")
+	flags.StringVar(&opts.summary, "summary", "", "Print a summary of the build results after the build output. Values: [json]")
 
 	return cmd
 }
@@ -174,5 +236,51 @@ func runBuild(ctx context.Context, dockerCli command.Cli, backendOptions *Backen
 	}
 	apiBuildOptions.Attestations = true
 
-	return backend.Build(ctx, project, apiBuildOptions)
+	if opts.summary == "" {
+		return backend.Build(ctx, project, apiBuildOptions)
+	}
+
+	summary := &buildSummary{}
+	apiBuildOptions.Results = summary.add
+	err = backend.Build(ctx, project, apiBuildOptions)
+	if printErr := summary.print(dockerCli.Out()); printErr != nil {
+		return errors.Join(err, printErr)
+	}
+	return err
+}
+
+// buildSummary collects the result of each service build
+type buildSummary struct {
+	resultCollector[api.BuildResult]
+}
+
+// print writes the build results as JSON, sorted by service name
+func (b *buildSummary) print(out io.Writer) error {
+	type buildResult struct {
+		Service  string  `json:"Service"`
+		Image    string  `json:"Image"`
+		ImageID  string  `json:"ImageID"`
+		Duration float64 `json:"Duration"` // in seconds
+		Cached   bool    `json:"Cached"`
+		Error    string  `json:"Error,omitempty"`
+		NotBuilt bool    `json:"NotBuilt,omitempty"`
+	}
+
+	results := b.sorted(func(a, b api.BuildResult) int {
+		return strings.Compare(a.Service, b.Service)
+	})
+	return printResultsJSON(out, results, func(r api.BuildResult) buildResult {
+		result := buildResult{
+			Service:  r.Service,
+			Image:    r.Image,
+			ImageID:  r.ImageID,
+			Duration: r.Duration.Round(time.Millisecond).Seconds(),
+			Cached:   r.Cached,
+			NotBuilt: r.NotBuilt,
+		}
+		if r.Error != nil {
+			result.Error = r.Error.Error()
+		}
+		return result
+	})
 }
@@ -0,0 +1,71 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestBuildSummary(t *testing.T) {
+	summary := &buildSummary{}
+	summary.add(api.BuildResult{
+		Service:  "web",
+		Image:    "project-web",
+		ImageID:  "sha256:abc",
+		Duration: 1500 * time.Millisecond,
+		Cached:   true,
+	})
+	summary.add(api.BuildResult{
+		Service:  "db",
+		Image:    "project-db",
+		Duration: 2 * time.Second,
+		Error:    errors.New("failed to solve"),
+	})
+	summary.add(api.BuildResult{
+		Service:  "worker",
+		Image:    "project-worker",
+		NotBuilt: true,
+	})
+
+	var buf bytes.Buffer
+	err := summary.print(&buf)
+	assert.NilError(t, err)
+	assert.Equal(t, buf.String(), `[{"Service":"db","Image":"project-db","ImageID":"","Duration":2,"Cached":false,"Error":"failed to solve"},`+
+		`{"Service":"web","Image":"project-web","ImageID":"sha256:abc","Duration":1.5,"Cached":true},`+
+		`{"Service":"worker","Image":"project-worker","ImageID":"","Duration":0,"Cached":false,"NotBuilt":true}]`+"\n")
+}
+
+func TestValidateCacheSpec(t *testing.T) {
+	assert.NilError(t, validateCacheSpec("type=registry,ref=user/app:cache,mode=max"))
+	assert.NilError(t, validateCacheSpec("type=gha"))
+	assert.NilError(t, validateCacheSpec("user/app:cache"))
+
+	assert.Error(t, validateCacheSpec("ref=user/app:cache"), "cache type is required")
+	assert.Error(t, validateCacheSpec("type=registry,mode"), `expected key=value, got "mode"`)
+	assert.Error(t, validateCacheSpec("type="), "cache type can't be empty")
+	assert.ErrorContains(t, validateCacheSpec("User/App"), "must be lowercase")
+
+	_, err := buildOptions{cacheTo: []string{"mode=max"}}.toAPIBuildOptions(nil)
+	assert.Error(t, err, `invalid --cache-to value "mode=max": cache type is required`)
+}
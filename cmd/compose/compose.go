@@ -70,6 +70,8 @@ const (
 	ComposeMenu = "COMPOSE_MENU"
 	// ComposeProgress defines type of progress output, if --progress isn't used
 	ComposeProgress = "COMPOSE_PROGRESS"
+	// ComposeProgressTimestamps prefixes plain progress output lines with a timestamp
+	ComposeProgressTimestamps = "COMPOSE_PROGRESS_TIMESTAMPS"
 )
 
 // rawEnv load a dot env file using docker/cli key=value parser, without attempt to interpolate or evaluate values
@@ -370,7 +372,8 @@ func (o *ProjectOptions) remoteLoaders(dockerCli command.Cli) []loader.ResourceL
 	}
 	git := remote.NewGitRemoteLoader(dockerCli, o.Offline)
 	oci := remote.NewOCIRemoteLoader(dockerCli, o.Offline, api.OCIOptions{})
-	return []loader.ResourceLoader{git, oci}
+	http := remote.NewHTTPRemoteLoader(o.Offline)
+	return []loader.ResourceLoader{git, oci, http}
 }
 
 func (o *ProjectOptions) toProjectOptions(po ...cli.ProjectOptionsFn) (*cli.ProjectOptions, error) {
@@ -401,7 +404,7 @@ func (o *ProjectOptions) toProjectOptions(po ...cli.ProjectOptionsFn) (*cli.Proj
 		cli.WithEnvFiles(o.EnvFiles...), //nolint:gocritic // intentionally applying cli.WithEnvFiles twice.
 		cli.WithDotEnv,                  //nolint:gocritic // intentionally applying cli.WithDotEnv twice.
 		// eventually COMPOSE_PROFILES should have been set
-		cli.WithDefaultProfiles(o.Profiles...),
+		compose.WithMergedProfiles(o.Profiles...),
 		cli.WithName(o.ProjectName),
 	)
 
@@ -591,6 +594,8 @@ func RootCommand(dockerCli command.Cli, backendOptions *BackendOptions) *cobra.C
 		alphaCommand(&opts, dockerCli, backendOptions),
 		bridgeCommand(&opts, dockerCli),
 		volumesCommand(&opts, dockerCli, backendOptions),
+		graphCommand(&opts, dockerCli, backendOptions),
+		lockCommand(&opts, dockerCli, backendOptions),
 	)
 
 	c.Flags().SetInterspersed(false)
@@ -650,11 +655,11 @@ func selectEventProcessor(dockerCli command.Cli, progress, ansi string, detached
 		switch {
 		case ansi == "never":
 			display.Mode = display.ModePlain
-			return display.Plain(dockerCli.Err()), nil
+			return plainEventProcessor(dockerCli), nil
 		case dockerCli.Err().IsTerminal():
 			return display.Full(dockerCli.Err(), stdinfo(dockerCli), detached), nil
 		default:
-			return display.Plain(dockerCli.Err()), nil
+			return plainEventProcessor(dockerCli), nil
 		}
 	case display.ModeTTY:
 		if ansi == "never" {
@@ -667,10 +672,13 @@ func selectEventProcessor(dockerCli command.Cli, progress, ansi string, detached
 			return nil, fmt.Errorf("can't use --progress plain while ANSI support is forced")
 		}
 		display.Mode = display.ModePlain
-		return display.Plain(dockerCli.Err()), nil
+		return plainEventProcessor(dockerCli), nil
 	case display.ModeQuiet, "none":
 		display.Mode = display.ModeQuiet
 		return display.Quiet(), nil
+	case display.ModeQuietErrors:
+		display.Mode = display.ModeQuietErrors
+		return display.QuietErrors(dockerCli.Err()), nil
 	case display.ModeJSON:
 		display.Mode = display.ModeJSON
 		logrus.SetFormatter(&logrus.JSONFormatter{})
@@ -680,6 +688,14 @@ func selectEventProcessor(dockerCli command.Cli, progress, ansi string, detached
 	}
 }
 
+// plainEventProcessor renders plain progress output, with timestamps when enabled by COMPOSE_PROGRESS_TIMESTAMPS
+func plainEventProcessor(dockerCli command.Cli) api.EventProcessor {
+	if utils.StringToBool(os.Getenv(ComposeProgressTimestamps)) {
+		return display.PlainWithTimestamps(dockerCli.Err())
+	}
+	return display.Plain(dockerCli.Err())
+}
+
 func setEnvWithDotEnv(opts ProjectOptions, dockerCli command.Cli) error {
 	// Check if we're using a remote config (OCI or Git)
 	// If so, skip env loading as remote loaders haven't been initialized yet
@@ -724,4 +740,5 @@ var printerModes = []string{
 	display.ModePlain,
 	display.ModeJSON,
 	display.ModeQuiet,
+	display.ModeQuietErrors,
 }
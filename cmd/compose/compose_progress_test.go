@@ -176,6 +176,12 @@ func TestSelectEventProcessor_ExplicitMode(t *testing.T) {
 			ansi:     "auto",
 			wantType: "*display.quiet",
 		},
+		{
+			name:     "progress=quiet-errors returns QuietErrors",
+			progress: display.ModeQuietErrors,
+			ansi:     "auto",
+			wantType: "*display.quietErrors",
+		},
 		{
 			name:     "progress=json returns JSON",
 			progress: display.ModeJSON,
@@ -20,18 +20,25 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"regexp"
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/cli"
 	"github.com/compose-spec/compose-go/v2/loader"
 	"github.com/compose-spec/compose-go/v2/template"
 	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/distribution/reference"
 	"github.com/docker/cli/cli/command"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"go.yaml.in/yaml/v4"
 
@@ -46,6 +53,8 @@ type configOptions struct {
 	Output              string
 	quiet               bool
 	resolveImageDigests bool
+	noCache             bool
+	digestsCacheTTL     int
 	noInterpolate       bool
 	noNormalize         bool
 	noResolvePath       bool
@@ -61,6 +70,9 @@ type configOptions struct {
 	variables           bool
 	environment         bool
 	lockImageDigests    bool
+	noDeps              bool
+	mergeOnly           bool
+	strict              bool
 }
 
 func (o *configOptions) ToProject(ctx context.Context, dockerCli command.Cli, backend api.Compose, services []string) (*types.Project, error) {
@@ -73,6 +85,16 @@ func (o *configOptions) ToModel(ctx context.Context, dockerCli command.Cli, serv
 	return o.ProjectOptions.ToModel(ctx, dockerCli, services, po...)
 }
 
+// setMergeOnly disables all processing of the model but merging Compose files
+func (o *configOptions) setMergeOnly() {
+	o.mergeOnly = true
+	o.noInterpolate = true
+	o.noNormalize = true
+	o.noResolvePath = true
+	o.noResolveEnv = true
+	o.noConsistency = true
+}
+
 // toProjectOptionsFns converts config options to cli.ProjectOptionsFn
 func (o *configOptions) toProjectOptionsFns() []cli.ProjectOptionsFn {
 	fns := []cli.ProjectOptionsFn{
@@ -86,6 +108,11 @@ func (o *configOptions) toProjectOptionsFns() []cli.ProjectOptionsFn {
 	if o.noResolveEnv {
 		fns = append(fns, cli.WithoutEnvironmentResolution)
 	}
+	if o.mergeOnly {
+		fns = append(fns, cli.WithLoadOptions(func(options *loader.Options) {
+			options.SkipDefaultValues = true
+		}))
+	}
 	return fns
 }
 
@@ -110,9 +137,21 @@ func configCommand(p *ProjectOptions, dockerCli command.Cli) *cobra.Command {
 			if opts.lockImageDigests {
 				opts.resolveImageDigests = true
 			}
+			if opts.noDeps && opts.noInterpolate {
+				return errors.New("--no-deps can't be used with --no-interpolate")
+			}
+			if opts.noDeps && opts.mergeOnly {
+				return errors.New("--no-deps can't be used with --merge-only")
+			}
+			if opts.mergeOnly {
+				opts.setMergeOnly()
+			}
 			return nil
 		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
+			if opts.services && opts.profiles {
+				return runServiceProfiles(ctx, dockerCli, opts, args)
+			}
 			if opts.services {
 				return runServices(ctx, dockerCli, opts)
 			}
@@ -152,12 +191,16 @@ func configCommand(p *ProjectOptions, dockerCli command.Cli) *cobra.Command {
 	flags.StringVar(&opts.Format, "format", "", "Format the output. Values: [yaml | json]")
 	flags.BoolVar(&opts.resolveImageDigests, "resolve-image-digests", false, "Pin image tags to digests")
 	flags.BoolVar(&opts.lockImageDigests, "lock-image-digests", false, "Produces an override file with image digests")
+	flags.BoolVar(&opts.noCache, "no-cache", false, "Resolve image digests from registries rather than from cache, refreshing cached digests")
+	flags.IntVar(&opts.digestsCacheTTL, "digests-cache-ttl", 0, "Duration in seconds resolved image digests are cached for. Caching is disabled when 0")
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only validate the configuration, don't print anything")
 	flags.BoolVar(&opts.noInterpolate, "no-interpolate", false, "Don't interpolate environment variables")
 	flags.BoolVar(&opts.noNormalize, "no-normalize", false, "Don't normalize compose model")
 	flags.BoolVar(&opts.noResolvePath, "no-path-resolution", false, "Don't resolve file paths")
 	flags.BoolVar(&opts.noConsistency, "no-consistency", false, "Don't check model consistency - warning: may produce invalid Compose output")
 	flags.BoolVar(&opts.noResolveEnv, "no-env-resolution", false, "Don't resolve service env files")
+	flags.BoolVar(&opts.mergeOnly, "merge-only", false, "Only merge Compose files, without interpolation, normalization nor path resolution")
+	flags.BoolVar(&opts.noDeps, "no-deps", false, "Only render selected services, without their dependencies")
 
 	flags.BoolVar(&opts.services, "services", false, "Print the service names, one per line.")
 	flags.BoolVar(&opts.volumes, "volumes", false, "Print the volume names, one per line.")
@@ -165,8 +208,9 @@ func configCommand(p *ProjectOptions, dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&opts.models, "models", false, "Print the model names, one per line.")
 	flags.BoolVar(&opts.profiles, "profiles", false, "Print the profile names, one per line.")
 	flags.BoolVar(&opts.images, "images", false, "Print the image names, one per line.")
-	flags.StringVar(&opts.hash, "hash", "", "Print the service config hash, one per line.")
+	flags.StringVar(&opts.hash, "hash", "", "Print the service config hash, one per line. Use \"*\" for all services, or a comma separated list of services.")
 	flags.BoolVar(&opts.variables, "variables", false, "Print model variables and default values.")
+	flags.BoolVar(&opts.strict, "strict", false, "With --variables, fail if a variable is not set and has no default value.")
 	flags.BoolVar(&opts.environment, "environment", false, "Print environment used for interpolation.")
 	flags.StringVarP(&opts.Output, "output", "o", "", "Save to file (default to stdout)")
 
@@ -213,8 +257,18 @@ func runConfigInterpolate(ctx context.Context, dockerCli command.Cli, opts confi
 		return nil, err
 	}
 
+	if opts.noDeps {
+		project, err = withoutDependencies(project, services)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if opts.resolveImageDigests {
-		project, err = project.WithImagesResolved(compose.ImageDigestResolver(ctx, dockerCli.ConfigFile(), dockerCli.Client()))
+		err = opts.withImageDigestResolver(ctx, dockerCli, func(resolver func(reference.Named) (digest.Digest, error)) error {
+			project, err = compose.ResolveImageDigests(project, resolver)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -253,6 +307,19 @@ func runConfigInterpolate(ctx context.Context, dockerCli command.Cli, opts confi
 	return content, nil
 }
 
+// withoutDependencies return project with only the selected services and the top-level resources they use, so it
+// can be used as a standalone Compose file
+func withoutDependencies(project *types.Project, services []string) (*types.Project, error) {
+	if len(services) == 0 {
+		return project, nil
+	}
+	project, err := project.WithSelectedServices(services, types.IgnoreDependencies)
+	if err != nil {
+		return nil, err
+	}
+	return project.WithoutUnnecessaryResources(), nil
+}
+
 // imagesOnly return project with all attributes removed but service.images
 func imagesOnly(project *types.Project) *types.Project {
 	digests := types.Services{}
@@ -273,7 +340,7 @@ func runConfigNoInterpolate(ctx context.Context, dockerCli command.Cli, opts con
 	}
 
 	if opts.resolveImageDigests {
-		err = resolveImageDigests(ctx, dockerCli, model)
+		err = resolveImageDigests(ctx, dockerCli, opts, model)
 		if err != nil {
 			return nil, err
 		}
@@ -299,7 +366,27 @@ func runConfigNoInterpolate(ctx context.Context, dockerCli command.Cli, opts con
 	return formatModel(model, opts.Format)
 }
 
-func resolveImageDigests(ctx context.Context, dockerCli command.Cli, model map[string]any) (err error) {
+// withImageDigestResolver runs fn with a resolver getting image digests from registries, through
+// the on-disk cache when enabled by a positive TTL
+func (opts configOptions) withImageDigestResolver(ctx context.Context, dockerCli command.Cli, fn func(resolver func(reference.Named) (digest.Digest, error)) error) error {
+	resolver := compose.ImageDigestResolver(ctx, dockerCli.ConfigFile(), dockerCli.Client())
+	if opts.digestsCacheTTL <= 0 {
+		return fn(resolver)
+	}
+	cache, err := compose.NewImageDigestsCache(time.Duration(opts.digestsCacheTTL)*time.Second, opts.noCache)
+	if err != nil {
+		return err
+	}
+	if err := fn(cache.Resolver(resolver)); err != nil {
+		return err
+	}
+	if err := cache.Save(); err != nil {
+		logrus.Warnf("failed to save image digests cache: %v", err)
+	}
+	return nil
+}
+
+func resolveImageDigests(ctx context.Context, dockerCli command.Cli, opts configOptions, model map[string]any) (err error) {
 	// create a pseudo-project so we can rely on WithImagesResolved to resolve images
 	p := &types.Project{
 		Services: types.Services{},
@@ -314,7 +401,10 @@ func resolveImageDigests(ctx context.Context, dockerCli command.Cli, model map[s
 		}
 	}
 
-	p, err = p.WithImagesResolved(compose.ImageDigestResolver(ctx, dockerCli.ConfigFile(), dockerCli.Client()))
+	err = opts.withImageDigestResolver(ctx, dockerCli, func(resolver func(reference.Named) (digest.Digest, error)) error {
+		p, err = compose.ResolveImageDigests(p, resolver)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -356,7 +446,7 @@ func runServices(ctx context.Context, dockerCli command.Cli, opts configOptions)
 		}
 
 		if _, ok := data["services"]; ok {
-			for serviceName := range data["services"].(map[string]any) {
+			for _, serviceName := range slices.Sorted(maps.Keys(data["services"].(map[string]any))) {
 				_, _ = fmt.Fprintln(dockerCli.Out(), serviceName)
 			}
 		}
@@ -391,7 +481,7 @@ func runVolumes(ctx context.Context, dockerCli command.Cli, opts configOptions)
 	if err != nil {
 		return err
 	}
-	for n := range project.Volumes {
+	for _, n := range slices.Sorted(maps.Keys(project.Volumes)) {
 		_, _ = fmt.Fprintln(dockerCli.Out(), n)
 	}
 	return nil
@@ -407,7 +497,7 @@ func runNetworks(ctx context.Context, dockerCli command.Cli, opts configOptions)
 	if err != nil {
 		return err
 	}
-	for n := range project.Networks {
+	for _, n := range slices.Sorted(maps.Keys(project.Networks)) {
 		_, _ = fmt.Fprintln(dockerCli.Out(), n)
 	}
 	return nil
@@ -423,18 +513,24 @@ func runModels(ctx context.Context, dockerCli command.Cli, opts configOptions) e
 	if err != nil {
 		return err
 	}
-	for _, model := range project.Models {
-		if model.Model != "" {
+	for _, name := range slices.Sorted(maps.Keys(project.Models)) {
+		if model := project.Models[name]; model.Model != "" {
 			_, _ = fmt.Fprintln(dockerCli.Out(), model.Model)
 		}
 	}
 	return nil
 }
 
+// runHash prints the config hash of services, as set by `up` on containers by the com.docker.compose.config-hash
+// label to detect services which need to be recreated
 func runHash(ctx context.Context, dockerCli command.Cli, opts configOptions) error {
 	var services []string
 	if opts.hash != "*" {
-		services = append(services, strings.Split(opts.hash, ",")...)
+		for _, name := range strings.Split(opts.hash, ",") {
+			if name = strings.TrimSpace(name); name != "" && !slices.Contains(services, name) {
+				services = append(services, name)
+			}
+		}
 	}
 
 	backend, err := compose.NewComposeService(dockerCli)
@@ -447,6 +543,12 @@ func runHash(ctx context.Context, dockerCli command.Cli, opts configOptions) err
 		return err
 	}
 
+	// resolve environment as commands creating containers do, so that env_file content is part of the hash
+	project, err = project.WithServicesEnvironmentResolved(true)
+	if err != nil {
+		return err
+	}
+
 	if err := applyPlatforms(project, true); err != nil {
 		return err
 	}
@@ -454,11 +556,9 @@ func runHash(ctx context.Context, dockerCli command.Cli, opts configOptions) err
 	if len(services) == 0 {
 		services = project.ServiceNames()
 	}
+	slices.Sort(services)
 
-	sorted := services
-	slices.Sort(sorted)
-
-	for _, name := range sorted {
+	for _, name := range services {
 		s, err := project.GetService(name)
 		if err != nil {
 			return err
@@ -501,6 +601,46 @@ func runProfiles(ctx context.Context, dockerCli command.Cli, opts configOptions,
 	return nil
 }
 
+// runServiceProfiles prints the profiles enabling each service, services without profiles being always enabled
+func runServiceProfiles(ctx context.Context, dockerCli command.Cli, opts configOptions, services []string) error {
+	backend, err := compose.NewComposeService(dockerCli)
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.ToProject(ctx, dockerCli, backend, services)
+	if err != nil {
+		return err
+	}
+
+	profiles := map[string][]string{}
+	for name, s := range project.AllServices() {
+		profiles[name] = slices.Sorted(slices.Values(s.Profiles))
+		if profiles[name] == nil {
+			profiles[name] = []string{}
+		}
+	}
+
+	if opts.Format == "yaml" {
+		result, err := yaml.Marshal(profiles)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(dockerCli.Out(), string(result))
+		return err
+	}
+
+	return formatter.Print(profiles, opts.Format, dockerCli.Out(), func(w io.Writer) {
+		for _, name := range slices.Sorted(maps.Keys(profiles)) {
+			enabledBy := "(always enabled)"
+			if len(profiles[name]) > 0 {
+				enabledBy = strings.Join(profiles[name], ", ")
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", name, enabledBy)
+		}
+	}, "SERVICE", "PROFILES")
+}
+
 func runConfigImages(ctx context.Context, dockerCli command.Cli, opts configOptions, services []string) error {
 	backend, err := compose.NewComposeService(dockerCli)
 	if err != nil {
@@ -512,12 +652,60 @@ func runConfigImages(ctx context.Context, dockerCli command.Cli, opts configOpti
 		return err
 	}
 
-	for _, s := range project.Services {
-		_, _ = fmt.Fprintln(dockerCli.Out(), api.GetImageNameOrDefault(s, project.Name))
+	for _, name := range project.ServiceNames() {
+		_, _ = fmt.Fprintln(dockerCli.Out(), api.GetImageNameOrDefault(project.Services[name], project.Name))
 	}
 	return nil
 }
 
+// variable describes a variable used for interpolation, and if it is set by the environment
+type variable struct {
+	template.Variable `yaml:",inline"`
+	Set               bool
+	// optional is set when all references to the variable have a default or alternate value operator
+	optional bool
+}
+
+// Missing tells if variable has no value, it isn't set and some references have no default value
+func (v variable) Missing() bool {
+	return !v.Set && !v.optional
+}
+
+// variableReference matches an escaped dollar sign or a variable reference, with the operator following the name
+// of a braced variable
+var variableReference = regexp.MustCompile(`\$\$|\$\{([_a-zA-Z][_a-zA-Z0-9]*)(:?[-+?])?|\$([_a-zA-Z][_a-zA-Z0-9]*)`)
+
+// collectOptionalVariables records, for variables referenced in value, if all references have a default or
+// alternate value operator. Such variables don't need to be set, even when the default value is empty.
+func collectOptionalVariables(value any, optional map[string]bool) {
+	switch value := value.(type) {
+	case string:
+		for _, match := range variableReference.FindAllStringSubmatch(value, -1) {
+			name, operator := match[1], match[2]
+			if name == "" {
+				name = match[3]
+			}
+			if name == "" {
+				// escaped dollar sign
+				continue
+			}
+			isOptional := strings.ContainsAny(operator, "-+")
+			if previous, ok := optional[name]; ok {
+				isOptional = isOptional && previous
+			}
+			optional[name] = isOptional
+		}
+	case map[string]any:
+		for _, elem := range value {
+			collectOptionalVariables(elem, optional)
+		}
+	case []any:
+		for _, elem := range value {
+			collectOptionalVariables(elem, optional)
+		}
+	}
+}
+
 func runVariables(ctx context.Context, dockerCli command.Cli, opts configOptions, services []string) error {
 	opts.noInterpolate = true
 	model, err := opts.ToModel(ctx, dockerCli, services, cli.WithoutEnvironmentResolution, cli.WithLoadOptions(loader.WithSkipValidation))
@@ -525,7 +713,26 @@ func runVariables(ctx context.Context, dockerCli command.Cli, opts configOptions
 		return err
 	}
 
-	variables := template.ExtractVariables(model, template.DefaultPattern)
+	// environment used for interpolation, including .env file
+	projectOptions, err := opts.toProjectOptions()
+	if err != nil {
+		return err
+	}
+
+	optional := map[string]bool{}
+	collectOptionalVariables(model, optional)
+	variables := map[string]variable{}
+	for name, v := range template.ExtractVariables(model, template.DefaultPattern) {
+		_, set := projectOptions.Environment[name]
+		variables[name] = variable{Variable: v, Set: set, optional: optional[name]}
+	}
+
+	var missing []string
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		if variables[name].Missing() {
+			missing = append(missing, name)
+		}
+	}
 
 	if opts.Format == "yaml" {
 		result, err := yaml.Marshal(variables)
@@ -533,14 +740,22 @@ func runVariables(ctx context.Context, dockerCli command.Cli, opts configOptions
 			return err
 		}
 		fmt.Print(string(result))
-		return nil
+	} else {
+		err = formatter.Print(variables, opts.Format, dockerCli.Out(), func(w io.Writer) {
+			for _, name := range slices.Sorted(maps.Keys(variables)) {
+				v := variables[name]
+				_, _ = fmt.Fprintf(w, "%s\t%t\t%s\t%s\t%t\n", name, v.Required, v.DefaultValue, v.PresenceValue, v.Set)
+			}
+		}, "NAME", "REQUIRED", "DEFAULT VALUE", "ALTERNATE VALUE", "SET")
+		if err != nil {
+			return err
+		}
 	}
 
-	return formatter.Print(variables, opts.Format, dockerCli.Out(), func(w io.Writer) {
-		for name, variable := range variables {
-			_, _ = fmt.Fprintf(w, "%s\t%t\t%s\t%s\n", name, variable.Required, variable.DefaultValue, variable.PresenceValue)
-		}
-	}, "NAME", "REQUIRED", "DEFAULT VALUE", "ALTERNATE VALUE")
+	if opts.strict && len(missing) > 0 {
+		return fmt.Errorf("variables are not set and have no default value: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func runEnvironment(ctx context.Context, dockerCli command.Cli, opts configOptions, services []string) error {
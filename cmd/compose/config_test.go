@@ -0,0 +1,344 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/cli/cli/streams"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/mocks"
+)
+
+func TestFormatModelOrdering(t *testing.T) {
+	model := map[string]any{
+		"volumes": map[string]any{"data": map[string]any{}},
+		"services": map[string]any{
+			"web": map[string]any{
+				"image":       "nginx",
+				"environment": map[string]any{"ZED": "1", "ALPHA": "2"},
+				"command":     []any{"serve", "--port", "80"},
+			},
+			"db": map[string]any{"image": "postgres"},
+		},
+	}
+
+	content, err := formatModel(model, "yaml")
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), `services:
+  db:
+    image: postgres
+  web:
+    command:
+      - serve
+      - --port
+      - "80"
+    environment:
+      ALPHA: "2"
+      ZED: "1"
+    image: nginx
+volumes:
+  data: {}
+`)
+
+	content, err = formatModel(model, "json")
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), `{
+  "services": {
+    "db": {
+      "image": "postgres"
+    },
+    "web": {
+      "command": [
+        "serve",
+        "--port",
+        "80"
+      ],
+      "environment": {
+        "ALPHA": "2",
+        "ZED": "1"
+      },
+      "image": "nginx"
+    }
+  },
+  "volumes": {
+    "data": {}
+  }
+}`)
+
+	_, err = formatModel(model, "toml")
+	assert.Error(t, err, `unsupported format "toml"`)
+}
+
+func TestWithoutDependencies(t *testing.T) {
+	project := &types.Project{
+		Name: "test",
+		Services: types.Services{
+			"web": {
+				Name:      "web",
+				DependsOn: types.DependsOnConfig{"db": {Condition: types.ServiceConditionStarted}},
+				Networks:  map[string]*types.ServiceNetworkConfig{"front": nil},
+				Volumes:   []types.ServiceVolumeConfig{{Type: types.VolumeTypeVolume, Source: "data", Target: "/data"}},
+				Secrets:   []types.ServiceSecretConfig{{Source: "token"}},
+			},
+			"db": {
+				Name:     "db",
+				Networks: map[string]*types.ServiceNetworkConfig{"back": nil},
+				Volumes:  []types.ServiceVolumeConfig{{Type: types.VolumeTypeVolume, Source: "dbdata", Target: "/var/lib"}},
+			},
+		},
+		Networks: types.Networks{"front": {}, "back": {}},
+		Volumes:  types.Volumes{"data": {}, "dbdata": {}},
+		Secrets:  types.Secrets{"token": {File: "./token"}, "other": {File: "./other"}},
+	}
+
+	unchanged, err := withoutDependencies(project, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(unchanged.Services), 2)
+
+	fragment, err := withoutDependencies(project, []string{"web"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fragment.ServiceNames(), []string{"web"})
+	assert.Equal(t, len(fragment.Services["web"].DependsOn), 0)
+	assert.DeepEqual(t, slices.Sorted(maps.Keys(fragment.Networks)), []string{"front"})
+	_, hasData := fragment.Volumes["data"]
+	_, hasDBData := fragment.Volumes["dbdata"]
+	assert.Assert(t, hasData && !hasDBData)
+	_, hasToken := fragment.Secrets["token"]
+	_, hasOther := fragment.Secrets["other"]
+	assert.Assert(t, hasToken && !hasOther)
+
+	_, err = withoutDependencies(project, []string{"unknown"})
+	assert.ErrorContains(t, err, "no such service")
+}
+
+func TestConfigMergeOnly(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "compose.yaml")
+	assert.NilError(t, os.WriteFile(base, []byte(`services:
+  web:
+    image: nginx
+    secrets: [token]
+secrets:
+  token:
+    file: ./token
+`), 0o600))
+	override := filepath.Join(dir, "compose.override.yaml")
+	assert.NilError(t, os.WriteFile(override, []byte(`services:
+  web:
+    environment:
+      FOO: ${FOO:-bar}
+`), 0o600))
+
+	opts := configOptions{
+		ProjectOptions: &ProjectOptions{
+			ConfigPaths: []string{base, override},
+			Offline:     true,
+		},
+		Format: "yaml",
+	}
+	opts.setMergeOnly()
+
+	content, err := runConfigNoInterpolate(t.Context(), nil, opts, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), `secrets:
+  token:
+    file: ./token
+services:
+  web:
+    environment:
+      FOO: ${FOO:-bar}
+    image: nginx
+    secrets:
+      - source: token
+`)
+}
+
+func TestConfigNoDepsConflicts(t *testing.T) {
+	for flag, expected := range map[string]string{
+		"--no-interpolate": "--no-deps can't be used with --no-interpolate",
+		"--merge-only":     "--no-deps can't be used with --merge-only",
+	} {
+		t.Run(flag, func(t *testing.T) {
+			cmd := configCommand(&ProjectOptions{}, nil)
+			cmd.SetContext(t.Context())
+			assert.NilError(t, cmd.ParseFlags([]string{"--no-deps", flag}))
+			assert.Error(t, cmd.PreRunE(cmd, []string{"web"}), expected)
+		})
+	}
+}
+
+func TestRunVariablesReportsMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+name: variables
+services:
+  web:
+    image: nginx:${TAG:-latest}
+    environment:
+      SET: ${SET_VAR}
+      MISSING: ${MISSING_VAR}
+      EMPTY: ${EMPTY_DEFAULT:-}
+      UNSET: ${UNSET_DEFAULT-}
+      ALTERNATE: ${ALTERNATE_VAR:+x}
+      PARTIAL: ${PARTIAL_VAR:-default} ${PARTIAL_VAR}
+`), 0o600))
+	t.Setenv("SET_VAR", "1")
+
+	buf := new(bytes.Buffer)
+	cli := mocks.NewMockCli(ctrl)
+	cli.EXPECT().Out().Return(streams.NewOut(buf)).AnyTimes()
+
+	opts := configOptions{
+		Format: "table",
+		ProjectOptions: &ProjectOptions{
+			ConfigPaths: []string{composePath},
+			ProjectDir:  dir,
+		},
+	}
+	assert.NilError(t, runVariables(t.Context(), cli, opts, nil))
+	var rows [][]string
+	for line := range strings.Lines(buf.String()) {
+		rows = append(rows, strings.Fields(line))
+	}
+	assert.DeepEqual(t, rows, [][]string{
+		{"NAME", "REQUIRED", "DEFAULT", "VALUE", "ALTERNATE", "VALUE", "SET"},
+		{"ALTERNATE_VAR", "false", "x", "false"},
+		{"EMPTY_DEFAULT", "false", "false"},
+		{"MISSING_VAR", "false", "false"},
+		{"PARTIAL_VAR", "false", "default", "false"},
+		{"SET_VAR", "false", "true"},
+		{"TAG", "false", "latest", "false"},
+		{"UNSET_DEFAULT", "false", "false"},
+	})
+
+	buf.Reset()
+	opts.strict = true
+	err := runVariables(t.Context(), cli, opts, nil)
+	// variables with an empty default value or an alternate value don't need to be set
+	assert.Error(t, err, "variables are not set and have no default value: MISSING_VAR, PARTIAL_VAR")
+}
+
+func TestRunServiceProfiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+name: profiles
+services:
+  web:
+    image: nginx
+  debug:
+    image: busybox
+    profiles: [tools, debug]
+`), 0o600))
+
+	buf := new(bytes.Buffer)
+	cli := mocks.NewMockCli(ctrl)
+	cli.EXPECT().Out().Return(streams.NewOut(buf)).AnyTimes()
+	cli.EXPECT().Err().Return(streams.NewOut(os.Stderr)).AnyTimes()
+
+	opts := configOptions{
+		Format: "json",
+		ProjectOptions: &ProjectOptions{
+			ConfigPaths: []string{composePath},
+			ProjectDir:  dir,
+			Offline:     true,
+		},
+	}
+	assert.NilError(t, runServiceProfiles(t.Context(), cli, opts, nil))
+
+	var profiles map[string][]string
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &profiles))
+	assert.DeepEqual(t, profiles, map[string][]string{
+		"web":   {},
+		"debug": {"debug", "tools"},
+	})
+}
+
+func TestRunHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+name: hash
+services:
+  web:
+    image: nginx
+    env_file: web.env
+  db:
+    image: postgres
+  cache:
+    image: redis
+`), 0o600))
+	envPath := filepath.Join(dir, "web.env")
+	assert.NilError(t, os.WriteFile(envPath, []byte("FOO=1\n"), 0o600))
+
+	buf := new(bytes.Buffer)
+	cli := mocks.NewMockCli(ctrl)
+	cli.EXPECT().Out().Return(streams.NewOut(buf)).AnyTimes()
+	cli.EXPECT().Err().Return(streams.NewOut(os.Stderr)).AnyTimes()
+
+	opts := configOptions{
+		hash: "web, db",
+		ProjectOptions: &ProjectOptions{
+			ConfigPaths: []string{composePath},
+			ProjectDir:  dir,
+			Offline:     true,
+		},
+	}
+	hashes := func() map[string]string {
+		buf.Reset()
+		assert.NilError(t, runHash(t.Context(), cli, opts))
+		result := map[string]string{}
+		for line := range strings.Lines(buf.String()) {
+			name, hash, _ := strings.Cut(strings.TrimSpace(line), " ")
+			result[name] = hash
+		}
+		return result
+	}
+
+	before := hashes()
+	assert.DeepEqual(t, slices.Sorted(maps.Keys(before)), []string{"db", "web"})
+
+	// env_file content is part of the container configuration, so changing it must change the hash
+	assert.NilError(t, os.WriteFile(envPath, []byte("FOO=2\n"), 0o600))
+	after := hashes()
+	assert.Equal(t, after["db"], before["db"])
+	assert.Assert(t, after["web"] != before["web"])
+
+	opts.hash = "unknown"
+	assert.ErrorContains(t, runHash(t.Context(), cli, opts), "unknown")
+}
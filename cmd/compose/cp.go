@@ -45,7 +45,8 @@ func copyCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	}
 	copyCmd := &cobra.Command{
 		Use: `cp [OPTIONS] SERVICE:SRC_PATH DEST_PATH|-
-	docker compose cp [OPTIONS] SRC_PATH|- SERVICE:DEST_PATH`,
+	docker compose cp [OPTIONS] SRC_PATH|- SERVICE:DEST_PATH
+	docker compose cp [OPTIONS] SERVICE:SRC_PATH SERVICE:DEST_PATH`,
 		Short: "Copy files/folders between a service container and the local filesystem",
 		Args:  cli.ExactArgs(2),
 		PreRunE: Adapt(func(ctx context.Context, args []string) error {
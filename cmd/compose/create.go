@@ -42,14 +42,19 @@ type createOptions struct {
 	removeOrphans bool
 	ignoreOrphans bool
 	forceRecreate bool
-	noRecreate    bool
-	recreateDeps  bool
-	noInherit     bool
-	timeChanged   bool
-	timeout       int
-	quietPull     bool
-	scale         []string
-	AssumeYes     bool
+	// forceRecreateServices restricts --force-recreate to the listed services
+	forceRecreateServices []string
+	noRecreate            bool
+	recreateDeps          bool
+	noInherit             bool
+	// renewAnonVolumesServices restricts --renew-anon-volumes to the listed services
+	renewAnonVolumesServices []string
+	timeChanged              bool
+	timeout                  int
+	quietPull                bool
+	locked                   bool
+	scale                    []string
+	AssumeYes                bool
 }
 
 func createCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -65,9 +70,12 @@ func createCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Bac
 			if opts.Build && opts.noBuild {
 				return fmt.Errorf("--build and --no-build are incompatible")
 			}
-			if opts.forceRecreate && opts.noRecreate {
+			if opts.isForceRecreate() && opts.noRecreate {
 				return fmt.Errorf("--force-recreate and --no-recreate are incompatible")
 			}
+			if opts.isRenewAnonVolumes() && opts.noRecreate {
+				return fmt.Errorf("--no-recreate and --renew-anon-volumes are incompatible")
+			}
 			return nil
 		}),
 		RunE: p.WithServices(dockerCli, func(ctx context.Context, project *types.Project, services []string) error {
@@ -80,8 +88,10 @@ func createCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Bac
 	flags.BoolVar(&opts.noBuild, "no-build", false, "Don't build an image, even if it's policy")
 	flags.StringVar(&opts.Pull, "pull", "policy", `Pull image before running ("always"|"missing"|"never"|"build")`)
 	flags.BoolVar(&opts.quietPull, "quiet-pull", false, "Pull without printing progress information")
-	flags.BoolVar(&opts.forceRecreate, "force-recreate", false, "Recreate containers even if their configuration and image haven't changed")
+	flags.BoolVar(&opts.locked, "locked", false, "Verify images match the digests recorded in "+compose.ImagesLockFile)
+	opts.addForceRecreateFlag(flags)
 	flags.BoolVar(&opts.noRecreate, "no-recreate", false, "If containers already exist, don't recreate them. Incompatible with --force-recreate.")
+	opts.addRenewAnonVolumesFlag(flags)
 	flags.BoolVar(&opts.removeOrphans, "remove-orphans", false, "Remove containers for services not defined in the Compose file")
 	flags.StringArrayVar(&opts.scale, "scale", []string{}, "Scale SERVICE to NUM instances. Overrides the `scale` setting in the Compose file if present.")
 	flags.BoolVarP(&opts.AssumeYes, "yes", "y", false, `Assume "yes" as answer to all prompts and run non-interactively`)
@@ -114,6 +124,15 @@ func runCreate(ctx context.Context, dockerCli command.Cli, backendOptions *Backe
 		backendOptions.Options = append(backendOptions.Options, compose.WithPrompt(compose.AlwaysOkPrompt()))
 	}
 
+	var lock api.ImagesLock
+	if createOpts.locked {
+		l, err := loadImagesLock(project)
+		if err != nil {
+			return err
+		}
+		lock = l
+	}
+
 	backend, err := compose.NewComposeService(dockerCli, backendOptions.Options...)
 	if err != nil {
 		return err
@@ -125,12 +144,97 @@ func runCreate(ctx context.Context, dockerCli command.Cli, backendOptions *Backe
 		IgnoreOrphans:        createOpts.ignoreOrphans,
 		Recreate:             createOpts.recreateStrategy(),
 		RecreateDependencies: createOpts.dependenciesRecreateStrategy(),
+		ForceRecreate:        createOpts.forceRecreateServices,
+		RenewAnonVolumes:     createOpts.renewAnonVolumes(project, services),
 		Inherit:              !createOpts.noInherit,
 		Timeout:              createOpts.GetTimeout(),
 		QuietPull:            createOpts.quietPull,
+		ImagesLock:           lock,
 	})
 }
 
+// addForceRecreateFlag declares --force-recreate, which can be set without value to recreate all services, or to a
+// comma separated list of services to only recreate those
+func (opts *createOptions) addForceRecreateFlag(flags *pflag.FlagSet) {
+	addBoolOrServicesFlag(flags, "force-recreate", "", &opts.forceRecreate, &opts.forceRecreateServices,
+		"Recreate containers even if their configuration and image haven't changed. Set to a comma separated list of services to only recreate those")
+}
+
+// addRenewAnonVolumesFlag declares --renew-anon-volumes, which can be set without value to renew anonymous volumes
+// of all services, or to a comma separated list of services to only renew those
+func (opts *createOptions) addRenewAnonVolumesFlag(flags *pflag.FlagSet) {
+	addBoolOrServicesFlag(flags, "renew-anon-volumes", "V", &opts.noInherit, &opts.renewAnonVolumesServices,
+		"Recreate anonymous volumes instead of retrieving data from the previous containers. Set to a comma separated list of services to only renew those")
+}
+
+func (opts createOptions) isForceRecreate() bool {
+	return opts.forceRecreate || len(opts.forceRecreateServices) > 0
+}
+
+func (opts createOptions) isRenewAnonVolumes() bool {
+	return opts.noInherit || len(opts.renewAnonVolumesServices) > 0
+}
+
+// renewAnonVolumes returns the services to be recreated with new anonymous volumes, being the selected ones, or
+// all services if none is selected, when --renew-anon-volumes is set without value
+func (opts createOptions) renewAnonVolumes(project *types.Project, services []string) []string {
+	switch {
+	case len(opts.renewAnonVolumesServices) > 0:
+		return opts.renewAnonVolumesServices
+	case !opts.noInherit:
+		return nil
+	case len(services) > 0:
+		return services
+	default:
+		return project.ServiceNames()
+	}
+}
+
+func addBoolOrServicesFlag(flags *pflag.FlagSet, name, shorthand string, all *bool, services *[]string, usage string) {
+	flags.VarP(&boolOrServicesValue{all: all, services: services}, name, shorthand, usage)
+	flags.Lookup(name).NoOptDefVal = "true"
+}
+
+// boolOrServicesValue is a pflag.Value accepting either a boolean or a list of services
+type boolOrServicesValue struct {
+	all      *bool
+	services *[]string
+}
+
+func (v *boolOrServicesValue) String() string {
+	if len(*v.services) > 0 {
+		return strings.Join(*v.services, ",")
+	}
+	return strconv.FormatBool(*v.all)
+}
+
+func (v *boolOrServicesValue) Set(s string) error {
+	if b, err := strconv.ParseBool(s); err == nil {
+		*v.all = b
+		*v.services = nil
+		return nil
+	}
+	*v.all = false
+	*v.services = appendServiceNames(*v.services, s)
+	return nil
+}
+
+func (v *boolOrServicesValue) Type() string {
+	return "string"
+}
+
+// appendServiceNames appends the comma-separated service names from values to names, skipping blanks and duplicates
+func appendServiceNames(names []string, values ...string) []string {
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
 func (opts createOptions) recreateStrategy() string {
 	if opts.noRecreate {
 		return api.RecreateNever
@@ -189,6 +293,17 @@ func (opts createOptions) Apply(project *types.Project) error {
 		return err
 	}
 
+	for _, name := range opts.forceRecreateServices {
+		if _, err := project.GetService(name); err != nil {
+			return fmt.Errorf("invalid --force-recreate option: %w", err)
+		}
+	}
+	for _, name := range opts.renewAnonVolumesServices {
+		if _, err := project.GetService(name); err != nil {
+			return fmt.Errorf("invalid --renew-anon-volumes option: %w", err)
+		}
+	}
+
 	err := applyScaleOpts(project, opts.scale)
 	if err != nil {
 		return err
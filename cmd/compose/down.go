@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/docker/cli/cli/command"
 	"github.com/sirupsen/logrus"
@@ -34,11 +33,14 @@ import (
 
 type downOptions struct {
 	*ProjectOptions
-	removeOrphans bool
-	timeChanged   bool
-	timeout       int
-	volumes       bool
-	images        string
+	removeOrphans         bool
+	timeChanged           bool
+	timeout               int
+	volumes               bool
+	images                string
+	removeExternalVolumes bool
+	assumeYes             bool
+	abortOnProvider       bool
 }
 
 func downCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -67,6 +69,9 @@ func downCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	flags.BoolVar(&opts.removeOrphans, "remove-orphans", removeOrphans, "Remove containers for services not defined in the Compose file")
 	flags.IntVarP(&opts.timeout, "timeout", "t", 0, "Specify a shutdown timeout in seconds")
 	flags.BoolVarP(&opts.volumes, "volumes", "v", false, `Remove named volumes declared in the "volumes" section of the Compose file and anonymous volumes attached to containers`)
+	flags.BoolVar(&opts.removeExternalVolumes, "remove-external-volumes", false, `Remove volumes declared as external in the Compose file, unless used by another project`)
+	flags.BoolVarP(&opts.assumeYes, "yes", "y", false, `Assume "yes" as answer to all prompts and run non-interactively`)
+	flags.BoolVar(&opts.abortOnProvider, "abort-on-provider-failure", false, "Stop the teardown when a provider service fails to be removed")
 	flags.StringVar(&opts.images, "rmi", "", `Remove images used by services. "local" remove only images that don't have a custom tag ("local"|"all")`)
 	flags.SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
 		if name == "volume" {
@@ -84,21 +89,21 @@ func runDown(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 		return err
 	}
 
-	var timeout *time.Duration
-	if opts.timeChanged {
-		timeoutValue := time.Duration(opts.timeout) * time.Second
-		timeout = &timeoutValue
+	if opts.assumeYes {
+		backendOptions.Options = append(backendOptions.Options, compose.WithPrompt(compose.AlwaysOkPrompt()))
 	}
 	backend, err := compose.NewComposeService(dockerCli, backendOptions.Options...)
 	if err != nil {
 		return err
 	}
 	return backend.Down(ctx, name, api.DownOptions{
-		RemoveOrphans: opts.removeOrphans,
-		Project:       project,
-		Timeout:       timeout,
-		Images:        opts.images,
-		Volumes:       opts.volumes,
-		Services:      services,
+		RemoveOrphans:          opts.removeOrphans,
+		Project:                project,
+		Timeout:                optionalTimeout(opts.timeout, opts.timeChanged),
+		Images:                 opts.images,
+		Volumes:                opts.volumes,
+		Services:               services,
+		RemoveExternalVolumes:  opts.removeExternalVolumes,
+		AbortOnProviderFailure: opts.abortOnProvider,
 	})
 }
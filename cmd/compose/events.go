@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/docker/cli/cli/command"
 	"github.com/spf13/cobra"
@@ -30,9 +32,10 @@ import (
 
 type eventsOpts struct {
 	*composeOptions
-	json  bool
-	since string
-	until string
+	json    bool
+	since   string
+	until   string
+	filters []string
 }
 
 func eventsCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -44,6 +47,10 @@ func eventsCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Bac
 	cmd := &cobra.Command{
 		Use:   "events [OPTIONS] [SERVICE...]",
 		Short: "Receive real time events from containers",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			_, err := parseEventFilters(opts.filters)
+			return err
+		},
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runEvents(ctx, dockerCli, backendOptions, opts, args)
 		}),
@@ -53,6 +60,7 @@ func eventsCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Bac
 	cmd.Flags().BoolVar(&opts.json, "json", false, "Output events as a stream of json objects")
 	cmd.Flags().StringVar(&opts.since, "since", "", "Show all events created since timestamp")
 	cmd.Flags().StringVar(&opts.until, "until", "", "Stream events until this timestamp")
+	cmd.Flags().StringArrayVar(&opts.filters, "filter", []string{}, "Filter events based on conditions provided (e.g. type=health_status, service=web)")
 	return cmd
 }
 
@@ -62,6 +70,11 @@ func runEvents(ctx context.Context, dockerCli command.Cli, backendOptions *Backe
 		return err
 	}
 
+	filters, err := parseEventFilters(opts.filters)
+	if err != nil {
+		return err
+	}
+
 	backend, err := compose.NewComposeService(dockerCli, backendOptions.Options...)
 	if err != nil {
 		return err
@@ -71,6 +84,9 @@ func runEvents(ctx context.Context, dockerCli command.Cli, backendOptions *Backe
 		Since:    opts.since,
 		Until:    opts.until,
 		Consumer: func(event api.Event) error {
+			if !filters.match(event) {
+				return nil
+			}
 			if opts.json {
 				marshal, err := json.Marshal(map[string]any{
 					"time":       event.Timestamp,
@@ -91,3 +107,37 @@ func runEvents(ctx context.Context, dockerCli command.Cli, backendOptions *Backe
 		},
 	})
 }
+
+// eventFilterKeys are the keys supported by `--filter`
+var eventFilterKeys = []string{"service", "type"}
+
+// eventFilters holds the values set by `--filter`, by key. Values for the same key are OR'ed,
+// distinct keys are AND'ed.
+type eventFilters map[string][]string
+
+func parseEventFilters(values []string) (eventFilters, error) {
+	filters := eventFilters{}
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid filter %q, expected key=value", value)
+		}
+		if !slices.Contains(eventFilterKeys, key) {
+			return nil, fmt.Errorf("invalid filter key %q, supported keys are: %s", key, strings.Join(eventFilterKeys, ", "))
+		}
+		filters[key] = append(filters[key], val)
+	}
+	return filters, nil
+}
+
+func (f eventFilters) match(event api.Event) bool {
+	if services, ok := f["service"]; ok && !slices.Contains(services, event.Service) {
+		return false
+	}
+	// actions can have details set after a colon, e.g. `health_status: healthy`
+	eventType, _, _ := strings.Cut(event.Status, ":")
+	if types, ok := f["type"]; ok && !slices.Contains(types, eventType) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestParseEventFilters(t *testing.T) {
+	filters, err := parseEventFilters([]string{"type=start", "service=web", "type=health_status"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, filters, eventFilters{
+		"type":    {"start", "health_status"},
+		"service": {"web"},
+	})
+
+	_, err = parseEventFilters([]string{"container=web-1"})
+	assert.Error(t, err, `invalid filter key "container", supported keys are: service, type`)
+
+	_, err = parseEventFilters([]string{"type"})
+	assert.Error(t, err, `invalid filter "type", expected key=value`)
+}
+
+func TestEventFiltersMatch(t *testing.T) {
+	filters := eventFilters{
+		"type":    {"start", "health_status"},
+		"service": {"web"},
+	}
+	tests := []struct {
+		event    api.Event
+		expected bool
+	}{
+		{event: api.Event{Service: "web", Status: "start"}, expected: true},
+		{event: api.Event{Service: "web", Status: "health_status: healthy"}, expected: true},
+		{event: api.Event{Service: "web", Status: "die"}, expected: false},
+		{event: api.Event{Service: "db", Status: "start"}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.event.Service+" "+tt.event.Status, func(t *testing.T) {
+			assert.Equal(t, filters.match(tt.event), tt.expected)
+		})
+	}
+
+	assert.Assert(t, eventFilters{}.match(api.Event{Service: "db", Status: "die"}))
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/cli/cli"
@@ -39,6 +40,7 @@ type execOpts struct {
 	service     string
 	command     []string
 	environment []string
+	envFiles    []string
 	workingDir  string
 
 	noTty       bool
@@ -80,11 +82,12 @@ func execCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 
 	runCmd.Flags().BoolVarP(&opts.detach, "detach", "d", false, "Detached mode: Run command in the background")
 	runCmd.Flags().StringArrayVarP(&opts.environment, "env", "e", []string{}, "Set environment variables")
+	runCmd.Flags().StringArrayVar(&opts.envFiles, "env-from-file", []string{}, "Set environment variables from file")
 	runCmd.Flags().IntVar(&opts.index, "index", 0, "Index of the container if service has multiple replicas")
 	runCmd.Flags().BoolVarP(&opts.privileged, "privileged", "", false, "Give extended privileges to the process")
 	runCmd.Flags().StringVarP(&opts.user, "user", "u", "", "Run the command as this user")
 	runCmd.Flags().BoolVarP(&opts.noTty, "no-tty", "T", !dockerCli.Out().IsTerminal(), "Disable pseudo-TTY allocation. By default 'docker compose exec' allocates a TTY.")
-	runCmd.Flags().StringVarP(&opts.workingDir, "workdir", "w", "", "Path to workdir directory for this command")
+	runCmd.Flags().StringVarP(&opts.workingDir, "workdir", "w", "", "Path to workdir directory for this command, a leading ~ is expanded to the user home directory")
 
 	runCmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", true, "Keep STDIN open even if not attached")
 	runCmd.Flags().MarkHidden("interactive") //nolint:errcheck
@@ -101,6 +104,23 @@ func execCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	return runCmd
 }
 
+// execEnvironment returns the environment variables of the exec process. Like `docker exec`, a variable set by
+// `--env` without a value which can't be resolved is passed through unchanged, unless an env file sets it.
+func execEnvironment(environment []string, envFiles []string, resolve func(string) (string, bool)) ([]string, error) {
+	env, err := loadEnvironment(environment, envFiles, resolve)
+	if err != nil {
+		return nil, err
+	}
+	values := env.Values()
+	for k, v := range types.NewMappingWithEquals(environment).Resolve(resolve) {
+		if _, ok := env[k]; v == nil && !ok {
+			values = append(values, k)
+		}
+	}
+	slices.Sort(values)
+	return values, nil
+}
+
 func runExec(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOptions, opts execOpts) error {
 	projectName, err := opts.toProjectName(ctx, dockerCli)
 	if err != nil {
@@ -114,10 +134,14 @@ func runExec(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 		v, ok := projectOptions.Environment[k]
 		return v, ok
 	}
+	environment, err := execEnvironment(opts.environment, opts.envFiles, lookupFn)
+	if err != nil {
+		return err
+	}
 	execOpts := api.RunOptions{
 		Service:     opts.service,
 		Command:     opts.command,
-		Environment: compose.ToMobyEnv(types.NewMappingWithEquals(opts.environment).Resolve(lookupFn)),
+		Environment: environment,
 		Tty:         !opts.noTty,
 		User:        opts.user,
 		Privileged:  opts.privileged,
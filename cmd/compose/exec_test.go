@@ -0,0 +1,40 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestExecEnvironment(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "exec.env")
+	assert.NilError(t, os.WriteFile(file, []byte("FROM_FILE=file\n"), 0o600))
+
+	lookup := func(k string) (string, bool) {
+		if k == "HOST" {
+			return "from-host", true
+		}
+		return "", false
+	}
+	env, err := execEnvironment([]string{"FOO=bar", "HOST", "UNSET", "FROM_FILE"}, []string{file}, lookup)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, env, []string{"FOO=bar", "FROM_FILE=file", "HOST=from-host", "UNSET"})
+}
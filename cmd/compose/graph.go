@@ -0,0 +1,82 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/compose/v5/pkg/compose"
+)
+
+type graphOptions struct {
+	*ProjectOptions
+	format string
+}
+
+func graphCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
+	opts := graphOptions{
+		ProjectOptions: p,
+	}
+	cmd := &cobra.Command{
+		Use:   "graph [OPTIONS]",
+		Short: "Print the dependency graph of services, networks and volumes",
+		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			switch opts.format {
+			case api.VizFormatDot, api.VizFormatMermaid:
+				return nil
+			default:
+				return fmt.Errorf("unsupported format %q", opts.format)
+			}
+		}),
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			return runGraph(ctx, dockerCli, backendOptions, opts)
+		}),
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noCompletion(),
+	}
+	cmd.Flags().StringVar(&opts.format, "format", api.VizFormatDot, "Format the output. Values: [dot | mermaid]")
+	return cmd
+}
+
+func runGraph(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOptions, opts graphOptions) error {
+	backend, err := compose.NewComposeService(dockerCli, backendOptions.Options...)
+	if err != nil {
+		return err
+	}
+
+	project, _, err := opts.ToProject(ctx, dockerCli, backend, nil)
+	if err != nil {
+		return err
+	}
+
+	graph, err := backend.Viz(ctx, project, api.VizOptions{
+		IncludeResources:  true,
+		IncludeConditions: true,
+		Indentation:       "    ",
+		Format:            opts.format,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(dockerCli.Out(), graph)
+	return err
+}
@@ -97,8 +97,10 @@ func runImages(ctx context.Context, dockerCli command.Cli, backendOptions *Backe
 		type img struct {
 			ID            string     `json:"ID"`
 			ContainerName string     `json:"ContainerName"`
+			Service       string     `json:"Service"`
 			Repository    string     `json:"Repository"`
 			Tag           string     `json:"Tag"`
+			Digest        string     `json:"Digest"`
 			Platform      string     `json:"Platform"`
 			Size          int64      `json:"Size"`
 			Created       *time.Time `json:"Created,omitempty"`
@@ -106,13 +108,16 @@ func runImages(ctx context.Context, dockerCli command.Cli, backendOptions *Backe
 		}
 		// Convert map to slice
 		var imageList []img
-		for ctr, i := range images {
+		for _, ctr := range slices.Sorted(maps.Keys(images)) {
+			i := images[ctr]
 			lastTagTime := i.LastTagTime
 			imageList = append(imageList, img{
 				ContainerName: ctr,
 				ID:            i.ID,
+				Service:       i.Service,
 				Repository:    i.Repository,
 				Tag:           i.Tag,
+				Digest:        i.Digest,
 				Platform:      platforms.Format(i.Platform),
 				Size:          i.Size,
 				Created:       i.Created,
@@ -145,9 +150,9 @@ func runImages(ctx context.Context, dockerCli command.Cli, backendOptions *Backe
 				if img.Created != nil {
 					created = units.HumanDuration(time.Now().UTC().Sub(*img.Created)) + " ago"
 				}
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-					container, repo, tag, platforms.Format(img.Platform), id, size, created)
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					container, repo, tag, platforms.Format(img.Platform), id, img.Digest, size, created)
 			}
 		},
-		"CONTAINER", "REPOSITORY", "TAG", "PLATFORM", "IMAGE ID", "SIZE", "CREATED")
+		"CONTAINER", "REPOSITORY", "TAG", "PLATFORM", "IMAGE ID", "DIGEST", "SIZE", "CREATED")
 }
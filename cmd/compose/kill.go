@@ -33,6 +33,9 @@ type killOptions struct {
 	*ProjectOptions
 	removeOrphans bool
 	signal        string
+	remove        bool
+	volumes       bool
+	index         int
 }
 
 func killCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -42,6 +45,15 @@ func killCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	cmd := &cobra.Command{
 		Use:   "kill [OPTIONS] [SERVICE...]",
 		Short: "Force stop service containers",
+		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			if opts.volumes && !opts.remove {
+				return errors.New("--volumes requires --remove")
+			}
+			if opts.index > 0 && len(args) != 1 {
+				return errors.New("--index requires one service to be selected")
+			}
+			return nil
+		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runKill(ctx, dockerCli, backendOptions, opts, args)
 		}),
@@ -52,6 +64,9 @@ func killCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	removeOrphans := utils.StringToBool(os.Getenv(ComposeRemoveOrphans))
 	flags.BoolVar(&opts.removeOrphans, "remove-orphans", removeOrphans, "Remove containers for services not defined in the Compose file")
 	flags.StringVarP(&opts.signal, "signal", "s", "SIGKILL", "SIGNAL to send to the container")
+	flags.BoolVar(&opts.remove, "remove", false, "Remove containers once they have exited")
+	flags.BoolVarP(&opts.volumes, "volumes", "v", false, "Remove any anonymous volumes attached to removed containers")
+	flags.IntVar(&opts.index, "index", 0, "Index of the container if service has multiple replicas")
 
 	return cmd
 }
@@ -68,6 +83,9 @@ func runKill(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 			Project:       project,
 			Services:      services,
 			Signal:        opts.signal,
+			Remove:        opts.remove,
+			RemoveVolumes: opts.volumes,
+			Index:         opts.index,
 		})
 		if errors.Is(err, api.ErrNoResources) {
 			_, _ = fmt.Fprintln(stdinfo(dockerCli), "No container to kill")
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/docker/cli/cli/command"
@@ -55,15 +56,19 @@ func listCommand(dockerCli command.Cli, backendOptions *BackendOptions) *cobra.C
 	lsCmd.Flags().StringVar(&lsOpts.Format, "format", "table", "Format the output. Values: [table | json]")
 	lsCmd.Flags().BoolVarP(&lsOpts.Quiet, "quiet", "q", false, "Only display project names")
 	lsCmd.Flags().Var(&lsOpts.Filter, "filter", "Filter output based on conditions provided")
-	lsCmd.Flags().BoolVarP(&lsOpts.All, "all", "a", false, "Show all stopped Compose projects")
+	lsCmd.Flags().BoolVarP(&lsOpts.All, "all", "a", false, "Show all Compose projects, including stopped ones")
 
 	return lsCmd
 }
 
 var acceptedListFilters = map[string]bool{
-	"name": true,
+	"name":   true,
+	"status": true,
+	"label":  true,
 }
 
+var acceptedListStatuses = []string{"created", "restarting", "running", "removing", "paused", "exited", "dead"}
+
 // match returns true if any of the values at key match the source string
 func match(filters client.Filters, field, source string) bool {
 	if f, ok := filters[field]; ok && f[source] {
@@ -83,6 +88,28 @@ func match(filters client.Filters, field, source string) bool {
 	return false
 }
 
+// matchStack returns true if the stack matches all the filters. A stack matches a status filter
+// when one of its containers is in any of the filtered states, and a label filter when the label
+// is set on all its containers, with the given value if any
+func matchStack(filters client.Filters, s api.Stack) bool {
+	if _, ok := filters["name"]; ok && !match(filters, "name", s.Name) {
+		return false
+	}
+	if statuses, ok := filters["status"]; ok && !slices.ContainsFunc(s.States, func(state string) bool {
+		return statuses[state]
+	}) {
+		return false
+	}
+	for label := range filters["label"] {
+		key, value, hasValue := strings.Cut(label, "=")
+		v, ok := s.Labels[key]
+		if !ok || hasValue && v != value {
+			return false
+		}
+	}
+	return true
+}
+
 func runList(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOptions, lsOpts lsOptions) error {
 	filters := lsOpts.Filter.Value()
 
@@ -91,12 +118,22 @@ func runList(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 			return errors.New("invalid filter '" + filter + "'")
 		}
 	}
+	// stopped projects are listed as soon as filtering on a status other than running
+	all := lsOpts.All
+	for status := range filters["status"] {
+		if !slices.Contains(acceptedListStatuses, status) {
+			return fmt.Errorf("invalid filter 'status=%s', valid values are: %s", status, strings.Join(acceptedListStatuses, ", "))
+		}
+		if status != "running" {
+			all = true
+		}
+	}
 
 	backend, err := compose.NewComposeService(dockerCli, backendOptions.Options...)
 	if err != nil {
 		return err
 	}
-	stackList, err := backend.List(ctx, api.ListOptions{All: lsOpts.All})
+	stackList, err := backend.List(ctx, api.ListOptions{All: all})
 	if err != nil {
 		return err
 	}
@@ -104,7 +141,7 @@ func runList(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 	if len(filters) > 0 {
 		var filtered []api.Stack
 		for _, s := range stackList {
-			if match(filters, "name", s.Name) {
+			if matchStack(filters, s) {
 				filtered = append(filtered, s)
 			}
 		}
@@ -0,0 +1,73 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/docker/cli/opts"
+	"github.com/moby/moby/client"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestMatchStack(t *testing.T) {
+	stack := api.Stack{
+		Name:   "myproject",
+		States: []string{"exited", "running"},
+		Labels: map[string]string{api.ProjectLabel: "myproject", "env": "prod"},
+	}
+	tests := []struct {
+		name    string
+		filters client.Filters
+		match   bool
+	}{
+		{name: "no filter", filters: client.Filters{}, match: true},
+		{name: "name", filters: make(client.Filters).Add("name", "my"), match: true},
+		{name: "other name", filters: make(client.Filters).Add("name", "other"), match: false},
+		{name: "status", filters: make(client.Filters).Add("status", "running"), match: true},
+		{name: "any status", filters: make(client.Filters).Add("status", "paused", "exited"), match: true},
+		{name: "other status", filters: make(client.Filters).Add("status", "paused"), match: false},
+		{name: "label", filters: make(client.Filters).Add("label", "env"), match: true},
+		{name: "label value", filters: make(client.Filters).Add("label", "env=prod"), match: true},
+		{name: "other label value", filters: make(client.Filters).Add("label", "env=dev"), match: false},
+		{name: "all labels", filters: make(client.Filters).Add("label", "env=prod", "team"), match: false},
+		{
+			name:    "all filters",
+			filters: make(client.Filters).Add("name", "myproject").Add("status", "running").Add("label", "env=prod"),
+			match:   true,
+		},
+		{
+			name:    "one filter mismatch",
+			filters: make(client.Filters).Add("name", "myproject").Add("status", "dead").Add("label", "env=prod"),
+			match:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, matchStack(tt.filters, stack), tt.match)
+		})
+	}
+}
+
+func TestRunListInvalidStatusFilter(t *testing.T) {
+	filter := opts.NewFilterOpt()
+	assert.NilError(t, filter.Set("status=up"))
+	err := runList(t.Context(), nil, &BackendOptions{}, lsOptions{Filter: filter})
+	assert.ErrorContains(t, err, "invalid filter 'status=up'")
+}
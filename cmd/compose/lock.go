@@ -0,0 +1,101 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/compose/v5/pkg/compose"
+)
+
+type lockOptions struct {
+	*ProjectOptions
+}
+
+func lockCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
+	opts := lockOptions{
+		ProjectOptions: p,
+	}
+	return &cobra.Command{
+		Use:   "lock [OPTIONS] [SERVICE...]",
+		Short: "Record the digests of service images in " + compose.ImagesLockFile,
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			return runLock(ctx, dockerCli, backendOptions, opts, args)
+		}),
+		ValidArgsFunction: completeServiceNames(dockerCli, p),
+	}
+}
+
+func runLock(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOptions, opts lockOptions, services []string) error {
+	backend, err := compose.NewComposeService(dockerCli, backendOptions.Options...)
+	if err != nil {
+		return err
+	}
+
+	project, _, err := opts.ToProject(ctx, dockerCli, backend, services, cli.WithoutEnvironmentResolution)
+	if err != nil {
+		return err
+	}
+
+	resolver := compose.ImageDigestResolver(ctx, dockerCli.ConfigFile(), dockerCli.Client())
+	images := api.ImagesLock{}
+	for _, service := range project.Services {
+		if service.Image == "" || service.Build != nil {
+			// images built locally can't be resolved from a registry
+			continue
+		}
+		named, err := reference.ParseDockerRef(service.Image)
+		if err != nil {
+			return err
+		}
+		if canonical, ok := named.(reference.Canonical); ok {
+			images[service.Image] = canonical.Digest().String()
+			continue
+		}
+		digest, err := resolver(named)
+		if err != nil {
+			return err
+		}
+		images[service.Image] = digest.String()
+	}
+	return compose.SaveImagesLock(imagesLockPath(project), images)
+}
+
+func imagesLockPath(project *types.Project) string {
+	return filepath.Join(project.WorkingDir, compose.ImagesLockFile)
+}
+
+// loadImagesLock reads the lockfile next to the Compose file, which must exist when --locked is set
+func loadImagesLock(project *types.Project) (api.ImagesLock, error) {
+	path := imagesLockPath(project)
+	lock, err := compose.LoadImagesLock(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("--locked requires %s, run `docker compose lock` to create it", path)
+	}
+	return lock, err
+}
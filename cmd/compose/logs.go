@@ -19,6 +19,8 @@ package compose
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 
 	"github.com/docker/cli-docs-tool/annotation"
 	"github.com/docker/cli/cli/command"
@@ -32,14 +34,22 @@ import (
 type logsOptions struct {
 	*ProjectOptions
 	composeOptions
-	follow     bool
-	index      int
-	tail       string
-	since      string
-	until      string
-	noColor    bool
-	noPrefix   bool
-	timestamps bool
+	follow      bool
+	index       int
+	tail        string
+	since       string
+	until       string
+	noColor     bool
+	color       string
+	noPrefix    bool
+	containerID bool
+	timestamps  bool
+	// timestampFormat is the format of timestamps, see formatter.TimestampFormatter
+	timestampFormat string
+	grep            string
+	grepInvert      bool
+	ignoreCase      bool
+	format          string
 }
 
 func logsCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -56,7 +66,34 @@ func logsCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 			if opts.index > 0 && len(args) != 1 {
 				return errors.New("--index requires one service to be selected")
 			}
-			return nil
+			if opts.format != "" && opts.format != formatter.TEXT && opts.format != formatter.JSON {
+				return fmt.Errorf("unsupported format %q, must be one of: %s, %s", opts.format, formatter.TEXT, formatter.JSON)
+			}
+			switch opts.color {
+			case formatter.Auto, formatter.Never:
+			case formatter.Always:
+				if opts.noColor {
+					return errors.New("--no-color cannot be combined with --color always")
+				}
+			default:
+				return fmt.Errorf("unsupported color mode %q, must be one of: %s, %s, %s", opts.color, formatter.Auto, formatter.Always, formatter.Never)
+			}
+			if opts.containerID && (opts.noPrefix || opts.format == formatter.JSON) {
+				return errors.New("--container-id cannot be combined with --no-log-prefix or --format json")
+			}
+			if cmd.Flags().Changed("timestamp-format") {
+				if !opts.timestamps || opts.format == formatter.JSON {
+					return errors.New("--timestamp-format requires --timestamps and can't be combined with --format json")
+				}
+				if _, err := formatter.TimestampFormatter(opts.timestampFormat); err != nil {
+					return err
+				}
+			}
+			if opts.grep == "" && (opts.grepInvert || opts.ignoreCase) {
+				return errors.New("--grep-invert and --ignore-case require --grep to be set")
+			}
+			_, err := opts.grepPattern()
+			return err
 		},
 		ValidArgsFunction: completeServiceNames(dockerCli, p),
 	}
@@ -69,14 +106,37 @@ func logsCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	flags.StringVar(&opts.until, "until", "", "Show logs before a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
 	flags.SetAnnotation("until", annotation.ExternalURL, []string{"https://docs.docker.com/reference/cli/docker/container/logs/#until"}) //nolint:errcheck
 	flags.BoolVar(&opts.noColor, "no-color", false, "Produce monochrome output")
+	flags.StringVar(&opts.color, "color", formatter.Auto, "Colorize log prefixes. Values: [auto | always | never]")
 	flags.BoolVar(&opts.noPrefix, "no-log-prefix", false, "Don't print prefix in logs")
+	flags.BoolVar(&opts.containerID, "container-id", false, "Include the short container ID in the prefix of logs")
 	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps")
 	flags.SetAnnotation("timestamps", annotation.ExternalURL, []string{"https://docs.docker.com/reference/cli/docker/container/logs/#timestamps"}) //nolint:errcheck
+	flags.StringVar(&opts.timestampFormat, "timestamp-format", formatter.TimestampRFC3339Nano, "Format of timestamps shown with --timestamps. Values: [rfc3339nano | local | Go time layout]")
 	flags.StringVarP(&opts.tail, "tail", "n", "all", "Number of lines to show from the end of the logs for each container")
 	flags.SetAnnotation("tail", annotation.ExternalURL, []string{"https://docs.docker.com/reference/cli/docker/container/logs/#tail"}) //nolint:errcheck
+	flags.StringVar(&opts.grep, "grep", "", "Only show log lines matching a regular expression")
+	flags.BoolVar(&opts.grepInvert, "grep-invert", false, "Only show log lines not matching the --grep regular expression")
+	flags.BoolVar(&opts.ignoreCase, "ignore-case", false, "Ignore case distinctions when matching --grep regular expression")
+	flags.StringVar(&opts.format, "format", formatter.TEXT, "Format the output. Values: [text | json]")
 	return logsCmd
 }
 
+// grepPattern returns the regular expression log lines must match, or nil when not filtering
+func (opts logsOptions) grepPattern() (*regexp.Regexp, error) {
+	if opts.grep == "" {
+		return nil, nil
+	}
+	expr := opts.grep
+	if opts.ignoreCase {
+		expr = "(?i)" + expr
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --grep regular expression: %w", err)
+	}
+	return pattern, nil
+}
+
 func runLogs(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOptions, opts logsOptions, services []string) error {
 	project, name, err := opts.projectOrName(ctx, dockerCli, services...)
 	if err != nil {
@@ -96,7 +156,35 @@ func runLogs(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 	if err != nil {
 		return err
 	}
-	consumer := formatter.NewLogConsumer(ctx, dockerCli.Out(), dockerCli.Err(), !opts.noColor, !opts.noPrefix, false)
+	var consumer api.LogConsumer
+	if opts.format == formatter.JSON {
+		consumer = formatter.NewJSONLogConsumer(dockerCli.Out())
+	} else {
+		if opts.color == formatter.Always {
+			// override --ansi and NO_COLOR, so logs are colored even when output is not a terminal
+			formatter.SetANSIMode(dockerCli, formatter.Always)
+		}
+		color := !opts.noColor && opts.color != formatter.Never
+		consumer = formatter.NewLogConsumer(ctx, dockerCli.Out(), dockerCli.Err(), color, !opts.noPrefix, false)
+		if opts.containerID || opts.timestampFormat != formatter.TimestampRFC3339Nano {
+			// rely on log records, so that the container ID and timestamp of each message are known
+			format := formatter.LogRecordFormat{ContainerID: opts.containerID}
+			if opts.timestamps {
+				format.Timestamp, err = formatter.TimestampFormatter(opts.timestampFormat)
+				if err != nil {
+					return err
+				}
+			}
+			consumer = formatter.NewLogRecordConsumer(consumer, format)
+		}
+	}
+	pattern, err := opts.grepPattern()
+	if err != nil {
+		return err
+	}
+	if pattern != nil {
+		consumer = formatter.NewLogFilter(consumer, pattern, opts.grepInvert)
+	}
 	return backend.Logs(ctx, name, consumer, api.LogOptions{
 		Project:    project,
 		Services:   services,
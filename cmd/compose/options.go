@@ -87,7 +87,7 @@ func applyPlatforms(project *types.Project, buildForSinglePlatform bool) error {
 	return nil
 }
 
-// isRemoteConfig checks if the main compose file is from a remote source (OCI or Git)
+// isRemoteConfig checks if the main compose file is from a remote source (OCI, Git or HTTP)
 func isRemoteConfig(dockerCli command.Cli, options buildOptions) bool {
 	if len(options.ConfigPaths) == 0 {
 		return false
@@ -249,7 +249,7 @@ func displayInterpolationVariables(writer io.Writer, varsInfo []varInfo) {
 
 func displayLocationRemoteStack(dockerCli command.Cli, project *types.Project, options buildOptions) {
 	mainComposeFile := options.ProjectOptions.ConfigPaths[0] //nolint:staticcheck
-	if display.Mode != display.ModeQuiet && display.Mode != display.ModeJSON {
+	if display.Mode != display.ModeQuiet && display.Mode != display.ModeQuietErrors && display.Mode != display.ModeJSON {
 		_, _ = fmt.Fprintf(dockerCli.Out(), "Your compose stack %q is stored in %q\n", mainComposeFile, project.WorkingDir)
 	}
 }
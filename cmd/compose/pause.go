@@ -18,6 +18,7 @@ package compose
 
 import (
 	"context"
+	"errors"
 
 	"github.com/docker/cli/cli/command"
 	"github.com/spf13/cobra"
@@ -27,6 +28,8 @@ import (
 
 type pauseOptions struct {
 	*ProjectOptions
+	index int
+	all   bool
 }
 
 func pauseCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -36,11 +39,17 @@ func pauseCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Back
 	cmd := &cobra.Command{
 		Use:   "pause [SERVICE...]",
 		Short: "Pause services",
+		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			return validatePauseSelection(opts.index, opts.all, args)
+		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runPause(ctx, dockerCli, backendOptions, opts, args)
 		}),
 		ValidArgsFunction: completeServiceNames(dockerCli, p),
 	}
+	flags := cmd.Flags()
+	flags.IntVar(&opts.index, "index", 0, "Index of the container if service has multiple replicas")
+	flags.BoolVar(&opts.all, "all", false, "Pause all containers of the project, including services not declared in the Compose file")
 	return cmd
 }
 
@@ -53,12 +62,16 @@ func runPause(ctx context.Context, dockerCli command.Cli, backendOptions *Backen
 		return backend.Pause(ctx, name, api.PauseOptions{
 			Services: services,
 			Project:  project,
+			Index:    opts.index,
+			All:      opts.all,
 		})
 	})
 }
 
 type unpauseOptions struct {
 	*ProjectOptions
+	index int
+	all   bool
 }
 
 func unpauseCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -68,11 +81,17 @@ func unpauseCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Ba
 	cmd := &cobra.Command{
 		Use:   "unpause [SERVICE...]",
 		Short: "Unpause services",
+		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			return validatePauseSelection(opts.index, opts.all, args)
+		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runUnPause(ctx, dockerCli, backendOptions, opts, args)
 		}),
 		ValidArgsFunction: completeServiceNames(dockerCli, p),
 	}
+	flags := cmd.Flags()
+	flags.IntVar(&opts.index, "index", 0, "Index of the container if service has multiple replicas")
+	flags.BoolVar(&opts.all, "all", false, "Unpause all containers of the project, including services not declared in the Compose file")
 	return cmd
 }
 
@@ -85,6 +104,18 @@ func runUnPause(ctx context.Context, dockerCli command.Cli, backendOptions *Back
 		return backend.UnPause(ctx, name, api.PauseOptions{
 			Services: services,
 			Project:  project,
+			Index:    opts.index,
+			All:      opts.all,
 		})
 	})
 }
+
+func validatePauseSelection(index int, all bool, services []string) error {
+	if all && (index > 0 || len(services) > 0) {
+		return errors.New("--all cannot be combined with --index or service names")
+	}
+	if index > 0 && len(services) != 1 {
+		return errors.New("--index requires one service to be selected")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestValidatePauseSelection(t *testing.T) {
+	assert.NilError(t, validatePauseSelection(0, false, nil))
+	assert.NilError(t, validatePauseSelection(0, false, []string{"web", "db"}))
+	assert.NilError(t, validatePauseSelection(2, false, []string{"web"}))
+	assert.NilError(t, validatePauseSelection(0, true, nil))
+	assert.Error(t, validatePauseSelection(2, false, nil), "--index requires one service to be selected")
+	assert.Error(t, validatePauseSelection(2, false, []string{"web", "db"}), "--index requires one service to be selected")
+	assert.Error(t, validatePauseSelection(0, true, []string{"web"}), "--all cannot be combined with --index or service names")
+	assert.Error(t, validatePauseSelection(1, true, []string{"web"}), "--all cannot be combined with --index or service names")
+}
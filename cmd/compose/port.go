@@ -18,13 +18,16 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/docker/cli/cli/command"
 	"github.com/spf13/cobra"
 
+	"github.com/docker/compose/v5/cmd/formatter"
 	"github.com/docker/compose/v5/pkg/api"
 	"github.com/docker/compose/v5/pkg/compose"
 )
@@ -34,6 +37,8 @@ type portOptions struct {
 	port     uint16
 	protocol string
 	index    int
+	all      bool
+	format   string
 }
 
 func portCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -41,25 +46,46 @@ func portCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 		ProjectOptions: p,
 	}
 	cmd := &cobra.Command{
-		Use:   "port [OPTIONS] SERVICE PRIVATE_PORT",
+		Use:   "port [OPTIONS] SERVICE [PRIVATE_PORT]",
 		Short: "Print the public port for a port binding",
-		Args:  cobra.MinimumNArgs(2),
-		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+		Args:  cobra.RangeArgs(1, 2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.format != formatter.TABLE && opts.format != formatter.JSON {
+				return fmt.Errorf("unsupported format %q, must be one of: %s, %s", opts.format, formatter.TABLE, formatter.JSON)
+			}
+			opts.protocol = strings.ToLower(opts.protocol)
+			if opts.all {
+				if len(args) > 1 {
+					return errors.New("cannot set a private port with --all")
+				}
+				if !cmd.Flags().Changed("protocol") {
+					// list ports for all protocols unless explicitly requested
+					opts.protocol = ""
+				}
+				return nil
+			}
+			if len(args) < 2 {
+				return errors.New("a private port is required, or use --all to list all published ports")
+			}
 			port, err := strconv.ParseUint(args[1], 10, 16)
 			if err != nil {
 				return err
 			}
 			opts.port = uint16(port)
-			opts.protocol = strings.ToLower(opts.protocol)
 			return nil
-		}),
+		},
 		RunE: Adapt(func(ctx context.Context, args []string) error {
+			if opts.all {
+				return runPorts(ctx, dockerCli, backendOptions, opts, args[0])
+			}
 			return runPort(ctx, dockerCli, backendOptions, opts, args[0])
 		}),
 		ValidArgsFunction: completeServiceNames(dockerCli, p),
 	}
 	cmd.Flags().StringVar(&opts.protocol, "protocol", "tcp", "tcp or udp")
 	cmd.Flags().IntVar(&opts.index, "index", 0, "Index of the container if service has multiple replicas")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "List all ports published by the service containers")
+	cmd.Flags().StringVar(&opts.format, "format", formatter.TABLE, "Format the output when used with --all. Values: [table | json]")
 	return cmd
 }
 
@@ -84,3 +110,52 @@ func runPort(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 	_, _ = fmt.Fprintf(dockerCli.Out(), "%s:%d\n", ip, port)
 	return nil
 }
+
+func runPorts(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOptions, opts portOptions, service string) error {
+	projectName, err := opts.toProjectName(ctx, dockerCli)
+	if err != nil {
+		return err
+	}
+
+	backend, err := compose.NewComposeService(dockerCli, backendOptions.Options...)
+	if err != nil {
+		return err
+	}
+	ports, err := backend.Ports(ctx, projectName, service, api.PortOptions{
+		Protocol: opts.protocol,
+		Index:    opts.index,
+	})
+	if err != nil {
+		return err
+	}
+	return printPorts(dockerCli.Out(), opts.format, ports)
+}
+
+func printPorts(out io.Writer, format string, ports []api.ContainerPortPublisher) error {
+	type port struct {
+		Container     string `json:"Container"`
+		Number        int    `json:"Number"`
+		URL           string `json:"URL"`
+		TargetPort    int    `json:"TargetPort"`
+		PublishedPort int    `json:"PublishedPort"`
+		Protocol      string `json:"Protocol"`
+	}
+	portList := make([]port, 0, len(ports))
+	for _, p := range ports {
+		portList = append(portList, port{
+			Container:     p.Container,
+			Number:        p.Number,
+			URL:           p.URL,
+			TargetPort:    p.TargetPort,
+			PublishedPort: p.PublishedPort,
+			Protocol:      p.Protocol,
+		})
+	}
+	return formatter.Print(portList, format, out,
+		func(w io.Writer) {
+			for _, p := range portList {
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%d/%s\t%s:%d\n", p.Container, p.Number, p.TargetPort, p.Protocol, p.URL, p.PublishedPort)
+			}
+		},
+		"CONTAINER", "#", "PRIVATE PORT", "PUBLISHED")
+}
@@ -0,0 +1,62 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/cmd/formatter"
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestPrintPorts(t *testing.T) {
+	ports := []api.ContainerPortPublisher{
+		{
+			PortPublisher: api.PortPublisher{URL: "0.0.0.0", TargetPort: 80, PublishedPort: 8080, Protocol: "tcp"},
+			Container:     "project-web-1",
+			Number:        1,
+		},
+		{
+			PortPublisher: api.PortPublisher{URL: "0.0.0.0", TargetPort: 80, PublishedPort: 8081, Protocol: "tcp"},
+			Container:     "project-web-2",
+			Number:        2,
+		},
+	}
+
+	var buf bytes.Buffer
+	err := printPorts(&buf, formatter.TABLE, ports)
+	assert.NilError(t, err)
+	var rows [][]string
+	for line := range strings.SplitSeq(strings.TrimSpace(buf.String()), "\n") {
+		rows = append(rows, strings.Fields(line))
+	}
+	assert.DeepEqual(t, rows, [][]string{
+		{"CONTAINER", "#", "PRIVATE", "PORT", "PUBLISHED"},
+		{"project-web-1", "1", "80/tcp", "0.0.0.0:8080"},
+		{"project-web-2", "2", "80/tcp", "0.0.0.0:8081"},
+	})
+
+	buf.Reset()
+	err = printPorts(&buf, formatter.JSON, ports)
+	assert.NilError(t, err)
+	assert.Equal(t, buf.String(), `[{"Container":"project-web-1","Number":1,"URL":"0.0.0.0","TargetPort":80,"PublishedPort":8080,"Protocol":"tcp"},`+
+		`{"Container":"project-web-2","Number":2,"URL":"0.0.0.0","TargetPort":80,"PublishedPort":8081,"Protocol":"tcp"}]`+"\n")
+}
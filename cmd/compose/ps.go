@@ -27,6 +27,7 @@ import (
 	"github.com/docker/cli/cli/command"
 	cliformatter "github.com/docker/cli/cli/command/formatter"
 	cliflags "github.com/docker/cli/cli/flags"
+	"github.com/moby/moby/api/types/container"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v5/cmd/formatter"
@@ -34,35 +35,49 @@ import (
 	"github.com/docker/compose/v5/pkg/compose"
 )
 
+// healthFilterValues are the health statuses supported by the `health` filter, `none` selecting
+// containers without a healthcheck
+var healthFilterValues = []string{
+	string(container.Healthy),
+	string(container.Unhealthy),
+	string(container.Starting),
+	string(container.NoHealthcheck),
+}
+
 type psOptions struct {
 	*ProjectOptions
 	Format   string
 	All      bool
 	Quiet    bool
 	Services bool
-	Filter   string
+	Filter   []string
 	Status   []string
+	Health   []string
 	noTrunc  bool
 	Orphans  bool
 }
 
 func (p *psOptions) parseFilter() error {
-	if p.Filter == "" {
-		return nil
-	}
-	key, val, ok := strings.Cut(p.Filter, "=")
-	if !ok {
-		return errors.New("arguments to --filter should be in form KEY=VAL")
-	}
-	switch key {
-	case "status":
-		p.Status = append(p.Status, val)
-		return nil
-	case "source":
-		return api.ErrNotImplemented
-	default:
-		return fmt.Errorf("unknown filter %s", key)
+	for _, filter := range p.Filter {
+		key, val, ok := strings.Cut(filter, "=")
+		if !ok {
+			return errors.New("arguments to --filter should be in form KEY=VAL")
+		}
+		switch key {
+		case "status":
+			p.Status = append(p.Status, val)
+		case "health":
+			if !slices.Contains(healthFilterValues, val) {
+				return fmt.Errorf("invalid health filter %q, supported values are: %s", val, strings.Join(healthFilterValues, ", "))
+			}
+			p.Health = append(p.Health, val)
+		case "source":
+			return api.ErrNotImplemented
+		default:
+			return fmt.Errorf("unknown filter %s", key)
+		}
 	}
+	return nil
 }
 
 func psCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -82,7 +97,7 @@ func psCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backend
 	}
 	flags := psCmd.Flags()
 	flags.StringVar(&opts.Format, "format", "table", cliflags.FormatHelp)
-	flags.StringVar(&opts.Filter, "filter", "", "Filter services by a property (supported filters: status)")
+	flags.StringArrayVar(&opts.Filter, "filter", []string{}, "Filter services by a property (supported filters: status, health)")
 	flags.StringArrayVar(&opts.Status, "status", []string{}, "Filter services by status. Values: [paused | restarting | removing | running | dead | created | exited]")
 	flags.BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs")
 	flags.BoolVar(&opts.Services, "services", false, "Display services")
@@ -128,6 +143,9 @@ func runPs(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOp
 	if len(opts.Status) != 0 {
 		containers = filterByStatus(containers, opts.Status)
 	}
+	if len(opts.Health) != 0 {
+		containers = filterByHealth(containers, opts.Health)
+	}
 
 	sort.Slice(containers, func(i, j int) bool {
 		return containers[i].Name < containers[j].Name
@@ -135,6 +153,10 @@ func runPs(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOp
 
 	if opts.Quiet {
 		for _, c := range containers {
+			if c.ID == "" {
+				// provider services have no container
+				continue
+			}
 			_, _ = fmt.Fprintln(dockerCli.Out(), c.ID)
 		}
 		return nil
@@ -173,3 +195,17 @@ func filterByStatus(containers []api.ContainerSummary, statuses []string) []api.
 	}
 	return filtered
 }
+
+func filterByHealth(containers []api.ContainerSummary, health []string) []api.ContainerSummary {
+	var filtered []api.ContainerSummary
+	for _, c := range containers {
+		status := c.Health
+		if status == "" {
+			status = container.NoHealthcheck
+		}
+		if slices.Contains(health, string(status)) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
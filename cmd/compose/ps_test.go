@@ -0,0 +1,65 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestPsParseFilter(t *testing.T) {
+	opts := psOptions{Filter: []string{"status=running", "health=unhealthy", "health=none"}}
+	assert.NilError(t, opts.parseFilter())
+	assert.DeepEqual(t, opts.Status, []string{"running"})
+	assert.DeepEqual(t, opts.Health, []string{"unhealthy", "none"})
+
+	opts = psOptions{Filter: []string{"health=sick"}}
+	assert.Error(t, opts.parseFilter(), `invalid health filter "sick", supported values are: healthy, unhealthy, starting, none`)
+
+	opts = psOptions{Filter: []string{"name=web"}}
+	assert.Error(t, opts.parseFilter(), "unknown filter name")
+}
+
+func TestFilterByHealth(t *testing.T) {
+	containers := []api.ContainerSummary{
+		{Name: "healthy", State: container.StateRunning, Health: container.Healthy},
+		{Name: "unhealthy", State: container.StateRunning, Health: container.Unhealthy},
+		{Name: "starting", State: container.StateRunning, Health: container.Starting},
+		{Name: "none", State: container.StateRunning},
+		{Name: "exited", State: container.StateExited},
+	}
+
+	names := func(containers []api.ContainerSummary) []string {
+		var names []string
+		for _, c := range containers {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	assert.DeepEqual(t, names(filterByHealth(containers, []string{"unhealthy"})), []string{"unhealthy"})
+	assert.DeepEqual(t, names(filterByHealth(containers, []string{"healthy", "starting"})), []string{"healthy", "starting"})
+	assert.DeepEqual(t, names(filterByHealth(containers, []string{"none"})), []string{"none", "exited"})
+
+	// status and health filters are combined
+	filtered := filterByHealth(filterByStatus(containers, []string{"running"}), []string{"none"})
+	assert.DeepEqual(t, names(filtered), []string{"none"})
+}
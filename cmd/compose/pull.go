@@ -41,6 +41,7 @@ type pullOptions struct {
 	ignorePullFailures bool
 	noBuildable        bool
 	policy             string
+	locked             bool
 }
 
 func pullCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -74,6 +75,7 @@ func pullCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	cmd.Flags().BoolVar(&opts.ignorePullFailures, "ignore-pull-failures", false, "Pull what it can and ignores images with pull failures")
 	cmd.Flags().BoolVar(&opts.noBuildable, "ignore-buildable", false, "Ignore images that can be built")
 	cmd.Flags().StringVar(&opts.policy, "policy", "", `Apply pull policy ("missing"|"always")`)
+	cmd.Flags().BoolVar(&opts.locked, "locked", false, "Verify pulled images match the digests recorded in "+compose.ImagesLockFile)
 	return cmd
 }
 
@@ -87,8 +89,14 @@ func (opts pullOptions) apply(project *types.Project, services []string) (*types
 	}
 
 	if opts.policy != "" {
+		switch opts.policy {
+		case types.PullPolicyMissing, types.PullPolicyAlways:
+		default:
+			return nil, fmt.Errorf("invalid --policy value %q, must be one of %q or %q", opts.policy, types.PullPolicyMissing, types.PullPolicyAlways)
+		}
 		for i, service := range project.Services {
 			if service.Image == "" {
+				// local build only, nothing to pull
 				continue
 			}
 			service.PullPolicy = opts.policy
@@ -114,9 +122,18 @@ func runPull(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 		return err
 	}
 
+	var lock api.ImagesLock
+	if opts.locked {
+		lock, err = loadImagesLock(project)
+		if err != nil {
+			return err
+		}
+	}
+
 	return backend.Pull(ctx, project, api.PullOptions{
 		Quiet:           opts.quiet,
 		IgnoreFailures:  opts.ignorePullFailures,
 		IgnoreBuildable: opts.noBuildable,
+		ImagesLock:      lock,
 	})
 }
@@ -55,3 +55,33 @@ func TestApplyPullOptions(t *testing.T) {
 	assert.Equal(t, project.Services["has-build"].PullPolicy, types.PullPolicyMissing)
 	assert.Equal(t, project.Services["must-pull"].PullPolicy, types.PullPolicyMissing)
 }
+
+func TestApplyPullOptionsPolicy(t *testing.T) {
+	newProject := func() *types.Project {
+		return &types.Project{
+			Services: types.Services{
+				"must-build": {
+					Name:  "must-build",
+					Build: &types.BuildConfig{Context: "."},
+				},
+				"never": {
+					Name:       "never",
+					Image:      "registry.example.com/never",
+					PullPolicy: types.PullPolicyNever,
+				},
+			},
+		}
+	}
+
+	project, err := pullOptions{policy: types.PullPolicyAlways}.apply(newProject(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, project.Services["must-build"].PullPolicy, "")
+	assert.Equal(t, project.Services["never"].PullPolicy, types.PullPolicyAlways)
+
+	project, err = pullOptions{}.apply(newProject(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, project.Services["never"].PullPolicy, types.PullPolicyNever)
+
+	_, err = pullOptions{policy: types.PullPolicyNever}.apply(newProject(), nil)
+	assert.Error(t, err, `invalid --policy value "never", must be one of "missing" or "always"`)
+}
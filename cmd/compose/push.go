@@ -17,12 +17,18 @@
 package compose
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/cli/cli/command"
 	"github.com/spf13/cobra"
 
+	"github.com/docker/compose/v5/cmd/formatter"
 	"github.com/docker/compose/v5/pkg/api"
 	"github.com/docker/compose/v5/pkg/compose"
 )
@@ -33,6 +39,7 @@ type pushOptions struct {
 	IncludeDeps    bool
 	Ignorefailures bool
 	Quiet          bool
+	Format         string
 }
 
 func pushCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -42,6 +49,14 @@ func pushCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	pushCmd := &cobra.Command{
 		Use:   "push [OPTIONS] [SERVICE...]",
 		Short: "Push service images",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch opts.Format {
+			case formatter.TABLE, formatter.JSON:
+				return nil
+			default:
+				return fmt.Errorf("unsupported format %q, must be one of %s, %s", opts.Format, formatter.TABLE, formatter.JSON)
+			}
+		},
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runPush(ctx, dockerCli, backendOptions, opts, args)
 		}),
@@ -49,7 +64,8 @@ func pushCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	}
 	pushCmd.Flags().BoolVar(&opts.Ignorefailures, "ignore-push-failures", false, "Push what it can and ignores images with push failures")
 	pushCmd.Flags().BoolVar(&opts.IncludeDeps, "include-deps", false, "Also push images of services declared as dependencies")
-	pushCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Push without printing progress information")
+	pushCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Push without printing progress information, only print the digests of pushed images")
+	pushCmd.Flags().StringVar(&opts.Format, "format", formatter.TABLE, "Format the output. Values: [table | json]")
 
 	return pushCmd
 }
@@ -72,8 +88,70 @@ func runPush(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 		}
 	}
 
-	return backend.Push(ctx, project, api.PushOptions{
+	if opts.Format != formatter.JSON && !opts.Quiet {
+		return backend.Push(ctx, project, api.PushOptions{
+			IgnoreFailures: opts.Ignorefailures,
+		})
+	}
+
+	summary := &pushSummary{}
+	err = backend.Push(ctx, project, api.PushOptions{
 		IgnoreFailures: opts.Ignorefailures,
 		Quiet:          opts.Quiet,
+		Results:        summary.add,
+	})
+	var printErr error
+	if opts.Format == formatter.JSON {
+		printErr = summary.printJSON(dockerCli.Out())
+	} else {
+		printErr = summary.printDigests(dockerCli.Out())
+	}
+	return errors.Join(err, printErr)
+}
+
+// pushSummary collects the result of each image push
+type pushSummary struct {
+	resultCollector[api.PushResult]
+}
+
+// sorted returns the push results sorted by service then image
+func (p *pushSummary) sorted() []api.PushResult {
+	return p.resultCollector.sorted(func(a, b api.PushResult) int {
+		return cmp.Or(strings.Compare(a.Service, b.Service), strings.Compare(a.Image, b.Image))
+	})
+}
+
+// printDigests writes the reference of each successfully pushed image, one per line
+func (p *pushSummary) printDigests(out io.Writer) error {
+	for _, r := range p.sorted() {
+		if r.Error != nil || r.Digest == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "%s@%s\n", r.Image, r.Digest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printJSON writes the push results as JSON
+func (p *pushSummary) printJSON(out io.Writer) error {
+	type pushResult struct {
+		Service string `json:"Service"`
+		Image   string `json:"Image"`
+		Digest  string `json:"Digest,omitempty"`
+		Error   string `json:"Error,omitempty"`
+	}
+
+	return printResultsJSON(out, p.sorted(), func(r api.PushResult) pushResult {
+		result := pushResult{
+			Service: r.Service,
+			Image:   r.Image,
+			Digest:  r.Digest,
+		}
+		if r.Error != nil {
+			result.Error = r.Error.Error()
+		}
+		return result
 	})
 }
@@ -0,0 +1,50 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestPushSummary(t *testing.T) {
+	summary := &pushSummary{}
+	summary.add(api.PushResult{
+		Service: "web",
+		Image:   "registry.example.com/web:latest",
+		Digest:  "sha256:abc",
+	})
+	summary.add(api.PushResult{
+		Service: "db",
+		Image:   "registry.example.com/db:latest",
+		Error:   errors.New("denied"),
+	})
+
+	var buf bytes.Buffer
+	assert.NilError(t, summary.printJSON(&buf))
+	assert.Equal(t, buf.String(), `[{"Service":"db","Image":"registry.example.com/db:latest","Error":"denied"},`+
+		`{"Service":"web","Image":"registry.example.com/web:latest","Digest":"sha256:abc"}]`+"\n")
+
+	buf.Reset()
+	assert.NilError(t, summary.printDigests(&buf))
+	assert.Equal(t, buf.String(), "registry.example.com/web:latest@sha256:abc\n")
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	composecli "github.com/compose-spec/compose-go/v2/cli"
 	"github.com/compose-spec/compose-go/v2/dotenv"
@@ -64,10 +65,13 @@ type runOptions struct {
 	servicePorts  bool
 	name          string
 	noDeps        bool
+	wait          bool
+	waitTimeout   int
 	ignoreOrphans bool
 	removeOrphans bool
 	quiet         bool
 	quietPull     bool
+	networks      []string
 }
 
 func (options runOptions) apply(project *types.Project) (*types.Project, error) {
@@ -120,26 +124,42 @@ func (options runOptions) apply(project *types.Project) (*types.Project, error)
 }
 
 func (options runOptions) getEnvironment(resolve func(string) (string, bool)) (types.Mapping, error) {
-	environment := types.NewMappingWithEquals(options.environment).Resolve(resolve).ToMapping()
-	for _, file := range options.envFiles {
-		f, err := os.Open(file)
-		if err != nil {
-			return nil, err
-		}
-		vars, err := dotenv.ParseWithLookup(f, func(k string) (string, bool) {
-			value, ok := environment[k]
+	return loadEnvironment(options.environment, options.envFiles, resolve)
+}
+
+// loadEnvironment resolves the environment variables set by `--env` flags, completed with the ones declared in
+// `--env-from-file` files, relative to the current working directory. Variables set by `--env` take precedence,
+// then files are read in order.
+func loadEnvironment(environment []string, envFiles []string, resolve func(string) (string, bool)) (types.Mapping, error) {
+	env := types.NewMappingWithEquals(environment).Resolve(resolve).ToMapping()
+	for _, file := range envFiles {
+		vars, err := readEnvFile(file, func(k string) (string, bool) {
+			value, ok := env[k]
 			return value, ok
 		})
 		if err != nil {
-			return nil, nil
+			return nil, err
 		}
 		for k, v := range vars {
-			if _, ok := environment[k]; !ok {
-				environment[k] = v
+			if _, ok := env[k]; !ok {
+				env[k] = v
 			}
 		}
 	}
-	return environment, nil
+	return env, nil
+}
+
+func readEnvFile(file string, lookup dotenv.LookupFn) (map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	vars, err := dotenv.ParseWithLookup(f, lookup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file %s: %w", file, err)
+	}
+	return vars, nil
 }
 
 func runCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -170,6 +190,12 @@ func runCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backen
 			if len(options.publish) > 0 && options.servicePorts {
 				return fmt.Errorf("--service-ports and --publish are incompatible")
 			}
+			if options.waitTimeout < 0 {
+				return fmt.Errorf("--wait-timeout must be a non-negative integer")
+			}
+			if options.noDeps && options.wait {
+				return fmt.Errorf("--wait and --no-deps are incompatible")
+			}
 			if cmd.Flags().Changed("entrypoint") {
 				command, err := shellwords.Parse(options.entrypoint)
 				if err != nil {
@@ -214,7 +240,7 @@ func runCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backen
 				return err
 			}
 
-			if createOpts.quietPull {
+			if options.quietPull {
 				buildOpts.Progress = string(xprogress.QuietMode)
 			}
 
@@ -237,9 +263,12 @@ func runCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backen
 	flags.Var(&options.capAdd, "cap-add", "Add Linux capabilities")
 	flags.Var(&options.capDrop, "cap-drop", "Drop Linux capabilities")
 	flags.BoolVar(&options.noDeps, "no-deps", false, "Don't start linked services")
+	flags.BoolVar(&options.wait, "wait", false, "Wait for dependencies to be running|healthy before running the command")
+	flags.IntVar(&options.waitTimeout, "wait-timeout", 0, "Maximum duration in seconds to wait for dependencies to be running|healthy")
 	flags.StringArrayVarP(&options.volumes, "volume", "v", []string{}, "Bind mount a volume")
 	flags.StringArrayVarP(&options.publish, "publish", "p", []string{}, "Publish a container's port(s) to the host")
-	flags.BoolVar(&options.useAliases, "use-aliases", false, "Use the service's network useAliases in the network(s) the container connects to")
+	flags.StringArrayVar(&options.networks, "network", []string{}, "Connect the container to an additional existing network")
+	flags.BoolVar(&options.useAliases, "use-aliases", false, "Use the service's network aliases in the network(s) the container connects to")
 	flags.BoolVarP(&options.servicePorts, "service-ports", "P", false, "Run command with all service's ports enabled and mapped to the host")
 	flags.StringVar(&createOpts.Pull, "pull", "policy", `Pull image before running ("always"|"missing"|"never")`)
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Don't print anything to STDOUT")
@@ -331,6 +360,9 @@ func runRun(ctx context.Context, backend api.Compose, project *types.Project, op
 		Labels:            labels,
 		UseNetworkAliases: options.useAliases,
 		NoDeps:            options.noDeps,
+		Wait:              options.wait,
+		WaitTimeout:       time.Duration(options.waitTimeout) * time.Second,
+		Networks:          options.networks,
 		Index:             0,
 	}
 
@@ -0,0 +1,62 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestLoadEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.env")
+	assert.NilError(t, os.WriteFile(first, []byte("# comment\nFOO=from-file\nBAR=${FOO}-bar\nQIX=first\n"), 0o600))
+	second := filepath.Join(dir, "second.env")
+	assert.NilError(t, os.WriteFile(second, []byte("QIX=second\nZOT=second\n"), 0o600))
+
+	lookup := func(k string) (string, bool) {
+		if k == "HOST" {
+			return "from-host", true
+		}
+		return "", false
+	}
+	env, err := loadEnvironment([]string{"FOO=from-flag", "HOST"}, []string{first, second}, lookup)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, env, types.Mapping{
+		"FOO":  "from-flag",
+		"BAR":  "from-flag-bar",
+		"HOST": "from-host",
+		"QIX":  "first",
+		"ZOT":  "second",
+	})
+}
+
+func TestLoadEnvironmentInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "invalid.env")
+	assert.NilError(t, os.WriteFile(file, []byte("FOO=bar\nIN VALID=value\n"), 0o600))
+
+	_, err := loadEnvironment(nil, []string{file}, nil)
+	assert.ErrorContains(t, err, "failed to read env file "+file+": line 2: ")
+
+	_, err = loadEnvironment(nil, []string{filepath.Join(dir, "missing.env")}, nil)
+	assert.Assert(t, os.IsNotExist(err))
+}
@@ -18,6 +18,7 @@ package compose
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/docker/cli/cli/command"
@@ -39,6 +40,9 @@ func startCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Back
 	startCmd := &cobra.Command{
 		Use:   "start [SERVICE...]",
 		Short: "Start services",
+		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			return opts.validate()
+		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runStart(ctx, dockerCli, backendOptions, opts, args)
 		}),
@@ -51,6 +55,13 @@ func startCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Back
 	return startCmd
 }
 
+func (opts startOptions) validate() error {
+	if opts.waitTimeout < 0 {
+		return fmt.Errorf("--wait-timeout must be a non-negative integer")
+	}
+	return nil
+}
+
 func runStart(ctx context.Context, dockerCli command.Cli, backendOptions *BackendOptions, opts startOptions, services []string) error {
 	project, name, err := opts.projectOrName(ctx, dockerCli, services...)
 	if err != nil {
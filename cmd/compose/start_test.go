@@ -0,0 +1,32 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestStartOptionsValidate(t *testing.T) {
+	assert.NilError(t, startOptions{}.validate())
+	assert.NilError(t, startOptions{wait: true}.validate())
+	assert.NilError(t, startOptions{wait: true, waitTimeout: 30}.validate())
+	assert.Error(t, startOptions{wait: true, waitTimeout: -1}.validate(), "--wait-timeout must be a non-negative integer")
+	// like up and run, --wait-timeout is ignored without --wait
+	assert.NilError(t, startOptions{waitTimeout: 30}.validate())
+}
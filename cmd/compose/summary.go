@@ -0,0 +1,60 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/docker/compose/v5/cmd/formatter"
+)
+
+// resultCollector collects the results an API call reports for each resource, which can be concurrent,
+// so they can be printed as a summary once done
+type resultCollector[T any] struct {
+	mux     sync.Mutex
+	results []T
+}
+
+func (c *resultCollector[T]) add(result T) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.results = append(c.results, result)
+}
+
+// sorted returns the collected results sorted by cmp
+func (c *resultCollector[T]) sorted(cmp func(a, b T) int) []T {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return slices.SortedStableFunc(slices.Values(c.results), cmp)
+}
+
+// printResultsJSON writes results as a JSON array, using convert to select the attributes of each result
+func printResultsJSON[T, R any](out io.Writer, results []T, convert func(T) R) error {
+	converted := make([]R, 0, len(results))
+	for _, r := range results {
+		converted = append(converted, convert(r))
+	}
+	outJSON, err := formatter.ToJSON(converted, "", "")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, outJSON)
+	return err
+}
@@ -27,12 +27,14 @@ import (
 	"github.com/docker/cli/cli/command"
 	"github.com/spf13/cobra"
 
+	"github.com/docker/compose/v5/cmd/formatter"
 	"github.com/docker/compose/v5/pkg/api"
 	"github.com/docker/compose/v5/pkg/compose"
 )
 
 type topOptions struct {
 	*ProjectOptions
+	Format string
 }
 
 func topCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -47,6 +49,7 @@ func topCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backen
 		}),
 		ValidArgsFunction: completeServiceNames(dockerCli, p),
 	}
+	topCmd.Flags().StringVar(&opts.Format, "format", formatter.TABLE, "Format the output. Values: [table | json]")
 	return topCmd
 }
 
@@ -74,8 +77,58 @@ func runTop(ctx context.Context, dockerCli command.Cli, backendOptions *BackendO
 		return containers[i].Name < containers[j].Name
 	})
 
-	header, entries := collectTop(containers)
-	return topPrint(dockerCli.Out(), header, entries)
+	switch opts.Format {
+	case formatter.TABLE, "":
+		header, entries := collectTop(containers)
+		return topPrint(dockerCli.Out(), header, entries)
+	case formatter.JSON:
+		return topPrintJSON(dockerCli.Out(), topResult(containers))
+	default:
+		return fmt.Errorf("unsupported format %q, must be one of: %s, %s", opts.Format, formatter.TABLE, formatter.JSON)
+	}
+}
+
+// topContainer is the machine-readable representation of the processes running in a container
+type topContainer struct {
+	Service   string              `json:"Service"`
+	Replica   string              `json:"Replica"`
+	Name      string              `json:"Name"`
+	ID        string              `json:"ID"`
+	Processes []map[string]string `json:"Processes"`
+}
+
+// topResult maps the processes of each container by the column titles returned by the engine
+func topResult(containers []api.ContainerProcSummary) []topContainer {
+	result := make([]topContainer, 0, len(containers))
+	for _, container := range containers {
+		processes := make([]map[string]string, 0, len(container.Processes))
+		for _, proc := range container.Processes {
+			process := make(map[string]string, len(container.Titles))
+			for i, title := range container.Titles {
+				if i < len(proc) {
+					process[title] = proc[i]
+				}
+			}
+			processes = append(processes, process)
+		}
+		result = append(result, topContainer{
+			Service:   container.Service,
+			Replica:   container.Replica,
+			Name:      container.Name,
+			ID:        container.ID,
+			Processes: processes,
+		})
+	}
+	return result
+}
+
+func topPrintJSON(out io.Writer, result []topContainer) error {
+	outJSON, err := formatter.ToJSON(result, "", "")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, outJSON)
+	return err
 }
 
 func collectTop(containers []api.ContainerProcSummary) (topHeader, []topEntries) {
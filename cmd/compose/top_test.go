@@ -319,6 +319,33 @@ func TestRunTopCore(t *testing.T) {
 	})
 }
 
+func TestTopPrintJSON(t *testing.T) {
+	result := topResult([]api.ContainerProcSummary{
+		{
+			ID:        "123",
+			Name:      "project-web-1",
+			Service:   "web",
+			Replica:   "1",
+			Titles:    []string{"UID", "PID", "CMD"},
+			Processes: [][]string{{"root", "1", "/entrypoint"}, {"root", "42", "sleep infinity"}},
+		},
+		{
+			ID:        "456",
+			Name:      "project-db-1",
+			Service:   "db",
+			Replica:   "1",
+			Titles:    []string{"UID", "PID", "CMD"},
+			Processes: [][]string{},
+		},
+	})
+
+	var buf bytes.Buffer
+	err := topPrintJSON(&buf, result)
+	assert.NilError(t, err)
+	assert.Equal(t, buf.String(), `[{"Service":"web","Replica":"1","Name":"project-web-1","ID":"123","Processes":[{"CMD":"/entrypoint","PID":"1","UID":"root"},{"CMD":"sleep infinity","PID":"42","UID":"root"}]},`+
+		`{"Service":"db","Replica":"1","Name":"project-db-1","ID":"456","Processes":[]}]`+"\n")
+}
+
 func trim(s string) string {
 	var out bytes.Buffer
 	for line := range strings.SplitSeq(strings.TrimSpace(s), "\n") {
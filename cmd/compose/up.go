@@ -73,9 +73,8 @@ func (opts upOptions) apply(project *types.Project, services []string) (*types.P
 		}
 	}
 
-	if opts.exitCodeFrom != "" {
-		_, err := project.GetService(opts.exitCodeFrom)
-		if err != nil {
+	for _, name := range appendServiceNames(nil, opts.exitCodeFrom) {
+		if _, err := project.GetService(name); err != nil {
 			return nil, err
 		}
 	}
@@ -154,21 +153,23 @@ func upCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backend
 	flags.StringArrayVar(&create.scale, "scale", []string{}, "Scale SERVICE to NUM instances. Overrides the `scale` setting in the Compose file if present.")
 	flags.BoolVar(&up.noColor, "no-color", false, "Produce monochrome output")
 	flags.BoolVar(&up.noPrefix, "no-log-prefix", false, "Don't print prefix in logs")
-	flags.BoolVar(&create.forceRecreate, "force-recreate", false, "Recreate containers even if their configuration and image haven't changed")
+	create.addForceRecreateFlag(flags)
 	flags.BoolVar(&create.noRecreate, "no-recreate", false, "If containers already exist, don't recreate them. Incompatible with --force-recreate.")
 	flags.BoolVar(&up.noStart, "no-start", false, "Don't start the services after creating them")
 	flags.BoolVar(&up.cascadeStop, "abort-on-container-exit", false, "Stops all containers if any container was stopped. Incompatible with -d")
 	flags.BoolVar(&up.cascadeFail, "abort-on-container-failure", false, "Stops all containers if any container exited with failure. Incompatible with -d")
-	flags.StringVar(&up.exitCodeFrom, "exit-code-from", "", "Return the exit code of the selected service container. Implies --abort-on-container-exit")
+	flags.StringVar(&up.exitCodeFrom, "exit-code-from", "", "Return the exit code of the selected service container, or the highest one for a comma-separated list of services. Implies --abort-on-container-exit")
 	flags.IntVarP(&create.timeout, "timeout", "t", 0, "Use this timeout in seconds for container shutdown when attached or when containers are already running")
 	flags.BoolVar(&up.timestamp, "timestamps", false, "Show timestamps")
 	flags.BoolVar(&up.noDeps, "no-deps", false, "Don't start linked services")
 	flags.BoolVar(&create.recreateDeps, "always-recreate-deps", false, "Recreate dependent containers. Incompatible with --no-recreate.")
-	flags.BoolVarP(&create.noInherit, "renew-anon-volumes", "V", false, "Recreate anonymous volumes instead of retrieving data from the previous containers")
+	create.addRenewAnonVolumesFlag(flags)
 	flags.BoolVar(&create.quietPull, "quiet-pull", false, "Pull without printing progress information")
+	flags.BoolVar(&create.locked, "locked", false, "Verify images match the digests recorded in "+compose.ImagesLockFile)
 	flags.BoolVar(&build.quiet, "quiet-build", false, "Suppress the build output")
-	flags.StringArrayVar(&up.attach, "attach", []string{}, "Restrict attaching to the specified services. Incompatible with --attach-dependencies.")
-	flags.StringArrayVar(&up.noAttach, "no-attach", []string{}, "Do not attach (stream logs) to the specified services")
+	flags.StringArrayVar(&build.args, "build-arg", []string{}, "Set build-time variables for services")
+	flags.StringArrayVar(&up.attach, "attach", []string{}, "Restrict attaching to the specified services, comma-separated or repeated. Incompatible with --attach-dependencies.")
+	flags.StringArrayVar(&up.noAttach, "no-attach", []string{}, "Do not attach (stream logs) to the specified services, comma-separated or repeated")
 	flags.BoolVar(&up.attachDependencies, "attach-dependencies", false, "Automatically attach to log output of dependent services")
 	flags.BoolVar(&up.wait, "wait", false, "Wait for services to be running|healthy. Implies detached mode.")
 	flags.IntVar(&up.waitTimeout, "wait-timeout", 0, "Maximum duration in seconds to wait for the project to be running|healthy")
@@ -213,10 +214,10 @@ func validateFlags(up *upOptions, create *createOptions) error {
 			return fmt.Errorf("--detach cannot be combined with --abort-on-container-exit, --abort-on-container-failure, --attach, --attach-dependencies or --watch")
 		}
 	}
-	if create.noInherit && create.noRecreate {
+	if create.isRenewAnonVolumes() && create.noRecreate {
 		return fmt.Errorf("--no-recreate and --renew-anon-volumes are incompatible")
 	}
-	if create.forceRecreate && create.noRecreate {
+	if create.isForceRecreate() && create.noRecreate {
 		return fmt.Errorf("--force-recreate and --no-recreate are incompatible")
 	}
 	if create.recreateDeps && create.noRecreate {
@@ -278,11 +279,20 @@ func runUp(
 		IgnoreOrphans:        createOptions.ignoreOrphans,
 		Recreate:             createOptions.recreateStrategy(),
 		RecreateDependencies: createOptions.dependenciesRecreateStrategy(),
+		ForceRecreate:        createOptions.forceRecreateServices,
+		RenewAnonVolumes:     createOptions.renewAnonVolumes(project, services),
 		Inherit:              !createOptions.noInherit,
 		Timeout:              createOptions.GetTimeout(),
 		QuietPull:            createOptions.quietPull,
 	}
 
+	if createOptions.locked {
+		create.ImagesLock, err = loadImagesLock(project)
+		if err != nil {
+			return err
+		}
+	}
+
 	if createOptions.AssumeYes {
 		backendOptions.Options = append(backendOptions.Options, compose.WithPrompt(compose.AlwaysOkPrompt()))
 	}
@@ -301,34 +311,10 @@ func runUp(
 	if !upOptions.Detach {
 		consumer = formatter.NewLogConsumer(ctx, dockerCli.Out(), dockerCli.Err(), !upOptions.noColor, !upOptions.noPrefix, upOptions.timestamp)
 
-		var attachSet utils.Set[string]
-		if len(upOptions.attach) != 0 {
-			// services are passed explicitly with --attach, verify they're valid and then use them as-is
-			attachSet = utils.NewSet(upOptions.attach...)
-			unexpectedSvcs := attachSet.Diff(utils.NewSet(project.ServiceNames()...))
-			if len(unexpectedSvcs) != 0 {
-				return fmt.Errorf("cannot attach to services not included in up: %s", strings.Join(unexpectedSvcs.Elements(), ", "))
-			}
-		} else {
-			// mark services being launched (and potentially their deps) for attach
-			// if they didn't opt-out via Compose YAML
-			attachSet = utils.NewSet[string]()
-			var dependencyOpt types.DependencyOption = types.IgnoreDependencies
-			if upOptions.attachDependencies {
-				dependencyOpt = types.IncludeDependencies
-			}
-			if err := project.ForEachService(services, func(serviceName string, s *types.ServiceConfig) error {
-				if s.Attach == nil || *s.Attach {
-					attachSet.Add(serviceName)
-				}
-				return nil
-			}, dependencyOpt); err != nil {
-				return err
-			}
+		attach, err = attachedServices(project, services, upOptions)
+		if err != nil {
+			return err
 		}
-		// filter out any services that have been explicitly marked for ignore with `--no-attach`
-		attachSet.RemoveAll(upOptions.noAttach...)
-		attach = attachSet.Elements()
 	}
 
 	var timeout time.Duration
@@ -352,6 +338,39 @@ func runUp(
 	})
 }
 
+// attachedServices returns the services to stream logs from, as selected by --attach, --no-attach and
+// --attach-dependencies. Both --attach and --no-attach accept comma-separated service names.
+func attachedServices(project *types.Project, services []string, upOptions upOptions) ([]string, error) {
+	var attachSet utils.Set[string]
+	if attach := appendServiceNames(nil, upOptions.attach...); len(attach) != 0 {
+		// services are passed explicitly with --attach, verify they're valid and then use them as-is
+		attachSet = utils.NewSet(attach...)
+		unexpectedSvcs := attachSet.Diff(utils.NewSet(project.ServiceNames()...))
+		if len(unexpectedSvcs) != 0 {
+			return nil, fmt.Errorf("cannot attach to services not included in up: %s", strings.Join(unexpectedSvcs.Elements(), ", "))
+		}
+	} else {
+		// mark services being launched (and potentially their deps) for attach
+		// if they didn't opt-out via Compose YAML
+		attachSet = utils.NewSet[string]()
+		var dependencyOpt types.DependencyOption = types.IgnoreDependencies
+		if upOptions.attachDependencies {
+			dependencyOpt = types.IncludeDependencies
+		}
+		if err := project.ForEachService(services, func(serviceName string, s *types.ServiceConfig) error {
+			if s.Attach == nil || *s.Attach {
+				attachSet.Add(serviceName)
+			}
+			return nil
+		}, dependencyOpt); err != nil {
+			return nil, err
+		}
+	}
+	// filter out any services that have been explicitly marked for ignore with `--no-attach`
+	attachSet.RemoveAll(appendServiceNames(nil, upOptions.noAttach...)...)
+	return attachSet.Elements(), nil
+}
+
 func setServiceScale(project *types.Project, name string, replicas int) error {
 	service, err := project.GetService(name)
 	if err != nil {
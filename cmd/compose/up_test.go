@@ -23,12 +23,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/compose-spec/compose-go/v2/loader"
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/cli/cli/streams"
+	"github.com/spf13/pflag"
 	"go.uber.org/mock/gomock"
 	"gotest.tools/v3/assert"
 
@@ -80,6 +82,100 @@ func TestApplyScaleOpt(t *testing.T) {
 	assert.Equal(t, *bar.Deploy.Replicas, 3)
 }
 
+func TestAttachedServices(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			"web":    {Name: "web", DependsOn: types.DependsOnConfig{"db": {Condition: types.ServiceConditionStarted}}},
+			"db":     {Name: "db"},
+			"worker": {Name: "worker"},
+		},
+	}
+	tests := []struct {
+		name     string
+		services []string
+		opts     upOptions
+		expected []string
+		err      string
+	}{
+		{name: "default", expected: []string{"db", "web", "worker"}},
+		{name: "selected services", services: []string{"web"}, expected: []string{"web"}},
+		{name: "dependencies", services: []string{"web"}, opts: upOptions{attachDependencies: true}, expected: []string{"db", "web"}},
+		{name: "comma-separated attach", opts: upOptions{attach: []string{"web,db"}}, expected: []string{"db", "web"}},
+		{name: "repeated attach", opts: upOptions{attach: []string{"web", "db"}}, expected: []string{"db", "web"}},
+		{name: "no-attach", opts: upOptions{noAttach: []string{"worker,db"}}, expected: []string{"web"}},
+		{name: "unknown service", opts: upOptions{attach: []string{"web,api"}}, err: "cannot attach to services not included in up: api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attach, err := attachedServices(project, tt.services, tt.opts)
+			if tt.err != "" {
+				assert.Error(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			slices.Sort(attach)
+			assert.DeepEqual(t, attach, tt.expected)
+		})
+	}
+}
+
+func TestForceRecreateFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		all      bool
+		services []string
+		strategy string
+	}{
+		{args: nil, strategy: api.RecreateDiverged},
+		{args: []string{"--force-recreate"}, all: true, strategy: api.RecreateForce},
+		{args: []string{"--force-recreate=false"}, strategy: api.RecreateDiverged},
+		{args: []string{"--force-recreate=web, worker,web"}, services: []string{"web", "worker"}, strategy: api.RecreateDiverged},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			opts := createOptions{}
+			flags := pflag.NewFlagSet("up", pflag.ContinueOnError)
+			opts.addForceRecreateFlag(flags)
+			assert.NilError(t, flags.Parse(tt.args))
+			assert.Equal(t, opts.forceRecreate, tt.all)
+			assert.DeepEqual(t, opts.forceRecreateServices, tt.services)
+			assert.Equal(t, opts.recreateStrategy(), tt.strategy)
+		})
+	}
+
+	opts := createOptions{forceRecreateServices: []string{"web"}, noRecreate: true}
+	assert.Error(t, validateFlags(&upOptions{}, &opts), "--force-recreate and --no-recreate are incompatible")
+
+	project := &types.Project{Services: types.Services{"web": {Name: "web"}}}
+	opts = createOptions{forceRecreateServices: []string{"web", "unknown"}}
+	assert.ErrorContains(t, opts.Apply(project), "invalid --force-recreate option")
+}
+
+func TestRenewAnonVolumesFlag(t *testing.T) {
+	project := &types.Project{Services: types.Services{"web": {Name: "web"}, "db": {Name: "db"}}}
+	parse := func(args ...string) createOptions {
+		opts := createOptions{}
+		flags := pflag.NewFlagSet("up", pflag.ContinueOnError)
+		opts.addRenewAnonVolumesFlag(flags)
+		assert.NilError(t, flags.Parse(args))
+		return opts
+	}
+
+	assert.Assert(t, parse().renewAnonVolumes(project, nil) == nil)
+
+	opts := parse("-V")
+	assert.Equal(t, opts.recreateStrategy(), api.RecreateForce)
+	assert.DeepEqual(t, opts.renewAnonVolumes(project, nil), []string{"db", "web"})
+	assert.DeepEqual(t, opts.renewAnonVolumes(project, []string{"web"}), []string{"web"})
+
+	opts = parse("--renew-anon-volumes=db")
+	assert.Equal(t, opts.recreateStrategy(), api.RecreateDiverged)
+	assert.DeepEqual(t, opts.renewAnonVolumes(project, []string{"web"}), []string{"db"})
+
+	opts.noRecreate = true
+	assert.Error(t, validateFlags(&upOptions{}, &opts), "--no-recreate and --renew-anon-volumes are incompatible")
+}
+
 func TestUpOptions_OnExit(t *testing.T) {
 	tests := []struct {
 		name string
@@ -119,6 +215,29 @@ func TestUpOptions_OnExit(t *testing.T) {
 	}
 }
 
+func TestValidateAbortOnContainerFailure(t *testing.T) {
+	up := upOptions{cascadeFail: true, cascadeStop: true}
+	assert.Error(t, validateFlags(&up, &createOptions{}), "--abort-on-container-failure cannot be combined with --abort-on-container-exit")
+
+	up = upOptions{cascadeFail: true, exitCodeFrom: "web"}
+	assert.NilError(t, validateFlags(&up, &createOptions{}))
+	assert.Equal(t, up.OnExit(), api.CascadeFail)
+
+	up = upOptions{cascadeFail: true}
+	up.Detach = true
+	assert.ErrorContains(t, validateFlags(&up, &createOptions{}), "--detach cannot be combined with")
+}
+
+func TestExitCodeFromServices(t *testing.T) {
+	project := &types.Project{Services: types.Services{"test-a": {Name: "test-a"}, "test-b": {Name: "test-b"}}}
+
+	_, err := upOptions{exitCodeFrom: "test-a, test-b"}.apply(project, nil)
+	assert.NilError(t, err)
+
+	_, err = upOptions{exitCodeFrom: "test-a,test-c"}.apply(project, nil)
+	assert.ErrorContains(t, err, "test-c")
+}
+
 func TestRunUpAllowsTemplatedPortFieldsInRemoteStackPrompt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -79,12 +79,15 @@ func runViz(ctx context.Context, dockerCli command.Cli, backendOptions *BackendO
 	}
 
 	// build graph
-	graphStr, _ := backend.Viz(ctx, project, api.VizOptions{
+	graphStr, err := backend.Viz(ctx, project, api.VizOptions{
 		IncludeNetworks:  opts.includeNetworks,
 		IncludePorts:     opts.includePorts,
 		IncludeImageName: opts.includeImageName,
 		Indentation:      opts.indentationStr,
 	})
+	if err != nil {
+		return err
+	}
 
 	fmt.Println(graphStr)
 
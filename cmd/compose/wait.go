@@ -18,7 +18,10 @@ package compose
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
@@ -34,8 +37,13 @@ type waitOptions struct {
 	services []string
 
 	downProject bool
+	condition   string
+	timeout     int
 }
 
+// waitTimeoutExitCode is the exit code used when services didn't reach the expected state within --timeout
+const waitTimeoutExitCode = 124
+
 func waitCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
 	opts := waitOptions{
 		ProjectOptions: p,
@@ -50,6 +58,9 @@ func waitCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 		RunE: Adapt(func(ctx context.Context, services []string) error {
 			opts.services = services
 			statusCode, err = runWait(ctx, dockerCli, backendOptions, &opts)
+			if errors.Is(err, api.ErrTimeout) {
+				return cli.StatusError{StatusCode: waitTimeoutExitCode, Status: err.Error()}
+			}
 			return err
 		}),
 		PostRun: func(cmd *cobra.Command, args []string) {
@@ -58,6 +69,8 @@ func waitCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Backe
 	}
 
 	cmd.Flags().BoolVar(&opts.downProject, "down-project", false, "Drops project when the first container stops")
+	cmd.Flags().StringVar(&opts.condition, "condition", "", "Wait for services to reach a condition rather than to stop. Values: [service_healthy | service_completed_successfully]")
+	cmd.Flags().IntVar(&opts.timeout, "timeout", 0, fmt.Sprintf("Maximum duration in seconds to wait for, exits with code %d on timeout", waitTimeoutExitCode))
 
 	return cmd
 }
@@ -75,5 +88,7 @@ func runWait(ctx context.Context, dockerCli command.Cli, backendOptions *Backend
 	return backend.Wait(ctx, name, api.WaitOptions{
 		Services:                   opts.services,
 		DownProjectOnContainerExit: opts.downProject,
+		Condition:                  opts.condition,
+		Timeout:                    time.Duration(opts.timeout) * time.Second,
 	})
 }
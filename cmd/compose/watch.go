@@ -19,6 +19,7 @@ package compose
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/cli/cli/command"
@@ -29,12 +30,14 @@ import (
 	"github.com/docker/compose/v5/internal/locker"
 	"github.com/docker/compose/v5/pkg/api"
 	"github.com/docker/compose/v5/pkg/compose"
+	"github.com/docker/compose/v5/pkg/watch"
 )
 
 type watchOptions struct {
 	*ProjectOptions
-	prune bool
-	noUp  bool
+	prune    bool
+	noUp     bool
+	debounce time.Duration
 }
 
 func watchCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *BackendOptions) *cobra.Command {
@@ -62,6 +65,7 @@ func watchCommand(p *ProjectOptions, dockerCli command.Cli, backendOptions *Back
 	cmd.Flags().BoolVar(&buildOpts.quiet, "quiet", false, "hide build output")
 	cmd.Flags().BoolVar(&watchOpts.prune, "prune", true, "Prune dangling images on rebuild")
 	cmd.Flags().BoolVar(&watchOpts.noUp, "no-up", false, "Do not build & start services before watching")
+	cmd.Flags().DurationVar(&watchOpts.debounce, "debounce", watch.QuietPeriod, "Time to wait for file changes to settle before updating a service")
 	return cmd
 }
 
@@ -128,5 +132,7 @@ func runWatch(ctx context.Context, dockerCli command.Cli, backendOptions *Backen
 		LogTo:    consumer,
 		Prune:    watchOpts.prune,
 		Services: services,
+		NoUp:     watchOpts.noUp,
+		Debounce: watchOpts.debounce,
 	})
 }
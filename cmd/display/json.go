@@ -21,6 +21,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/compose/v5/pkg/api"
 )
@@ -28,12 +31,20 @@ import (
 func JSON(out io.Writer) api.EventProcessor {
 	return &jsonWriter{
 		out: out,
+		now: time.Now,
 	}
 }
 
 type jsonWriter struct {
+	mtx    sync.Mutex
 	out    io.Writer
 	dryRun bool
+	now    func() time.Time
+}
+
+// flusher is implemented by buffered writers, which need to be flushed for events to reach streaming consumers
+type flusher interface {
+	Flush() error
 }
 
 type jsonMessage struct {
@@ -47,6 +58,9 @@ type jsonMessage struct {
 	Current  int64  `json:"current,omitempty"`
 	Total    int64  `json:"total,omitempty"`
 	Percent  int    `json:"percent,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Time     string `json:"time,omitempty"`
 }
 
 func (p *jsonWriter) Start(ctx context.Context, operation string) {
@@ -65,9 +79,24 @@ func (p *jsonWriter) Event(e api.Resource) {
 		Total:    e.Total,
 		Percent:  e.Percent,
 	}
+	// resource IDs are set by Compose as "Type name", e.g. "Container myproject-web-1"
+	if kind, name, ok := strings.Cut(e.ID, " "); ok {
+		message.Type = strings.ToLower(kind)
+		message.Name = name
+	}
+	if p.now != nil {
+		message.Time = p.now().UTC().Format(time.RFC3339Nano)
+	}
 	marshal, err := json.Marshal(message)
-	if err == nil {
-		_, _ = fmt.Fprintln(p.out, string(marshal))
+	if err != nil {
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	_, _ = fmt.Fprintln(p.out, string(marshal))
+	if f, ok := p.out.(flusher); ok {
+		_ = f.Flush()
 	}
 }
 
@@ -17,9 +17,11 @@
 package display
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 
@@ -60,3 +62,33 @@ func TestJsonWriter_Event(t *testing.T) {
 	}
 	assert.DeepEqual(t, expected, actual)
 }
+
+func TestJsonWriter_ResourceTypeAndTime(t *testing.T) {
+	var out bytes.Buffer
+	buffered := bufio.NewWriter(&out)
+	w := JSON(buffered).(*jsonWriter)
+	w.now = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	w.On(
+		api.Resource{ID: "Container myproject-web-1", Status: api.Working, Text: api.StatusStarting},
+		api.Resource{ID: "Container myproject-web-1", Status: api.Error, Text: api.StatusError, Details: "failed"},
+	)
+
+	// events are flushed as they are written, so streaming consumers get them right away
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Equal(t, len(lines), 2)
+
+	var actual jsonMessage
+	assert.NilError(t, json.Unmarshal(lines[1], &actual))
+	assert.DeepEqual(t, actual, jsonMessage{
+		ID:      "Container myproject-web-1",
+		Status:  "Error",
+		Text:    api.StatusError,
+		Details: "failed",
+		Type:    "container",
+		Name:    "myproject-web-1",
+		Time:    "2024-01-02T03:04:05Z",
+	})
+}
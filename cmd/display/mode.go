@@ -28,6 +28,8 @@ const (
 	ModePlain = "plain"
 	// ModeQuiet don't display events
 	ModeQuiet = "quiet"
+	// ModeQuietErrors only display error events
+	ModeQuietErrors = "quiet-errors"
 	// ModeJSON outputs a machine-readable JSON stream
 	ModeJSON = "json"
 )
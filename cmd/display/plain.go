@@ -20,19 +20,32 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/docker/compose/v5/pkg/api"
 )
 
+// timestampFormat is RFC3339 with milliseconds, so durations can be computed from plain progress output
+const timestampFormat = "2006-01-02T15:04:05.000Z07:00"
+
 func Plain(out io.Writer) api.EventProcessor {
 	return &plainWriter{
 		out: out,
 	}
 }
 
+// PlainWithTimestamps renders progress like Plain, prefixing each line with the time the event was received
+func PlainWithTimestamps(out io.Writer) api.EventProcessor {
+	return &plainWriter{
+		out: out,
+		now: time.Now,
+	}
+}
+
 type plainWriter struct {
 	out    io.Writer
 	dryRun bool
+	now    func() time.Time
 }
 
 func (p *plainWriter) Start(ctx context.Context, operation string) {
@@ -43,6 +56,9 @@ func (p *plainWriter) Event(e api.Resource) {
 	if p.dryRun {
 		prefix = DRYRUN_PREFIX
 	}
+	if p.now != nil {
+		prefix = p.now().Format(timestampFormat) + prefix
+	}
 	_, _ = fmt.Fprintln(p.out, prefix, e.ID, e.Text, e.Details)
 }
 
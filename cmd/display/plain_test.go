@@ -0,0 +1,60 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestPlainWriter_Event(t *testing.T) {
+	var out bytes.Buffer
+	w := Plain(&out)
+
+	w.On(api.Resource{ID: "Container myproject-web-1", Status: api.Done, Text: api.StatusStarted})
+	assert.Equal(t, out.String(), " Container myproject-web-1 Started \n")
+}
+
+func TestPlainWriter_Timestamps(t *testing.T) {
+	var out bytes.Buffer
+	w := PlainWithTimestamps(&out).(*plainWriter)
+	w.now = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	}
+
+	w.On(api.Resource{ID: "Container myproject-web-1", Status: api.Done, Text: api.StatusStarted})
+	assert.Equal(t, out.String(), "2024-01-02T03:04:05.006Z Container myproject-web-1 Started \n")
+
+	out.Reset()
+	w.dryRun = true
+	w.On(api.Resource{ID: "Container myproject-web-1", Status: api.Done, Text: api.StatusStarted})
+	assert.Equal(t, out.String(), "2024-01-02T03:04:05.006Z DRY-RUN MODE -  Container myproject-web-1 Started \n")
+}
+
+func TestPlainWriter_Info(t *testing.T) {
+	var out bytes.Buffer
+	w := Plain(&out)
+
+	w.On(api.Resource{ID: "database", Status: api.Working, Text: api.StatusInfo, Details: "provisioning replica"})
+	w.On(api.Resource{ID: "database", Status: api.Working, Text: "provisioning replica"})
+	assert.Equal(t, out.String(), " database Info provisioning replica\n database provisioning replica \n")
+}
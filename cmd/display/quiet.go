@@ -18,6 +18,8 @@ package display
 
 import (
 	"context"
+	"fmt"
+	"io"
 
 	"github.com/docker/compose/v5/pkg/api"
 )
@@ -36,3 +38,28 @@ func (q *quiet) Done(_ string, _ bool) {
 
 func (q *quiet) On(_ ...api.Resource) {
 }
+
+// QuietErrors doesn't display events, except those reporting an error
+func QuietErrors(out io.Writer) api.EventProcessor {
+	return &quietErrors{
+		out: out,
+	}
+}
+
+type quietErrors struct {
+	out io.Writer
+}
+
+func (q *quietErrors) Start(_ context.Context, _ string) {
+}
+
+func (q *quietErrors) Done(_ string, _ bool) {
+}
+
+func (q *quietErrors) On(events ...api.Resource) {
+	for _, e := range events {
+		if e.Status == api.Error {
+			_, _ = fmt.Fprintln(q.out, e.ID, e.Text, e.Details)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestQuietErrors(t *testing.T) {
+	var out bytes.Buffer
+	w := QuietErrors(&out)
+
+	w.On(
+		api.Resource{ID: "Container myproject-web-1", Status: api.Working, Text: api.StatusCreating},
+		api.Resource{ID: "Container myproject-web-1", Status: api.Done, Text: api.StatusCreated},
+		api.Resource{ID: "Container myproject-db-1", Status: api.Warning, Text: api.StatusWarning, Details: "deprecated"},
+		api.Resource{ID: "Container myproject-web-1", Status: api.Error, Text: api.StatusError, Details: "port is already allocated"},
+	)
+	assert.Equal(t, out.String(), "Container myproject-web-1 Error port is already allocated\n")
+}
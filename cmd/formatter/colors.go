@@ -18,9 +18,9 @@ package formatter
 
 import (
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/docker/cli/cli/command"
 )
@@ -65,11 +65,14 @@ const ansiColorOffset = 30
 // SetANSIMode configure formatter for colored output on ANSI-compliant console
 func SetANSIMode(streams command.Streams, ansi string) {
 	if !useAnsi(streams, ansi) {
-		nextColor = func() colorFunc {
+		serviceColor = func(string) colorFunc {
 			return monochrome
 		}
 		disableAnsi = true
+		return
 	}
+	serviceColor = hashColor
+	disableAnsi = false
 }
 
 func useAnsi(streams command.Streams, ansi string) bool {
@@ -113,18 +116,27 @@ func makeColorFunc(code string) colorFunc {
 }
 
 var (
-	nextColor    = rainbowColor
+	serviceColor = hashColor
 	rainbow      []colorFunc
-	currentIndex = 0
-	mutex        sync.Mutex
 )
 
-func rainbowColor() colorFunc {
-	mutex.Lock()
-	defer mutex.Unlock()
-	result := rainbow[currentIndex]
-	currentIndex = (currentIndex + 1) % len(rainbow)
-	return result
+// hashColor selects the color for a service from the rainbow palette based on a hash of its name, so a service is
+// always rendered with the same color, whatever the other services running or the order logs are received
+func hashColor(name string) colorFunc {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return rainbow[mixHash(h.Sum32())%uint32(len(rainbow))]
+}
+
+// mixHash spreads bits of h (murmur3 finalizer), as FNV hashes of short service names only differing by
+// their last character, like `api` and `app`, would otherwise often select the same color
+func mixHash(h uint32) uint32 {
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
 }
 
 func init() {
@@ -27,4 +27,6 @@ const (
 	PRETTY = "pretty"
 	// TABLE Print output in table format with column headers (default)
 	TABLE = "table"
+	// TEXT Print logs as plain text lines (default)
+	TEXT = "text"
 )
@@ -18,8 +18,10 @@ package formatter
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,6 +29,7 @@ import (
 
 	"github.com/buger/goterm"
 	"github.com/moby/moby/client/pkg/jsonmessage"
+	"github.com/moby/moby/client/pkg/stringid"
 
 	"github.com/docker/compose/v5/pkg/api"
 )
@@ -57,6 +60,19 @@ func NewLogConsumer(ctx context.Context, stdout, stderr io.Writer, color, prefix
 	}
 }
 
+// serviceName returns the service name of a container name, stripping its replica number, so all
+// replicas of a service are rendered with the same color
+func serviceName(container string) string {
+	i := strings.LastIndex(container, api.Separator)
+	if i <= 0 {
+		return container
+	}
+	if _, err := strconv.Atoi(container[i+len(api.Separator):]); err != nil {
+		return container
+	}
+	return container[:i]
+}
+
 func (l *logConsumer) register(name string) *presenter {
 	var p *presenter
 	root, _, found := strings.Cut(name, " ")
@@ -76,7 +92,7 @@ func (l *logConsumer) register(name string) *presenter {
 			case api.WatchLogger:
 				cf = makeColorFunc("92")
 			default:
-				cf = nextColor()
+				cf = serviceColor(serviceName(name))
 			}
 		}
 		p = &presenter{
@@ -184,3 +200,177 @@ func (l logDecorator) Status(container, msg string) {
 	l.decorated.Status(container, msg)
 	l.After()
 }
+
+// NewLogFilter returns a LogConsumer only forwarding log lines matching pattern to consumer, or lines
+// not matching pattern when invert is set
+func NewLogFilter(consumer api.LogConsumer, pattern *regexp.Regexp, invert bool) api.LogConsumer {
+	filter := logFilter{
+		decorated: consumer,
+		pattern:   pattern,
+		invert:    invert,
+	}
+	if records, ok := consumer.(api.LogRecordConsumer); ok {
+		return logRecordFilter{
+			logFilter: filter,
+			decorated: records,
+		}
+	}
+	return filter
+}
+
+type logFilter struct {
+	decorated api.LogConsumer
+	pattern   *regexp.Regexp
+	invert    bool
+}
+
+func (l logFilter) Log(containerName, message string) {
+	if message, ok := l.filter(message); ok {
+		l.decorated.Log(containerName, message)
+	}
+}
+
+func (l logFilter) Err(containerName, message string) {
+	if message, ok := l.filter(message); ok {
+		l.decorated.Err(containerName, message)
+	}
+}
+
+func (l logFilter) Status(container, msg string) {
+	l.decorated.Status(container, msg)
+}
+
+// filter returns the lines of message to be forwarded, and false when there's none
+func (l logFilter) filter(message string) (string, bool) {
+	var lines []string
+	for line := range strings.SplitSeq(message, "\n") {
+		if l.pattern.MatchString(line) != l.invert {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), len(lines) > 0
+}
+
+type logRecordFilter struct {
+	logFilter
+	decorated api.LogRecordConsumer
+}
+
+func (l logRecordFilter) LogRecord(record api.LogRecord) {
+	if message, ok := l.filter(record.Message); ok {
+		record.Message = message
+		l.decorated.LogRecord(record)
+	}
+}
+
+// LogRecordFormat defines how a LogConsumer created by NewLogRecordConsumer renders log records
+type LogRecordFormat struct {
+	// ContainerID adds the short ID of containers to their name, so the prefix of log lines identifies the container
+	ContainerID bool
+	// Timestamp, if set, renders the time log messages were written, added at the beginning of messages
+	Timestamp func(time.Time) string
+}
+
+// NewLogRecordConsumer returns a LogConsumer rendering log records as log messages sent to consumer
+func NewLogRecordConsumer(consumer api.LogConsumer, format LogRecordFormat) api.LogRecordConsumer {
+	return logRecordConsumer{
+		LogConsumer: consumer,
+		format:      format,
+	}
+}
+
+type logRecordConsumer struct {
+	api.LogConsumer
+	format LogRecordFormat
+}
+
+func (l logRecordConsumer) LogRecord(record api.LogRecord) {
+	name := record.Container
+	if l.format.ContainerID && record.ContainerID != "" {
+		name = fmt.Sprintf("%s %s", name, stringid.TruncateID(record.ContainerID))
+	}
+	message := record.Message
+	if l.format.Timestamp != nil && !record.Timestamp.IsZero() {
+		message = fmt.Sprintf("%s %s", l.format.Timestamp(record.Timestamp), message)
+	}
+	l.Log(name, message)
+}
+
+// Timestamp formats supported by TimestampFormatter, other values being used as a Go time layout
+const (
+	TimestampRFC3339Nano = "rfc3339nano"
+	TimestampLocal       = "local"
+)
+
+// TimestampFormatter returns a func rendering timestamps according to format. The default format, as well as
+// TimestampRFC3339Nano, renders UTC time with nanoseconds, as the engine does. TimestampLocal renders local time
+// with milliseconds. Any other format is a Go time layout (see https://pkg.go.dev/time#pkg-constants) applied to
+// local time.
+func TimestampFormatter(format string) (func(time.Time) string, error) {
+	switch format {
+	case "", TimestampRFC3339Nano:
+		return func(t time.Time) string {
+			return t.UTC().Format(jsonmessage.RFC3339NanoFixed)
+		}, nil
+	case TimestampLocal:
+		format = "2006-01-02 15:04:05.000"
+	}
+	reference := time.Date(1999, 12, 31, 23, 58, 59, 0, time.UTC)
+	if reference.Format(format) == format {
+		return nil, fmt.Errorf("invalid timestamp format %q: not a Go time layout", format)
+	}
+	return func(t time.Time) string {
+		return t.Local().Format(format)
+	}, nil
+}
+
+// NewJSONLogConsumer creates a LogConsumer writing log messages to out as JSON lines
+func NewJSONLogConsumer(out io.Writer) api.LogRecordConsumer {
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	return &jsonLogConsumer{
+		encoder: encoder,
+	}
+}
+
+type jsonLogConsumer struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+type jsonLogLine struct {
+	Service     string `json:"service,omitempty"`
+	Container   string `json:"container"`
+	ContainerID string `json:"container_id,omitempty"`
+	Timestamp   string `json:"timestamp,omitempty"`
+	Stream      string `json:"stream"`
+	Message     string `json:"message"`
+}
+
+func (l *jsonLogConsumer) Log(containerName, message string) {
+	l.LogRecord(api.LogRecord{Container: containerName, Stream: api.StdoutStream, Message: message})
+}
+
+func (l *jsonLogConsumer) Err(containerName, message string) {
+	l.LogRecord(api.LogRecord{Container: containerName, Stream: api.StderrStream, Message: message})
+}
+
+// Status is ignored, as it doesn't come from the container output
+func (l *jsonLogConsumer) Status(string, string) {}
+
+func (l *jsonLogConsumer) LogRecord(record api.LogRecord) {
+	line := jsonLogLine{
+		Service:     record.Service,
+		Container:   record.Container,
+		ContainerID: record.ContainerID,
+		Stream:      record.Stream,
+		Message:     record.Message,
+	}
+	if !record.Timestamp.IsZero() {
+		line.Timestamp = record.Timestamp.Format(time.RFC3339Nano)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Encode writes a single line, so a line is flushed as soon as it is received
+	_ = l.encoder.Encode(line)
+}
@@ -0,0 +1,183 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestLogFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		invert   bool
+		expected string
+	}{
+		{
+			name:     "matching lines",
+			pattern:  "ERROR",
+			expected: "ERROR failed to connect\nERROR retrying\n",
+		},
+		{
+			name:     "case insensitive",
+			pattern:  "(?i)error",
+			expected: "ERROR failed to connect\nERROR retrying\nno error\n",
+		},
+		{
+			name:     "inverted",
+			pattern:  "ERROR",
+			invert:   true,
+			expected: "starting\nno error\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			consumer := NewLogFilter(NewLogConsumer(t.Context(), out, out, false, false, false), regexp.MustCompile(tt.pattern), tt.invert)
+			consumer.Log("web", "starting")
+			consumer.Log("web", "ERROR failed to connect\nERROR retrying")
+			consumer.Err("web", "no error")
+			assert.Equal(t, out.String(), tt.expected)
+		})
+	}
+}
+
+func TestJSONLogConsumer(t *testing.T) {
+	out := &bytes.Buffer{}
+	consumer := NewJSONLogConsumer(out)
+	consumer.LogRecord(api.LogRecord{
+		Service:     "web",
+		Container:   "web-1",
+		ContainerID: "abc123",
+		Timestamp:   time.Date(2024, 1, 2, 13, 23, 37, 123456789, time.UTC),
+		Stream:      api.StderrStream,
+		Message:     "<failed> to connect",
+	})
+	consumer.Log("provider", "deployed")
+	consumer.Status("web-1", "exited with code 0")
+	assert.Equal(t, out.String(), `{"service":"web","container":"web-1","container_id":"abc123","timestamp":"2024-01-02T13:23:37.123456789Z","stream":"stderr","message":"<failed> to connect"}
+{"container":"provider","stream":"stdout","message":"deployed"}
+`)
+}
+
+func TestLogFilterRecords(t *testing.T) {
+	out := &bytes.Buffer{}
+	consumer := NewLogFilter(NewJSONLogConsumer(out), regexp.MustCompile("ERROR"), false)
+	records, ok := consumer.(api.LogRecordConsumer)
+	assert.Assert(t, ok)
+	records.LogRecord(api.LogRecord{Container: "web-1", Stream: api.StdoutStream, Message: "starting"})
+	records.LogRecord(api.LogRecord{Container: "web-1", Stream: api.StdoutStream, Message: "ERROR failed"})
+	assert.Equal(t, out.String(), `{"container":"web-1","stream":"stdout","message":"ERROR failed"}
+`)
+}
+
+func TestLogRecordConsumer(t *testing.T) {
+	out := &bytes.Buffer{}
+	timestamp, err := TimestampFormatter("")
+	assert.NilError(t, err)
+	consumer := NewLogRecordConsumer(NewLogConsumer(t.Context(), out, out, false, true, false), LogRecordFormat{
+		ContainerID: true,
+		Timestamp:   timestamp,
+	})
+	consumer.LogRecord(api.LogRecord{
+		Container:   "web-1",
+		ContainerID: "1a2b3c4d5e6f7a8b9c0d",
+		Timestamp:   time.Date(2024, 1, 2, 13, 23, 37, 123456789, time.UTC),
+		Message:     "starting",
+	})
+	consumer.LogRecord(api.LogRecord{
+		Container:   "web-10",
+		ContainerID: "0f9e8d7c6b5a4f3e2d1c",
+		Message:     "ready",
+	})
+	// prefixes are padded to the longest container name, so log lines stay aligned
+	assert.Equal(t, out.String(), "web-1 1a2b3c4d5e6f  | 2024-01-02T13:23:37.123456789Z starting\n"+
+		"web-10 0f9e8d7c6b5a  | ready\n")
+}
+
+func TestTimestampFormatter(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 13, 23, 37, 120000000, time.FixedZone("CET", 3600))
+	tests := []struct {
+		format   string
+		expected string
+		err      string
+	}{
+		{format: "", expected: "2024-01-02T12:23:37.120000000Z"},
+		{format: TimestampRFC3339Nano, expected: "2024-01-02T12:23:37.120000000Z"},
+		{format: TimestampLocal, expected: ts.Local().Format("2006-01-02 15:04:05.000")},
+		{format: "15:04:05", expected: ts.Local().Format("15:04:05")},
+		{format: "hh:mm:ss", err: `invalid timestamp format "hh:mm:ss": not a Go time layout`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			format, err := TimestampFormatter(tt.format)
+			if tt.err != "" {
+				assert.Error(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, format(ts), tt.expected)
+		})
+	}
+}
+
+func TestLogConsumerServiceColors(t *testing.T) {
+	render := func(services ...string) map[string]string {
+		out := &bytes.Buffer{}
+		consumer := NewLogConsumer(t.Context(), out, out, true, true, false)
+		for _, service := range services {
+			consumer.Log(service, "hello")
+		}
+		lines := map[string]string{}
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			for _, service := range services {
+				if strings.Contains(line, service+" ") {
+					lines[service] = line[:strings.Index(line, service)]
+				}
+			}
+		}
+		return lines
+	}
+
+	colors := render("web", "db", "worker")
+	assert.DeepEqual(t, render("worker", "db", "web"), colors)
+	assert.DeepEqual(t, render("db", "worker", "web"), colors)
+	// prefix is the ANSI code for the service color
+	assert.Equal(t, colors["web"], ansiColorCode("34"))
+
+	// replicas of a service share its color
+	replicas := render("web-1", "web-2", "web-10")
+	assert.Equal(t, replicas["web-1"], colors["web"])
+	assert.Equal(t, replicas["web-2"], colors["web"])
+	assert.Equal(t, replicas["web-10"], colors["web"])
+}
+
+func TestServiceName(t *testing.T) {
+	assert.Equal(t, serviceName("web-1"), "web")
+	assert.Equal(t, serviceName("my-app-12"), "my-app")
+	assert.Equal(t, serviceName("web"), "web")
+	assert.Equal(t, serviceName("web-run-3f2a1b"), "web-run-3f2a1b")
+	assert.Equal(t, serviceName("-1"), "-1")
+}
@@ -40,6 +40,13 @@ import (
 // Multiple listeners can be registered, and all will be notified of events.
 type LoadListener func(event string, metadata map[string]any)
 
+// EnvFileResolver resolves `env_file` entries using a custom scheme, like `vault://path/to/secret`,
+// into environment variables. It is registered for a scheme with [compose.WithEnvFileResolver]
+type EnvFileResolver interface {
+	// Resolve returns the KEY=VALUE pairs for the env_file reference, including its scheme
+	Resolve(ctx context.Context, reference string) ([]string, error)
+}
+
 // ProjectLoadOptions configures how a Compose project should be loaded
 type ProjectLoadOptions struct {
 	// ProjectName to use, or empty to infer from directory
@@ -127,6 +134,8 @@ type Compose interface {
 	Events(ctx context.Context, projectName string, options EventsOptions) error
 	// Port executes the equivalent to a `compose port`
 	Port(ctx context.Context, projectName string, service string, port uint16, options PortOptions) (string, int, error)
+	// Ports executes the equivalent to a `compose port --all`
+	Ports(ctx context.Context, projectName string, service string, options PortOptions) ([]ContainerPortPublisher, error)
 	// Publish executes the equivalent to a `compose publish`
 	Publish(ctx context.Context, project *types.Project, repository string, options PublishOptions) error
 	// Images executes the equivalent of a `compose images`
@@ -166,6 +175,10 @@ type WaitOptions struct {
 	Services []string
 	// Executes a down when a container exits
 	DownProjectOnContainerExit bool
+	// Condition services are waited for, as a `depends_on` condition. Wait for containers to exit when not set
+	Condition string
+	// Timeout to wait for, after which ErrTimeout is returned. Wait forever when not set
+	Timeout time.Duration
 }
 
 type VizOptions struct {
@@ -177,8 +190,21 @@ type VizOptions struct {
 	IncludeImageName bool
 	// Indentation string to be used to indent graphviz code, e.g. "\t", "    "
 	Indentation string
+	// IncludeResources if true, networks and volumes used by services are added to the graph as nodes
+	IncludeResources bool
+	// IncludeConditions if true, dependency edges are labeled with their depends_on condition
+	IncludeConditions bool
+	// Format of the graph, either VizFormatDot (default) or VizFormatMermaid
+	Format string
 }
 
+const (
+	// VizFormatDot renders the graph using the Graphviz DOT language
+	VizFormatDot = "dot"
+	// VizFormatMermaid renders the graph as a Mermaid flowchart
+	VizFormatMermaid = "mermaid"
+)
+
 // WatchLogger is a reserved name to log watch events
 const WatchLogger = "#watch"
 
@@ -188,6 +214,11 @@ type WatchOptions struct {
 	LogTo    LogConsumer
 	Prune    bool
 	Services []string
+	// NoUp is set when services have not been started before watch, so they must already be running
+	NoUp bool
+	// Debounce is the quiet period to wait for after a file event before applying changes to a service.
+	// Defaults to watch.QuietPeriod when not set
+	Debounce time.Duration
 }
 
 // BuildOptions group options of the Build API
@@ -224,8 +255,26 @@ type BuildOptions struct {
 	Provenance string
 	// SBOM generate a SBOM attestation
 	SBOM string
+	// CacheFrom overrides the external cache sources of all services
+	CacheFrom []string
+	// CacheTo overrides the cache export destinations of all services
+	CacheTo []string
 	// Out is the stream to write build progress
 	Out io.Writer
+	// Results, if set, receives the result of each service build. It can be called concurrently
+	Results func(BuildResult)
+}
+
+// BuildResult is the outcome of building a service image
+type BuildResult struct {
+	Service  string
+	Image    string
+	ImageID  string
+	Duration time.Duration
+	Cached   bool
+	Error    error
+	// NotBuilt is set when the service build didn't complete, as it was interrupted by another service failing
+	NotBuilt bool
 }
 
 // Apply mutates project according to build options
@@ -253,6 +302,12 @@ func (o BuildOptions) Apply(project *types.Project) error {
 
 		service.Build.Pull = service.Build.Pull || o.Pull
 		service.Build.NoCache = service.Build.NoCache || o.NoCache
+		if len(o.CacheFrom) > 0 {
+			service.Build.CacheFrom = o.CacheFrom
+		}
+		if len(o.CacheTo) > 0 {
+			service.Build.CacheTo = o.CacheTo
+		}
 
 		project.Services[name] = service
 	}
@@ -272,6 +327,12 @@ type CreateOptions struct {
 	Recreate string
 	// RecreateDependencies define the strategy to apply on dependencies services
 	RecreateDependencies string
+	// ForceRecreate lists services to be recreated even if their configuration and image haven't changed,
+	// regardless of Recreate and RecreateDependencies
+	ForceRecreate []string
+	// RenewAnonVolumes lists services to be recreated with new anonymous volumes, instead of retrieving data from
+	// the previous containers. Named volumes are not affected. User is asked to confirm data loss
+	RenewAnonVolumes []string
 	// Inherit reuse anonymous volumes from previous container
 	Inherit bool
 	// Timeout set delay to wait for container to gracefully stop before sending SIGKILL
@@ -280,6 +341,8 @@ type CreateOptions struct {
 	QuietPull bool
 	// SkipProviders skips provider services during convergence (e.g. watch rebuild)
 	SkipProviders bool
+	// ImagesLock, if set, is used to verify service images match the locked digests before containers are created
+	ImagesLock ImagesLock
 }
 
 // StartOptions group options of the Start API
@@ -292,7 +355,7 @@ type StartOptions struct {
 	AttachTo []string
 	// OnExit defines behavior when a container stops
 	OnExit Cascade
-	// ExitCodeFrom return exit code from specified service
+	// ExitCodeFrom return exit code from specified service, or the highest exit code from a comma-separated list of services
 	ExitCodeFrom string
 	// Wait won't return until containers reached the running|healthy state
 	Wait        bool
@@ -321,6 +384,8 @@ type RestartOptions struct {
 	Services []string
 	// NoDeps ignores services dependencies
 	NoDeps bool
+	// Signal overrides the signal sent to stop containers before they restart, defaults to SIGTERM
+	Signal string
 }
 
 // StopOptions group options of the Stop API
@@ -337,6 +402,9 @@ type StopOptions struct {
 type UpOptions struct {
 	Create CreateOptions
 	Start  StartOptions
+	// Events is notified about the operation and progress events, in addition to the EventProcessor
+	// configured for the service
+	Events EventProcessor
 }
 
 // DownOptions group options of the Down API
@@ -351,8 +419,14 @@ type DownOptions struct {
 	Images string
 	// Volumes remove volumes, both declared in the `volumes` section and anonymous ones
 	Volumes bool
+	// RemoveExternalVolumes also removes volumes declared as external, after confirmation, unless in use by
+	// containers from another project
+	RemoveExternalVolumes bool
 	// Services passed in the command line to be stopped
 	Services []string
+	// AbortOnProviderFailure stops the teardown when a provider service fails to be removed, which is otherwise
+	// reported once all other resources have been removed
+	AbortOnProviderFailure bool
 }
 
 // ConfigOptions group options of the Config API
@@ -370,6 +444,16 @@ type PushOptions struct {
 	Quiet          bool
 	IgnoreFailures bool
 	ImageMandatory bool
+	// Results, if set, receives the result of each image push. It can be called concurrently
+	Results func(PushResult)
+}
+
+// PushResult is the outcome of pushing a service image
+type PushResult struct {
+	Service string
+	Image   string
+	Digest  string
+	Error   error
 }
 
 // PullOptions group options of the Pull API
@@ -377,8 +461,13 @@ type PullOptions struct {
 	Quiet           bool
 	IgnoreFailures  bool
 	IgnoreBuildable bool
+	// ImagesLock, if set, is used to verify the pulled images match the locked digests
+	ImagesLock ImagesLock
 }
 
+// ImagesLock maps image references to the digest they are expected to resolve to
+type ImagesLock map[string]string
+
 // ImagesOptions group options of the Images API
 type ImagesOptions struct {
 	Services []string
@@ -396,6 +485,12 @@ type KillOptions struct {
 	Signal string
 	// All can be set to true to try to kill all found containers, independently of their state
 	All bool
+	// Remove containers once they have exited
+	Remove bool
+	// RemoveVolumes removes anonymous volumes attached to the removed containers
+	RemoveVolumes bool
+	// Index selects a single replica of the target service, all replicas are killed when 0
+	Index int
 }
 
 // RemoveOptions group options of the Remove API
@@ -434,6 +529,12 @@ type RunOptions struct {
 	Privileged        bool
 	UseNetworkAliases bool
 	NoDeps            bool
+	// Wait for dependencies to be running, or healthy if they declare a healthcheck, before running the command
+	Wait bool
+	// WaitTimeout is the maximum duration to wait for dependencies, no limit if zero
+	WaitTimeout time.Duration
+	// Networks are existing networks to connect the container to, in addition to the service networks
+	Networks []string
 	// used by exec
 	Index int
 }
@@ -509,6 +610,7 @@ func (e Event) String() string {
 
 // ListOptions group options of the ls API
 type ListOptions struct {
+	// All includes projects without running containers
 	All bool
 }
 
@@ -537,6 +639,13 @@ type PortPublisher struct {
 	Protocol      string
 }
 
+// ContainerPortPublisher hold status about a port published by a service container
+type ContainerPortPublisher struct {
+	PortPublisher
+	Container string
+	Number    int
+}
+
 // ContainerSummary hold high-level description of a container
 type ContainerSummary struct {
 	ID           string
@@ -602,8 +711,10 @@ type ContainerProcSummary struct {
 // ImageSummary holds container image description
 type ImageSummary struct {
 	ID          string
+	Service     string
 	Repository  string
 	Tag         string
+	Digest      string
 	Platform    platforms.Platform
 	Size        int64
 	Created     *time.Time
@@ -638,6 +749,10 @@ type PauseOptions struct {
 	Services []string
 	// Project is the compose project used to define this app. Might be nil if user ran command just with project name
 	Project *types.Project
+	// Index selects a single replica of the target service
+	Index int
+	// All selects all containers of the project, including services not declared by the Project
+	All bool
 }
 
 // ExportOptions group options of the Export API
@@ -698,6 +813,12 @@ type Stack struct {
 	Status      string
 	ConfigFiles string
 	Reason      string
+	// Running is the number of running containers of the project
+	Running int
+	// States are the distinct states of the project containers
+	States []string
+	// Labels are the labels set with the same value on all the project containers
+	Labels map[string]string
 }
 
 // LogConsumer is a callback to process log messages from services
@@ -707,6 +828,30 @@ type LogConsumer interface {
 	Status(container, msg string)
 }
 
+// Log streams a container can write to
+const (
+	StdoutStream = "stdout"
+	StderrStream = "stderr"
+)
+
+// LogRecord is a log message along with metadata about its source
+type LogRecord struct {
+	Service     string
+	Container   string
+	ContainerID string
+	// Timestamp is the time the message was written, or zero if unknown
+	Timestamp time.Time
+	Stream    string
+	Message   string
+}
+
+// LogRecordConsumer is a LogConsumer which processes log messages along with their metadata. Logs
+// are sent to such a consumer as LogRecord rather than through the Log callback
+type LogRecordConsumer interface {
+	LogConsumer
+	LogRecord(record LogRecord)
+}
+
 // ContainerEventListener is a callback to process ContainerEvent from services
 type ContainerEventListener func(event ContainerEvent)
 
@@ -36,3 +36,33 @@ func TestRunOptionsEnvironmentMap(t *testing.T) {
 	assert.Equal(t, *env["ZOT"], "")
 	assert.Check(t, env["QIX"] == nil)
 }
+
+func TestBuildOptionsApplyCache(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			"web": {
+				Name: "web",
+				Build: &types.BuildConfig{
+					Context:   ".",
+					CacheFrom: []string{"type=registry,ref=user/web:cache"},
+					CacheTo:   []string{"type=inline"},
+				},
+			},
+			"db": {
+				Name: "db",
+				Build: &types.BuildConfig{
+					Context: ".",
+				},
+			},
+		},
+	}
+	err := BuildOptions{
+		CacheFrom: []string{"type=gha"},
+	}.Apply(project)
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, project.Services["web"].Build.CacheFrom, types.StringList{"type=gha"})
+	assert.DeepEqual(t, project.Services["web"].Build.CacheTo, types.StringList{"type=inline"})
+	assert.DeepEqual(t, project.Services["db"].Build.CacheFrom, types.StringList{"type=gha"})
+	assert.Assert(t, project.Services["db"].Build.CacheTo == nil)
+}
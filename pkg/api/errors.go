@@ -18,6 +18,8 @@ package api
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 const (
@@ -45,6 +47,8 @@ var (
 	ErrParsingFailed = errors.New("parsing failed")
 	// ErrNoResources is returned when operation didn't selected any resource
 	ErrNoResources = errors.New("no resources")
+	// ErrTimeout is returned when an operation didn't complete within the configured timeout
+	ErrTimeout = errors.New("timeout")
 )
 
 // IsNotFoundError returns true if the unwrapped error is ErrNotFound
@@ -86,3 +90,63 @@ func IsErrParsingFailed(err error) bool {
 func IsErrCanceled(err error) bool {
 	return errors.Is(err, ErrCanceled)
 }
+
+// ProviderError is returned when a provider command failed, exposing the provider exit code
+type ProviderError struct {
+	// Service is the name of the provider service
+	Service string
+	// Action is the action the provider failed to complete, e.g. "create"
+	Action string
+	// ExitCode is the provider command exit code, or -1 if the command didn't exit normally
+	ExitCode int
+	// Err is the underlying error, typically an *exec.ExitError
+	Err error
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("failed to %s service provider: %s", e.Action, e.Err.Error())
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// DependencyCycleError is returned when services depend on each other through a cycle, so they can't be ordered
+type DependencyCycleError struct {
+	// Path lists the services forming the cycle, the first one being repeated at the end
+	Path []string
+}
+
+func (e *DependencyCycleError) Error() string {
+	return fmt.Sprintf("dependency cycle found: %s", strings.Join(e.Path, " -> "))
+}
+
+// ImageDigestMismatch describes an image which digest doesn't match the one recorded in a lockfile
+type ImageDigestMismatch struct {
+	Image string
+	// Expected is the locked digest, empty if the image is missing from the lockfile
+	Expected string
+	// Actual is the digest of the local image, empty if the image has no repository digest
+	Actual string
+}
+
+// ImagesLockMismatchError is returned when images don't match the digests recorded in a lockfile
+type ImagesLockMismatchError struct {
+	Mismatches []ImageDigestMismatch
+}
+
+func (e *ImagesLockMismatchError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("images do not match the locked digests:")
+	for _, m := range e.Mismatches {
+		expected, actual := m.Expected, m.Actual
+		if expected == "" {
+			expected = "<not locked>"
+		}
+		if actual == "" {
+			actual = "<no digest>"
+		}
+		fmt.Fprintf(&sb, "\n  %s\n    - %s (expected)\n    + %s (actual)", m.Image, expected, actual)
+	}
+	return sb.String()
+}
@@ -51,3 +51,14 @@ func TestIsUnknown(t *testing.T) {
 
 	assert.Assert(t, !IsUnknownError(errors.New("another error")))
 }
+
+func TestProviderError(t *testing.T) {
+	cause := errors.New("exit status 3")
+	err := fmt.Errorf("provider: %w", &ProviderError{Service: "db", Action: "create", ExitCode: 3, Err: cause})
+	assert.Error(t, err, "provider: failed to create service provider: exit status 3")
+
+	var providerErr *ProviderError
+	assert.Assert(t, errors.As(err, &providerErr))
+	assert.Equal(t, providerErr.ExitCode, 3)
+	assert.Assert(t, errors.Is(err, cause))
+}
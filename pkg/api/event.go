@@ -39,6 +39,8 @@ const ResourceCompose = "Compose"
 
 const (
 	StatusError            = "Error"
+	StatusWarning          = "Warning"
+	StatusInfo             = "Info"
 	StatusCreating         = "Creating"
 	StatusStarting         = "Starting"
 	StatusStarted          = "Started"
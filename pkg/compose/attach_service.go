@@ -27,6 +27,12 @@ import (
 
 func (s *composeService) Attach(ctx context.Context, projectName string, options api.AttachOptions) error {
 	projectName = strings.ToLower(projectName)
+	if options.Index == 0 {
+		if err := s.ensureSingleContainer(ctx, projectName, options.Service); err != nil {
+			return err
+		}
+	}
+
 	target, err := s.getSpecifiedContainer(ctx, projectName, oneOffInclude, false, options.Service, options.Index)
 	if err != nil {
 		return err
@@ -0,0 +1,74 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestAttachScaledServiceRequiresIndex(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	first := testContainer("service1", "123", false)
+	first.Labels[api.ContainerNumberLabel] = "1"
+	second := testContainer("service1", "456", false)
+	second.Labels[api.ContainerNumberLabel] = "2"
+
+	apiClient.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude, "service1"),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{first, second},
+	}, nil)
+
+	err = tested.Attach(t.Context(), name, api.AttachOptions{Service: "service1"})
+	assert.Error(t, err, `service "service1" has 2 running containers, use --index to select one of: 1, 2`)
+}
+
+func TestAttachServiceNotRunning(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	filters := getDefaultFilters(name, oneOffInclude, "service1").Add("label", containerNumberFilter(2))
+	apiClient.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: filters,
+	}).Return(client.ContainerListResult{}, nil)
+	apiClient.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffInclude, "service1"),
+	}).Return(client.ContainerListResult{}, nil)
+
+	err = tested.Attach(t.Context(), name, api.AttachOptions{Service: "service1", Index: 2})
+	assert.Error(t, err, `service "service1" is not running container #2`)
+}
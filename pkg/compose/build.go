@@ -101,7 +101,8 @@ func (s *composeService) build(ctx context.Context, project *types.Project, opti
 	if err != nil {
 		return nil, err
 	}
-	if bake {
+	if bake || options.Print {
+		// bake file can be printed even if bake isn't available to run the build
 		return s.doBuildBake(ctx, project, serviceToBuild, options)
 	}
 	return s.doBuildClassic(ctx, project, serviceToBuild, options)
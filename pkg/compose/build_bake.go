@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -351,6 +352,7 @@ func (s *composeService) doBuildBake(ctx context.Context, project *types.Project
 
 	var errMessage []string
 	reader := bufio.NewReader(pipe)
+	progress := newBakeProgress(group.Targets)
 
 	err = cmd.Start()
 	if err != nil {
@@ -380,6 +382,7 @@ func (s *composeService) doBuildBake(ctx context.Context, project *types.Project
 			}
 			continue
 		}
+		progress.update(&status)
 		ch <- &status
 	}
 	close(ch) // stop build progress UI
@@ -387,9 +390,21 @@ func (s *composeService) doBuildBake(ctx context.Context, project *types.Project
 	err = eg.Wait()
 	if err != nil {
 		if len(errMessage) > 0 {
-			return nil, errors.New(strings.Join(errMessage, "\n"))
+			err = errors.New(strings.Join(errMessage, "\n"))
+		} else {
+			err = fmt.Errorf("failed to execute bake: %w", err)
+		}
+		if options.Results != nil {
+			// bake might still have recorded the targets it built before failing
+			var md bakeMetadata
+			if b, readErr := os.ReadFile(metadataFile); readErr == nil {
+				_ = json.Unmarshal(b, &md)
+			}
+			for _, result := range progress.failedResults(slices.Sorted(maps.Keys(serviceToBeBuild)), targets, expectedImages, md, err) {
+				options.Results(result)
+			}
 		}
-		return nil, fmt.Errorf("failed to execute bake: %w", err)
+		return nil, err
 	}
 
 	b, err = os.ReadFile(metadataFile)
@@ -413,6 +428,16 @@ func (s *composeService) doBuildBake(ctx context.Context, project *types.Project
 		}
 		results[image] = built.Digest
 		s.events.On(builtEvent(image))
+		if options.Results != nil {
+			duration, cached, _ := progress.result(target)
+			options.Results(api.BuildResult{
+				Service:  name,
+				Image:    image,
+				ImageID:  built.Digest,
+				Duration: duration,
+				Cached:   cached,
+			})
+		}
 	}
 	return results, nil
 }
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/cli/cli"
@@ -89,7 +90,18 @@ func (s *composeService) doBuildClassic(ctx context.Context, project *types.Proj
 
 		image := api.GetImageNameOrDefault(service, project.Name)
 		s.events.On(buildingEvent(image))
+		start := time.Now()
 		id, err := s.doBuildImage(ctx, project, service, options)
+		if options.Results != nil {
+			// the classic builder doesn't report cache usage
+			options.Results(api.BuildResult{
+				Service:  name,
+				Image:    image,
+				ImageID:  id,
+				Duration: time.Since(start),
+				Error:    err,
+			})
+		}
 		if err != nil {
 			return err
 		}
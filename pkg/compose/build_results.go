@@ -0,0 +1,139 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+// buildStepName matches vertices running a Dockerfile instruction, like `[2/3] RUN make` or
+// `[web builder 2/3] RUN make` when prefixed by the bake target
+var buildStepName = regexp.MustCompile(`^\[[^\]]*\b\d+/\d+\]`)
+
+// bakeProgress collects the vertices reported by bake to compute per-target build results
+type bakeProgress struct {
+	// single is the only target built by bake, as vertices then are not prefixed by target name
+	single   string
+	vertices map[digest.Digest]*client.Vertex
+}
+
+func newBakeProgress(targets []string) *bakeProgress {
+	p := &bakeProgress{
+		vertices: map[digest.Digest]*client.Vertex{},
+	}
+	if len(targets) == 1 {
+		p.single = targets[0]
+	}
+	return p
+}
+
+func (p *bakeProgress) update(status *client.SolveStatus) {
+	for _, v := range status.Vertexes {
+		p.vertices[v.Digest] = v
+	}
+}
+
+// vertexTarget returns the bake target a vertex belongs to
+func (p *bakeProgress) vertexTarget(v *client.Vertex) string {
+	if p.single != "" {
+		return p.single
+	}
+	name, ok := strings.CutPrefix(v.Name, "[")
+	if !ok {
+		return ""
+	}
+	if i := strings.IndexAny(name, " ]"); i > 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// result returns the build duration of target, whether all its build steps were cached, and the
+// first error reported while building it
+func (p *bakeProgress) result(target string) (duration time.Duration, cached bool, errMessage string) {
+	var (
+		started, completed time.Time
+		steps, cachedSteps int
+	)
+	for _, v := range p.vertices {
+		if p.vertexTarget(v) != target {
+			continue
+		}
+		if v.Started != nil && (started.IsZero() || v.Started.Before(started)) {
+			started = *v.Started
+		}
+		if v.Completed != nil && v.Completed.After(completed) {
+			completed = *v.Completed
+		}
+		if v.Error != "" && errMessage == "" {
+			errMessage = v.Error
+		}
+		if buildStepName.MatchString(v.Name) {
+			steps++
+			if v.Cached {
+				cachedSteps++
+			}
+		}
+	}
+	if !started.IsZero() && completed.After(started) {
+		duration = completed.Sub(started)
+	}
+	return duration, steps > 0 && steps == cachedSteps, errMessage
+}
+
+// failedResults returns the results of services when bake failed with err. Services whose target reported an error
+// failed with it, those found in bake metadata were built, and the others were not built. When no target reported
+// an error, err can't be attributed and is reported for all services which were not built.
+func (p *bakeProgress) failedResults(services []string, targets, images map[string]string, md bakeMetadata, err error) []api.BuildResult {
+	results := make([]api.BuildResult, 0, len(services))
+	attributed := false
+	for _, name := range services {
+		duration, cached, message := p.result(targets[name])
+		result := api.BuildResult{
+			Service:  name,
+			Image:    images[name],
+			Duration: duration,
+			Cached:   cached,
+		}
+		if message != "" {
+			result.Error = errors.New(message)
+			attributed = true
+		} else if built, ok := md[targets[name]]; ok && built.Digest != "" {
+			result.ImageID = built.Digest
+		} else {
+			result.NotBuilt = true
+		}
+		results = append(results, result)
+	}
+	if !attributed {
+		for i, result := range results {
+			if result.NotBuilt {
+				results[i].NotBuilt = false
+				results[i].Error = err
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,109 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/v3/assert"
+)
+
+func TestBakeProgressResult(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) *time.Time {
+		t := start.Add(time.Duration(seconds) * time.Second)
+		return &t
+	}
+	vertex := func(name string, started, completed int, cached bool) *client.Vertex {
+		return &client.Vertex{
+			Digest:    digest.FromString(name),
+			Name:      name,
+			Started:   at(started),
+			Completed: at(completed),
+			Cached:    cached,
+		}
+	}
+
+	progress := newBakeProgress([]string{"web", "db"})
+	progress.update(&client.SolveStatus{Vertexes: []*client.Vertex{
+		vertex("[web internal] load build definition from Dockerfile", 0, 1, false),
+		vertex("[web 1/2] FROM docker.io/library/alpine", 1, 2, true),
+		vertex("[web 2/2] RUN make", 2, 3, true),
+		vertex("[web] exporting to image", 3, 4, false),
+		vertex("[db internal] load build definition from Dockerfile", 0, 1, false),
+		vertex("[db 1/2] FROM docker.io/library/postgres", 1, 2, true),
+		vertex("[db 2/2] RUN make", 2, 10, false),
+	}})
+	failed := vertex("[db 2/2] RUN make", 2, 12, false)
+	failed.Error = "process did not complete successfully"
+	progress.update(&client.SolveStatus{Vertexes: []*client.Vertex{failed}})
+
+	duration, cached, message := progress.result("web")
+	assert.Equal(t, duration, 4*time.Second)
+	assert.Equal(t, cached, true)
+	assert.Equal(t, message, "")
+
+	duration, cached, message = progress.result("db")
+	assert.Equal(t, duration, 12*time.Second)
+	assert.Equal(t, cached, false)
+	assert.Equal(t, message, "process did not complete successfully")
+}
+
+func TestBakeProgressSingleTarget(t *testing.T) {
+	started := time.Now()
+	completed := started.Add(time.Second)
+	progress := newBakeProgress([]string{"web"})
+	progress.update(&client.SolveStatus{Vertexes: []*client.Vertex{
+		{Digest: "sha256:1", Name: "[2/2] RUN make", Started: &started, Completed: &completed},
+	}})
+
+	duration, cached, _ := progress.result("web")
+	assert.Equal(t, duration, time.Second)
+	assert.Equal(t, cached, false)
+}
+
+func TestBakeProgressFailedResults(t *testing.T) {
+	started := time.Now()
+	completed := started.Add(time.Second)
+	progress := newBakeProgress([]string{"web", "db", "worker"})
+	progress.update(&client.SolveStatus{Vertexes: []*client.Vertex{
+		{Digest: "sha256:1", Name: "[web 2/2] RUN make", Started: &started, Completed: &completed},
+		{Digest: "sha256:2", Name: "[db 2/2] RUN make", Started: &started, Completed: &completed, Error: "exit code: 2"},
+	}})
+	targets := map[string]string{"web": "web", "db": "db", "worker": "worker"}
+	images := map[string]string{"web": "project-web", "db": "project-db", "worker": "project-worker"}
+	md := bakeMetadata{"web": {Digest: "sha256:abc"}}
+	bakeErr := errors.New("failed to execute bake: exit status 1")
+
+	results := progress.failedResults([]string{"db", "web", "worker"}, targets, images, md, bakeErr)
+	assert.Equal(t, len(results), 3)
+	assert.Error(t, results[0].Error, "exit code: 2")
+	assert.Equal(t, results[1].ImageID, "sha256:abc")
+	assert.NilError(t, results[1].Error)
+	assert.Equal(t, results[2].NotBuilt, true)
+	assert.NilError(t, results[2].Error)
+
+	// the bake error is reported when no target failed on its own
+	results = newBakeProgress([]string{"web", "worker"}).failedResults([]string{"web", "worker"}, targets, images, md, bakeErr)
+	assert.Equal(t, results[0].ImageID, "sha256:abc")
+	assert.Equal(t, results[1].NotBuilt, false)
+	assert.Equal(t, results[1].Error, bakeErr)
+}
@@ -17,11 +17,18 @@
 package compose
 
 import (
+	"bytes"
+	"encoding/json"
 	"slices"
 	"testing"
 
 	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/cli/cli/streams"
+	"go.uber.org/mock/gomock"
 	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/compose/v5/pkg/mocks"
 )
 
 func Test_dockerFilePath(t *testing.T) {
@@ -107,3 +114,71 @@ func Test_addBuildDependencies(t *testing.T) {
 	slices.Sort(expected)
 	assert.DeepEqual(t, services, expected)
 }
+
+func Test_resolveAndMergeBuildArgs(t *testing.T) {
+	project := &types.Project{Environment: types.Mapping{"VERSION": "1.2.3"}}
+	service := types.ServiceConfig{
+		Name: "web",
+		Build: &types.BuildConfig{
+			Args: types.NewMappingWithEquals([]string{"BASE=alpine", "DEBUG=false"}),
+		},
+	}
+	opts := api.BuildOptions{
+		Args: types.NewMappingWithEquals([]string{"DEBUG=true", "VERSION", "UNSET"}),
+	}
+
+	// CLI args override the compose file, and those without a value are resolved from the environment
+	args := resolveAndMergeBuildArgs(map[string]string{"HTTP_PROXY": "proxy", "DEBUG": "ignored"}, project, service, opts)
+	assert.DeepEqual(t, args.ToMapping(), types.Mapping{
+		"BASE":       "alpine",
+		"DEBUG":      "true",
+		"VERSION":    "1.2.3",
+		"HTTP_PROXY": "proxy",
+	})
+}
+
+func TestBuildPrint(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var out bytes.Buffer
+	cli := mocks.NewMockCli(mockCtrl)
+	// bake file is printed even if BuildKit is disabled
+	cli.EXPECT().BuildKitEnabled().Return(false, nil)
+	cli.EXPECT().Out().Return(streams.NewOut(&out)).AnyTimes()
+	s := &composeService{
+		dockerCli:   cli,
+		events:      noopEventProcessor{},
+		proxyConfig: map[string]string{},
+	}
+
+	project := &types.Project{
+		Name: "test",
+		Services: types.Services{
+			"web": {
+				Name: "web",
+				Build: &types.BuildConfig{
+					Context: "/src/web",
+					Args:    types.NewMappingWithEquals([]string{"FOO=bar"}),
+				},
+			},
+			"db": {
+				Name:  "db",
+				Image: "postgres",
+			},
+		},
+	}
+	_, err := s.build(t.Context(), project, api.BuildOptions{
+		Print: true,
+		Args:  types.NewMappingWithEquals([]string{"FOO=override"}),
+	}, nil)
+	assert.NilError(t, err)
+
+	var cfg bakeConfig
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &cfg))
+	assert.DeepEqual(t, cfg.Groups["default"].Targets, []string{"web"})
+	target := cfg.Targets["web"]
+	assert.Equal(t, target.Context, "/src/web")
+	assert.Equal(t, target.Args["FOO"], "override")
+	assert.DeepEqual(t, target.Tags, []string{"test-web"})
+}
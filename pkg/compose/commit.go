@@ -35,6 +35,12 @@ func (s *composeService) Commit(ctx context.Context, projectName string, options
 func (s *composeService) commit(ctx context.Context, projectName string, options api.CommitOptions) error {
 	projectName = strings.ToLower(projectName)
 
+	if options.Index == 0 {
+		if err := s.ensureSingleContainer(ctx, projectName, options.Service); err != nil {
+			return err
+		}
+	}
+
 	ctr, err := s.getSpecifiedContainer(ctx, projectName, oneOffInclude, false, options.Service, options.Index)
 	if err != nil {
 		return err
@@ -69,11 +75,17 @@ func (s *composeService) commit(ctx context.Context, projectName string, options
 		return err
 	}
 
+	committed := response.ID
+	if options.Reference != "" {
+		committed = options.Reference
+	}
 	s.events.On(api.Resource{
 		ID:     name,
-		Text:   fmt.Sprintf("Committed as %s", response.ID),
+		Text:   fmt.Sprintf("Committed as %s", committed),
 		Status: api.Done,
 	})
 
+	// like docker commit, print the image ID so it can be used by scripts
+	_, _ = fmt.Fprintln(s.stdout(), response.ID)
 	return nil
 }
@@ -0,0 +1,100 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/cli/streams"
+	"github.com/docker/cli/opts"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	compose "github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/compose/v5/pkg/mocks"
+)
+
+func TestCommitScaledServiceRequiresIndex(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	first := testContainer("service1", "123", false)
+	first.Labels[compose.ContainerNumberLabel] = "1"
+	second := testContainer("service1", "456", false)
+	second.Labels[compose.ContainerNumberLabel] = "2"
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude, "service1"),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{first, second},
+	}, nil)
+
+	err = tested.Commit(t.Context(), name, compose.CommitOptions{Service: "service1", Reference: "myimage:debug"})
+	assert.Error(t, err, `service "service1" has 2 running containers, use --index to select one of: 1, 2`)
+}
+
+func TestCommitPrintsImageID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var out bytes.Buffer
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	cli.EXPECT().Client().Return(api).AnyTimes()
+	cli.EXPECT().Err().Return(streams.NewOut(os.Stderr)).AnyTimes()
+	cli.EXPECT().Out().Return(streams.NewOut(&out)).AnyTimes()
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	replica := testContainer("service1", "456", false)
+	replica.Labels[compose.ContainerNumberLabel] = "2"
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffInclude, "service1").Add("label", containerNumberFilter(2)),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{replica},
+	}, nil)
+	api.EXPECT().ContainerCommit(gomock.Any(), "456", client.ContainerCommitOptions{
+		Reference: "myimage:debug",
+		Comment:   "debug snapshot",
+		Changes:   []string{"ENV DEBUG=1"},
+	}).Return(client.ContainerCommitResult{ID: "sha256:abc"}, nil)
+
+	changes := opts.NewListOpts(nil)
+	assert.NilError(t, changes.Set("ENV DEBUG=1"))
+	err = tested.Commit(t.Context(), name, compose.CommitOptions{
+		Service:   "service1",
+		Reference: "myimage:debug",
+		Comment:   "debug snapshot",
+		Changes:   changes,
+		Pause:     true,
+		Index:     2,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), "sha256:abc\n")
+}
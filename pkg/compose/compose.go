@@ -65,10 +65,11 @@ type Option func(service *composeService) error
 //	    WithStreams(customOut, customErr, customIn))
 func NewComposeService(dockerCli command.Cli, options ...Option) (api.Compose, error) {
 	s := &composeService{
-		dockerCli:      dockerCli,
-		clock:          clockwork.NewRealClock(),
-		maxConcurrency: -1,
-		dryRun:         false,
+		dockerCli:         dockerCli,
+		clock:             clockwork.NewRealClock(),
+		maxConcurrency:    -1,
+		dryRun:            false,
+		runtimeAPIVersion: &runtimeVersionCache{},
 	}
 	for _, option := range options {
 		if err := option(s); err != nil {
@@ -144,6 +145,22 @@ func WithProxyConfig(config map[string]string) Option {
 	}
 }
 
+// WithEnvFileResolver registers a resolver for env_file entries using scheme, like `vault` for
+// `vault://path/to/secret`. Those entries are resolved as the project is loaded, before service
+// environment gets resolved, so that values can be referenced by other env files
+func WithEnvFileResolver(scheme string, resolver api.EnvFileResolver) Option {
+	return func(s *composeService) error {
+		if scheme == "" || strings.Contains(scheme, ":") {
+			return fmt.Errorf("invalid env_file resolver scheme %q", scheme)
+		}
+		if s.envFileResolvers == nil {
+			s.envFileResolvers = map[string]api.EnvFileResolver{}
+		}
+		s.envFileResolvers[scheme] = resolver
+		return nil
+	}
+}
+
 // WithPrompt configure a UI component for Compose service to interact with user and confirm actions
 func WithPrompt(prompt Prompt) Option {
 	return func(s *composeService) error {
@@ -198,6 +215,27 @@ func WithEventProcessor(bus api.EventProcessor) Option {
 	}
 }
 
+// multiEventProcessor notifies Compose operation and progress events to multiple processors
+type multiEventProcessor []api.EventProcessor
+
+func (m multiEventProcessor) Start(ctx context.Context, operation string) {
+	for _, p := range m {
+		p.Start(ctx, operation)
+	}
+}
+
+func (m multiEventProcessor) On(events ...api.Resource) {
+	for _, p := range m {
+		p.On(events...)
+	}
+}
+
+func (m multiEventProcessor) Done(operation string, success bool) {
+	for _, p := range m {
+		p.Done(operation, success)
+	}
+}
+
 type composeService struct {
 	dockerCli command.Cli
 	// prompt is used to interact with user and confirm actions
@@ -212,11 +250,23 @@ type composeService struct {
 	contextInfo api.ContextInfo
 	proxyConfig map[string]string
 
+	// envFileResolvers resolve env_file entries by scheme
+	envFileResolvers map[string]api.EnvFileResolver
+
 	clock          clockwork.Clock
 	maxConcurrency int
 	dryRun         bool
 
-	runtimeAPIVersion runtimeVersionCache
+	// runtimeAPIVersion is shared by copies of the service, as they target the same engine
+	runtimeAPIVersion *runtimeVersionCache
+}
+
+// withEvents returns a copy of the service which also notifies events to the given processor, so
+// an API call can be observed without changing the processor configured for the service
+func (s *composeService) withEvents(events api.EventProcessor) *composeService {
+	c := *s
+	c.events = multiEventProcessor{s.events, events}
+	return &c
 }
 
 // Close releases any connections/resources held by the underlying clients.
@@ -509,12 +559,25 @@ type runtimeVersionCache struct {
 // After negotiation, Compose should never rely on features or request attributes
 // not defined by this API version, even if the daemon's raw version is higher.
 func (s *composeService) RuntimeAPIVersion(ctx context.Context) (string, error) {
+	if s.runtimeAPIVersion == nil {
+		// service created without NewComposeService, don't cache the version
+		return s.negotiateAPIVersion(ctx)
+	}
 	s.runtimeAPIVersion.mu.Lock()
 	defer s.runtimeAPIVersion.mu.Unlock()
 	if s.runtimeAPIVersion.val != "" {
 		return s.runtimeAPIVersion.val, nil
 	}
 
+	version, err := s.negotiateAPIVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.runtimeAPIVersion.val = version
+	return s.runtimeAPIVersion.val, nil
+}
+
+func (s *composeService) negotiateAPIVersion(ctx context.Context) (string, error) {
 	cli := s.apiClient()
 	_, err := cli.Ping(ctx, client.PingOptions{NegotiateAPIVersion: true})
 	if err != nil {
@@ -525,7 +588,5 @@ func (s *composeService) RuntimeAPIVersion(ctx context.Context) (string, error)
 	if version == "" {
 		return "", fmt.Errorf("docker client returned empty version after successful API negotiation")
 	}
-
-	s.runtimeAPIVersion.val = version
-	return s.runtimeAPIVersion.val, nil
+	return version, nil
 }
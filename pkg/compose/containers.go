@@ -22,6 +22,7 @@ import (
 	"slices"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/moby/moby/api/types/container"
@@ -102,7 +103,7 @@ func (s *composeService) getSpecifiedContainer(ctx context.Context, projectName
 	containers := res.Items
 	if len(containers) < 1 {
 		if containerIndex > 0 {
-			return container.Summary{}, fmt.Errorf("service %q is not running container #%d", serviceName, containerIndex)
+			return container.Summary{}, s.missingContainerIndexError(ctx, projectName, oneOff, all, serviceName, containerIndex)
 		}
 		return container.Summary{}, fmt.Errorf("service %q is not running", serviceName)
 	}
@@ -123,6 +124,53 @@ func (s *composeService) getSpecifiedContainer(ctx context.Context, projectName
 	return containers[0], nil
 }
 
+// missingContainerIndexError reports containerIndex doesn't exist for service, with the indices of
+// containers available to select
+func (s *composeService) missingContainerIndexError(ctx context.Context, projectName string, oneOff oneOff, all bool, serviceName string, containerIndex int) error {
+	err := fmt.Errorf("service %q is not running container #%d", serviceName, containerIndex)
+	res, listErr := s.apiClient().ContainerList(ctx, client.ContainerListOptions{
+		Filters: getDefaultFilters(projectName, oneOff, serviceName),
+		All:     all,
+	})
+	if listErr != nil {
+		return err
+	}
+	available := containerIndices(res.Items)
+	if len(available) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w, available indices: %s", err, strings.Join(available, ", "))
+}
+
+// ensureSingleContainer checks service doesn't run multiple containers, so it can be selected without an index
+func (s *composeService) ensureSingleContainer(ctx context.Context, projectName string, serviceName string) error {
+	replicas, err := s.getContainers(ctx, projectName, oneOffExclude, false, serviceName)
+	if err != nil {
+		return err
+	}
+	if len(replicas) > 1 {
+		return fmt.Errorf("service %q has %d running containers, use --index to select one of: %s",
+			serviceName, len(replicas), strings.Join(containerIndices(replicas), ", "))
+	}
+	return nil
+}
+
+// containerIndices returns the sorted, distinct container numbers of containers
+func containerIndices(containers Containers) []string {
+	var indices []int
+	for _, ctr := range containers {
+		if number, convErr := strconv.Atoi(ctr.Labels[api.ContainerNumberLabel]); convErr == nil && !slices.Contains(indices, number) {
+			indices = append(indices, number)
+		}
+	}
+	slices.Sort(indices)
+	available := make([]string, len(indices))
+	for i, index := range indices {
+		available[i] = strconv.Itoa(index)
+	}
+	return available
+}
+
 // containerPredicate define a predicate we want container to satisfy for filtering operations
 type containerPredicate func(c container.Summary) bool
 
@@ -146,15 +194,27 @@ func isService(services ...string) containerPredicate {
 func isOrphaned(project *types.Project) containerPredicate {
 	services := append(project.ServiceNames(), project.DisabledServiceNames()...)
 	return func(c container.Summary) bool {
-		// One-off container
-		v, ok := c.Labels[api.OneoffLabel]
-		if ok && v == "True" {
-			return c.State == container.StateExited || c.State == container.StateDead
+		return orphanReason(services, c) != ""
+	}
+}
+
+// orphanReason returns why a container is considered orphaned given the services declared by the
+// compose project, or an empty string if it is not
+func orphanReason(services []string, c container.Summary) string {
+	service := c.Labels[api.ServiceLabel]
+	// One-off container
+	v, ok := c.Labels[api.OneoffLabel]
+	if ok && v == "True" {
+		if c.State == container.StateExited || c.State == container.StateDead {
+			return fmt.Sprintf("one-off container for service %q is %s", service, c.State)
 		}
-		// Service that is not defined in the compose model
-		service := c.Labels[api.ServiceLabel]
-		return !slices.Contains(services, service)
+		return ""
+	}
+	// Service that is not defined in the compose model
+	if !slices.Contains(services, service) {
+		return fmt.Sprintf("service %q is not defined in the project", service)
 	}
+	return ""
 }
 
 func isNotOneOff(c container.Summary) bool {
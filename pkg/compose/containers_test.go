@@ -0,0 +1,78 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestGetSpecifiedContainer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	service, err := NewComposeService(cli)
+	assert.NilError(t, err)
+	tested := service.(*composeService)
+
+	replica := func(id, number string) container.Summary {
+		ctr := testContainer("web", id, false)
+		ctr.Labels[api.ContainerNumberLabel] = number
+		return ctr
+	}
+	name := strings.ToLower(testProject)
+
+	apiClient.EXPECT().ContainerList(gomock.Any(), client.ContainerListOptions{
+		Filters: projectFilter(name).Add("label", serviceFilter("web"), api.ConfigHashLabel, containerNumberFilter(2)),
+	}).Return(client.ContainerListResult{Items: []container.Summary{replica("456", "2")}}, nil)
+
+	ctr, err := tested.getSpecifiedContainer(t.Context(), name, oneOffInclude, false, "web", 2)
+	assert.NilError(t, err)
+	assert.Equal(t, ctr.ID, "456")
+
+	apiClient.EXPECT().ContainerList(gomock.Any(), client.ContainerListOptions{
+		Filters: projectFilter(name).Add("label", serviceFilter("web"), api.ConfigHashLabel, containerNumberFilter(3)),
+	}).Return(client.ContainerListResult{}, nil)
+	apiClient.EXPECT().ContainerList(gomock.Any(), client.ContainerListOptions{
+		Filters: projectFilter(name).Add("label", serviceFilter("web"), api.ConfigHashLabel),
+	}).Return(client.ContainerListResult{Items: []container.Summary{replica("456", "2"), replica("123", "1")}}, nil)
+
+	_, err = tested.getSpecifiedContainer(t.Context(), name, oneOffInclude, false, "web", 3)
+	assert.Error(t, err, `service "web" is not running container #3, available indices: 1, 2`)
+}
+
+func TestOrphanReason(t *testing.T) {
+	services := []string{"web", "db"}
+	ctr := func(service string, oneOff bool, state container.ContainerState) container.Summary {
+		c := testContainer(service, service, oneOff)
+		c.State = state
+		return c
+	}
+
+	assert.Equal(t, orphanReason(services, ctr("web", false, container.StateRunning)), "")
+	assert.Equal(t, orphanReason(services, ctr("old", false, container.StateRunning)), `service "old" is not defined in the project`)
+	assert.Equal(t, orphanReason(services, ctr("web", true, container.StateRunning)), "")
+	assert.Equal(t, orphanReason(services, ctr("web", true, container.StateExited)), `one-off container for service "web" is exited`)
+}
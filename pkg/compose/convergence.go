@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -153,6 +154,23 @@ func containerReasonEvents(containers Containers, eventFunc func(string, string)
 // ServiceConditionRunningOrHealthy is a service condition on status running or healthy
 const ServiceConditionRunningOrHealthy = "running_or_healthy"
 
+// dependencies state is polled with an exponential backoff, so fast starting services are detected
+// quickly without hammering the engine while waiting for slow ones
+const (
+	dependencyPollInitialInterval = 100 * time.Millisecond
+	dependencyPollMaxInterval     = 2 * time.Second
+	dependencyPollMultiplier      = 1.5
+)
+
+// dependencyPollInterval returns the delay before polling dependency state for the given attempt, starting at 0
+func dependencyPollInterval(attempt int) time.Duration {
+	interval := float64(dependencyPollInitialInterval) * math.Pow(dependencyPollMultiplier, float64(attempt))
+	if interval >= float64(dependencyPollMaxInterval) {
+		return dependencyPollMaxInterval
+	}
+	return time.Duration(interval)
+}
+
 //nolint:gocyclo
 func (s *composeService) waitDependencies(ctx context.Context, project *types.Project, dependant string, dependencies types.DependsOnConfig, containers Containers, timeout time.Duration) error {
 	if timeout > 0 {
@@ -162,6 +180,14 @@ func (s *composeService) waitDependencies(ctx context.Context, project *types.Pr
 	}
 	eg, ctx := errgroup.WithContext(ctx)
 	for dep, config := range dependencies {
+		delay, err := dependencyStartupDelay(dep, config, project)
+		if err != nil {
+			return err
+		}
+		if config.Condition == types.ServiceConditionStarted && delay == 0 {
+			// already managed by InDependencyOrder
+			continue
+		}
 		if shouldWait, err := shouldWaitForDependency(dep, config, project); err != nil {
 			return err
 		} else if !shouldWait {
@@ -179,15 +205,21 @@ func (s *composeService) waitDependencies(ctx context.Context, project *types.Pr
 		}
 
 		eg.Go(func() error {
-			ticker := time.NewTicker(500 * time.Millisecond)
-			defer ticker.Stop()
-			for {
+			timer := time.NewTimer(dependencyPollInterval(0))
+			defer timer.Stop()
+			for attempt := 1; ; attempt++ {
 				select {
-				case <-ticker.C:
+				case <-timer.C:
+					timer.Reset(dependencyPollInterval(attempt))
 				case <-ctx.Done():
 					return nil
 				}
 				switch config.Condition {
+				case types.ServiceConditionStarted:
+					// containers have been started by InDependencyOrder, only the startup delay is left
+					err := waitStartupDelay(ctx, delay)
+					s.events.On(containerEvents(waitingFor, startedEvent)...)
+					return err
 				case ServiceConditionRunningOrHealthy:
 					isHealthy, err := s.isServiceHealthy(ctx, waitingFor, true)
 					if err != nil {
@@ -201,7 +233,7 @@ func (s *composeService) waitDependencies(ctx context.Context, project *types.Pr
 					}
 					if isHealthy {
 						s.events.On(containerEvents(waitingFor, healthy)...)
-						return nil
+						return waitStartupDelay(ctx, delay)
 					}
 				case types.ServiceConditionHealthy:
 					isHealthy, err := s.isServiceHealthy(ctx, waitingFor, false)
@@ -219,7 +251,7 @@ func (s *composeService) waitDependencies(ctx context.Context, project *types.Pr
 					}
 					if isHealthy {
 						s.events.On(containerEvents(waitingFor, healthy)...)
-						return nil
+						return waitStartupDelay(ctx, delay)
 					}
 				case types.ServiceConditionCompletedSuccessfully:
 					isExited, code, err := s.isServiceCompleted(ctx, waitingFor)
@@ -229,7 +261,7 @@ func (s *composeService) waitDependencies(ctx context.Context, project *types.Pr
 					if isExited {
 						if code == 0 {
 							s.events.On(containerEvents(waitingFor, exited)...)
-							return nil
+							return waitStartupDelay(ctx, delay)
 						}
 
 						messageSuffix := fmt.Sprintf("%q didn't complete successfully: exit %d", dep, code)
@@ -261,11 +293,53 @@ func (s *composeService) waitDependencies(ctx context.Context, project *types.Pr
 	return err
 }
 
-func shouldWaitForDependency(serviceName string, dependencyConfig types.ServiceDependency, project *types.Project) (bool, error) {
-	if dependencyConfig.Condition == types.ServiceConditionStarted {
-		// already managed by InDependencyOrder
-		return false, nil
+// startupDelayExtension is the extension to delay dependant services once a dependency condition is met.
+// It can be set on a service as the default for all its dependants, and on a depends_on entry to override it
+const startupDelayExtension = "x-startup-delay"
+
+// dependencyStartupDelay returns the startup delay configured for a dependency, either as a duration or
+// a number of seconds
+func dependencyStartupDelay(serviceName string, dependencyConfig types.ServiceDependency, project *types.Project) (time.Duration, error) {
+	value, ok := dependencyConfig.Extensions[startupDelayExtension]
+	if !ok {
+		service, err := project.GetService(serviceName)
+		if err != nil {
+			return 0, nil
+		}
+		if value, ok = service.Extensions[startupDelayExtension]; !ok {
+			return 0, nil
+		}
+	}
+	switch v := value.(type) {
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d, nil
+		}
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second, nil
+		}
 	}
+	return 0, fmt.Errorf("dependency %q has invalid %s %v, must be a duration or a number of seconds", serviceName, startupDelayExtension, value)
+}
+
+// waitStartupDelay waits for the startup delay of a dependency, or the context to be done
+func waitStartupDelay(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func shouldWaitForDependency(serviceName string, dependencyConfig types.ServiceDependency, project *types.Project) (bool, error) {
 	if service, err := project.GetService(serviceName); err != nil {
 		for _, ds := range project.DisabledServices {
 			if ds.Name == serviceName {
@@ -22,6 +22,7 @@ import (
 	"net/netip"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/cli/cli/config/configfile"
@@ -255,6 +256,98 @@ func TestWaitDependencies(t *testing.T) {
 		}
 		assert.NilError(t, tested.(*composeService).waitDependencies(t.Context(), &project, "", dependencies, nil, 0))
 	})
+	t.Run("should wait for startup delay with condition service_started", func(t *testing.T) {
+		dbService := types.ServiceConfig{Name: "db", Scale: intPtr(1)}
+		project := types.Project{Name: strings.ToLower(testProject), Services: types.Services{
+			"db": dbService,
+		}}
+		dependencies := types.DependsOnConfig{
+			"db": {
+				Condition:  types.ServiceConditionStarted,
+				Required:   true,
+				Extensions: types.Extensions{startupDelayExtension: "200ms"},
+			},
+		}
+		containers := Containers{testContainer("db", "123", false)}
+		start := time.Now()
+		assert.NilError(t, tested.(*composeService).waitDependencies(t.Context(), &project, "", dependencies, containers, 0))
+		assert.Check(t, time.Since(start) >= 200*time.Millisecond)
+	})
+}
+
+func TestWaitStartupDelay(t *testing.T) {
+	assert.NilError(t, waitStartupDelay(t.Context(), 0))
+	assert.NilError(t, waitStartupDelay(t.Context(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	assert.ErrorIs(t, waitStartupDelay(ctx, time.Hour), context.Canceled)
+}
+
+func TestDependencyStartupDelay(t *testing.T) {
+	project := &types.Project{Services: types.Services{
+		"db":    {Name: "db", Extensions: types.Extensions{startupDelayExtension: "5s"}},
+		"redis": {Name: "redis"},
+	}}
+
+	tests := []struct {
+		name       string
+		dependency string
+		config     types.ServiceDependency
+		want       time.Duration
+		wantErr    string
+	}{
+		{name: "no delay", dependency: "redis", want: 0},
+		{name: "service default", dependency: "db", want: 5 * time.Second},
+		{
+			name:       "dependency override",
+			dependency: "db",
+			config:     types.ServiceDependency{Extensions: types.Extensions{startupDelayExtension: 2}},
+			want:       2 * time.Second,
+		},
+		{
+			name:       "seconds as string",
+			dependency: "redis",
+			config:     types.ServiceDependency{Extensions: types.Extensions{startupDelayExtension: "3"}},
+			want:       3 * time.Second,
+		},
+		{
+			name:       "invalid",
+			dependency: "redis",
+			config:     types.ServiceDependency{Extensions: types.Extensions{startupDelayExtension: "soon"}},
+			wantErr:    `dependency "redis" has invalid x-startup-delay soon, must be a duration or a number of seconds`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, err := dependencyStartupDelay(tt.dependency, tt.config, project)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, delay, tt.want)
+		})
+	}
+}
+
+func TestDependencyPollInterval(t *testing.T) {
+	var schedule []time.Duration
+	for attempt := range 9 {
+		schedule = append(schedule, dependencyPollInterval(attempt))
+	}
+	assert.DeepEqual(t, schedule, []time.Duration{
+		100 * time.Millisecond,
+		150 * time.Millisecond,
+		225 * time.Millisecond,
+		337500 * time.Microsecond,
+		506250 * time.Microsecond,
+		759375 * time.Microsecond,
+		1139062500 * time.Nanosecond,
+		1708593750 * time.Nanosecond,
+		dependencyPollMaxInterval,
+	})
+	assert.Equal(t, dependencyPollInterval(100), dependencyPollMaxInterval)
 }
 
 func TestIsServiceHealthy(t *testing.T) {
@@ -650,7 +743,7 @@ func TestRuntimeAPIVersionCachesNegotiation(t *testing.T) {
 
 	apiClient := mocks.NewMockAPIClient(mockCtrl)
 	cli := mocks.NewMockCli(mockCtrl)
-	tested := &composeService{dockerCli: cli}
+	tested := &composeService{dockerCli: cli, runtimeAPIVersion: &runtimeVersionCache{}}
 
 	cli.EXPECT().Client().Return(apiClient).AnyTimes()
 
@@ -678,7 +771,7 @@ func TestRuntimeAPIVersionRetriesOnTransientError(t *testing.T) {
 
 	apiClient := mocks.NewMockAPIClient(mockCtrl)
 	cli := mocks.NewMockCli(mockCtrl)
-	tested := &composeService{dockerCli: cli}
+	tested := &composeService{dockerCli: cli, runtimeAPIVersion: &runtimeVersionCache{}}
 
 	cli.EXPECT().Client().Return(apiClient).AnyTimes()
 
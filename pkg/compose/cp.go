@@ -67,7 +67,7 @@ func (s *composeService) copy(ctx context.Context, projectName string, options a
 		copyFunc = s.copyToContainer
 	}
 	if direction == acrossServices {
-		return errors.New("copying between services is not supported")
+		return s.copyAcrossServices(ctx, projectName, srcService, srcPath, destService, dstPath, options)
 	}
 
 	if direction == 0 {
@@ -112,6 +112,95 @@ func (s *composeService) copy(ctx context.Context, projectName string, options a
 	return g.Wait()
 }
 
+// copyAcrossServices copies srcPath from srcService container to dstPath in dstService container,
+// streaming the archive from one container to the other without writing to the local filesystem
+func (s *composeService) copyAcrossServices(ctx context.Context, projectName, srcService, srcPath, dstService, dstPath string, options api.CopyOptions) error {
+	src, err := s.getSingleContainerForCopy(ctx, projectName, options, srcService)
+	if err != nil {
+		return err
+	}
+	dst, err := s.getSingleContainerForCopy(ctx, projectName, options, dstService)
+	if err != nil {
+		return err
+	}
+
+	name := getCanonicalContainerName(dst)
+	msg := fmt.Sprintf("%s:%s to %s:%s", getCanonicalContainerName(src), srcPath, name, dstPath)
+	s.events.On(api.Resource{
+		ID:      name,
+		Text:    api.StatusCopying,
+		Details: msg,
+		Status:  api.Working,
+	})
+
+	srcPath, rebaseName := s.resolveContainerSourcePath(ctx, src.ID, srcPath, options.FollowLink)
+	dstInfo, err := s.statContainerDestinationPath(ctx, dst.ID, dstPath)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.apiClient().CopyFromContainer(ctx, src.ID, client.CopyFromContainerOptions{
+		SourcePath: srcPath,
+	})
+	if err != nil {
+		return err
+	}
+	defer res.Content.Close() //nolint:errcheck
+
+	srcInfo := archive.CopyInfo{
+		Path:       srcPath,
+		Exists:     true,
+		IsDir:      res.Stat.Mode.IsDir(),
+		RebaseName: rebaseName,
+	}
+	dstDir, preparedArchive, err := archive.PrepareArchiveCopy(res.Content, srcInfo, dstInfo)
+	if err != nil {
+		return err
+	}
+	defer preparedArchive.Close() //nolint:errcheck
+
+	_, err = s.apiClient().CopyToContainer(ctx, dst.ID, client.CopyToContainerOptions{
+		DestinationPath:           dstDir,
+		Content:                   preparedArchive,
+		AllowOverwriteDirWithFile: false,
+		CopyUIDGID:                options.CopyUIDGID,
+	})
+	if err != nil {
+		return err
+	}
+	s.events.On(api.Resource{
+		ID:      name,
+		Text:    api.StatusCopied,
+		Details: msg,
+		Status:  api.Done,
+	})
+	return nil
+}
+
+// getSingleContainerForCopy returns the container to copy from or to when copying across services,
+// which requires a single container to be selected for each service
+func (s *composeService) getSingleContainerForCopy(ctx context.Context, projectName string, options api.CopyOptions, serviceName string) (container.Summary, error) {
+	if options.Index > 0 {
+		return s.getSpecifiedContainer(ctx, projectName, oneOffExclude, true, serviceName, options.Index)
+	}
+	withOneOff := oneOffExclude
+	if options.All {
+		withOneOff = oneOffInclude
+	}
+	containers, err := s.getContainers(ctx, projectName, withOneOff, true, serviceName)
+	if err != nil {
+		return container.Summary{}, err
+	}
+	switch len(containers) {
+	case 0:
+		return container.Summary{}, fmt.Errorf("no container found for service %q", serviceName)
+	case 1:
+		return containers[0], nil
+	default:
+		return container.Summary{}, fmt.Errorf("service %q has %d containers, use --index to select the one to copy from or to", serviceName, len(containers))
+	}
+}
+
 func (s *composeService) listContainersTargetedForCopy(ctx context.Context, projectName string, options api.CopyOptions, direction copyDirection, serviceName string) (Containers, error) {
 	var containers Containers
 	var err error
@@ -152,47 +241,9 @@ func (s *composeService) copyToContainer(ctx context.Context, containerID string
 		}
 	}
 
-	// Prepare destination copy info by stat-ing the container path.
-	dstInfo := archive.CopyInfo{Path: dstPath}
-	var dstStat container.PathStat
-	res, err := s.apiClient().ContainerStatPath(ctx, containerID, client.ContainerStatPathOptions{
-		Path: dstPath,
-	})
-	if err == nil {
-		dstStat = res.Stat
-	}
-
-	// If the destination is a symbolic link, we should evaluate it.
-	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
-		linkTarget := dstStat.LinkTarget
-		if !isAbs(linkTarget) {
-			// Join with the parent directory.
-			dstParent, _ := archive.SplitPathDirEntry(dstPath)
-			linkTarget = filepath.Join(dstParent, linkTarget)
-		}
-
-		dstInfo.Path = linkTarget
-		res, err = s.apiClient().ContainerStatPath(ctx, containerID, client.ContainerStatPathOptions{
-			Path: linkTarget,
-		})
-		if err == nil {
-			dstStat = res.Stat
-		}
-	}
-
-	// Validate the destination path
-	if err := command.ValidateOutputPathFileMode(dstStat.Mode); err != nil {
-		return fmt.Errorf(`destination "%s:%s" must be a directory or a regular file: %w`, containerID, dstPath, err)
-	}
-
-	// Ignore any error and assume that the parent directory of the destination
-	// path exists, in which case the copy may still succeed. If there is any
-	// type of conflict (e.g., non-directory overwriting an existing directory
-	// or vice versa) the extraction will fail. If the destination simply did
-	// not exist, but the parent directory does, the extraction will still
-	// succeed.
-	if err == nil {
-		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
+	dstInfo, err := s.statContainerDestinationPath(ctx, containerID, dstPath)
+	if err != nil {
+		return err
 	}
 
 	var (
@@ -253,6 +304,52 @@ func (s *composeService) copyToContainer(ctx context.Context, containerID string
 	return err
 }
 
+// statContainerDestinationPath prepares destination copy info by stat-ing the container path
+func (s *composeService) statContainerDestinationPath(ctx context.Context, containerID, dstPath string) (archive.CopyInfo, error) {
+	dstInfo := archive.CopyInfo{Path: dstPath}
+	var dstStat container.PathStat
+	res, err := s.apiClient().ContainerStatPath(ctx, containerID, client.ContainerStatPathOptions{
+		Path: dstPath,
+	})
+	if err == nil {
+		dstStat = res.Stat
+	}
+
+	// If the destination is a symbolic link, we should evaluate it.
+	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
+		linkTarget := dstStat.LinkTarget
+		if !isAbs(linkTarget) {
+			// Join with the parent directory.
+			dstParent, _ := archive.SplitPathDirEntry(dstPath)
+			linkTarget = filepath.Join(dstParent, linkTarget)
+		}
+
+		dstInfo.Path = linkTarget
+		res, err = s.apiClient().ContainerStatPath(ctx, containerID, client.ContainerStatPathOptions{
+			Path: linkTarget,
+		})
+		if err == nil {
+			dstStat = res.Stat
+		}
+	}
+
+	// Validate the destination path
+	if err := command.ValidateOutputPathFileMode(dstStat.Mode); err != nil {
+		return dstInfo, fmt.Errorf(`destination "%s:%s" must be a directory or a regular file: %w`, containerID, dstPath, err)
+	}
+
+	// Ignore any error and assume that the parent directory of the destination
+	// path exists, in which case the copy may still succeed. If there is any
+	// type of conflict (e.g., non-directory overwriting an existing directory
+	// or vice versa) the extraction will fail. If the destination simply did
+	// not exist, but the parent directory does, the extraction will still
+	// succeed.
+	if err == nil {
+		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
+	}
+	return dstInfo, nil
+}
+
 func (s *composeService) copyFromContainer(ctx context.Context, containerID, srcPath, dstPath string, opts api.CopyOptions) error {
 	var err error
 	if dstPath != "-" {
@@ -267,30 +364,7 @@ func (s *composeService) copyFromContainer(ctx context.Context, containerID, src
 		return err
 	}
 
-	// if client requests to follow symbol link, then must decide target file to be copied
-	var rebaseName string
-	if opts.FollowLink {
-		var srcStat container.PathStat
-		res, err := s.apiClient().ContainerStatPath(ctx, containerID, client.ContainerStatPathOptions{
-			Path: srcPath,
-		})
-		if err == nil {
-			srcStat = res.Stat
-		}
-
-		// If the destination is a symbolic link, we should follow it.
-		if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
-			linkTarget := srcStat.LinkTarget
-			if !isAbs(linkTarget) {
-				// Join with the parent directory.
-				srcParent, _ := archive.SplitPathDirEntry(srcPath)
-				linkTarget = filepath.Join(srcParent, linkTarget)
-			}
-
-			linkTarget, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
-			srcPath = linkTarget
-		}
-	}
+	srcPath, rebaseName := s.resolveContainerSourcePath(ctx, containerID, srcPath, opts.FollowLink)
 
 	res, err := s.apiClient().CopyFromContainer(ctx, containerID, client.CopyFromContainerOptions{
 		SourcePath: srcPath,
@@ -321,6 +395,29 @@ func (s *composeService) copyFromContainer(ctx context.Context, containerID, src
 	return archive.CopyTo(preArchive, srcInfo, dstPath)
 }
 
+// resolveContainerSourcePath returns the container path to be copied and the name to rebase archive
+// entries to. If client requests to follow symbol link, then must decide target file to be copied
+func (s *composeService) resolveContainerSourcePath(ctx context.Context, containerID, srcPath string, followLink bool) (string, string) {
+	if !followLink {
+		return srcPath, ""
+	}
+	res, err := s.apiClient().ContainerStatPath(ctx, containerID, client.ContainerStatPathOptions{
+		Path: srcPath,
+	})
+	if err != nil || res.Stat.Mode&os.ModeSymlink == 0 {
+		return srcPath, ""
+	}
+
+	// If the source is a symbolic link, we should follow it.
+	linkTarget := res.Stat.LinkTarget
+	if !isAbs(linkTarget) {
+		// Join with the parent directory.
+		srcParent, _ := archive.SplitPathDirEntry(srcPath)
+		linkTarget = filepath.Join(srcParent, linkTarget)
+	}
+	return archive.GetRebaseName(srcPath, linkTarget)
+}
+
 // IsAbs is a platform-agnostic wrapper for filepath.IsAbs.
 //
 // On Windows, golang filepath.IsAbs does not consider a path \windows\system32
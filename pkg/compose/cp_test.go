@@ -0,0 +1,105 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestCopyAcrossServices(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	var content bytes.Buffer
+	tw := tar.NewWriter(&content)
+	assert.NilError(t, tw.WriteHeader(&tar.Header{Name: "app.conf", Mode: 0o640, Uid: 1000, Gid: 1000, Size: 5}))
+	_, err = tw.Write([]byte("hello"))
+	assert.NilError(t, err)
+	assert.NilError(t, tw.Close())
+
+	gomock.InOrder(
+		apiClient.EXPECT().ContainerList(gomock.Any(), gomock.Any()).Return(client.ContainerListResult{
+			Items: []container.Summary{testContainer("source", "123", false)},
+		}, nil),
+		apiClient.EXPECT().ContainerList(gomock.Any(), gomock.Any()).Return(client.ContainerListResult{
+			Items: []container.Summary{testContainer("target", "456", false)},
+		}, nil),
+	)
+	apiClient.EXPECT().ContainerStatPath(gomock.Any(), "456", client.ContainerStatPathOptions{Path: "/data"}).
+		Return(client.ContainerStatPathResult{Stat: container.PathStat{Name: "data", Mode: os.ModeDir | 0o755}}, nil)
+	apiClient.EXPECT().CopyFromContainer(gomock.Any(), "123", client.CopyFromContainerOptions{SourcePath: "/etc/app.conf"}).
+		Return(client.CopyFromContainerResult{
+			Content: io.NopCloser(&content),
+			Stat:    container.PathStat{Name: "app.conf", Mode: 0o640},
+		}, nil)
+	apiClient.EXPECT().CopyToContainer(gomock.Any(), "456", gomock.Any()).
+		DoAndReturn(func(_ any, _ string, opts client.CopyToContainerOptions) (client.CopyToContainerResult, error) {
+			assert.Equal(t, opts.DestinationPath, "/data")
+			assert.Assert(t, opts.CopyUIDGID)
+			tr := tar.NewReader(opts.Content)
+			header, err := tr.Next()
+			assert.NilError(t, err)
+			assert.Equal(t, header.Name, "app.conf")
+			assert.Equal(t, header.Uid, 1000)
+			return client.CopyToContainerResult{}, nil
+		})
+
+	err = tested.Copy(t.Context(), strings.ToLower(testProject), api.CopyOptions{
+		Source:      "source:/etc/app.conf",
+		Destination: "target:/data",
+		CopyUIDGID:  true,
+	})
+	assert.NilError(t, err)
+}
+
+func TestCopyAcrossServicesRequiresSingleContainer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	apiClient.EXPECT().ContainerList(gomock.Any(), gomock.Any()).Return(client.ContainerListResult{
+		Items: []container.Summary{
+			testContainer("source", "123", false),
+			testContainer("source", "789", false),
+		},
+	}, nil)
+
+	err = tested.Copy(t.Context(), strings.ToLower(testProject), api.CopyOptions{
+		Source:      "source:/etc/app.conf",
+		Destination: "target:/data",
+	})
+	assert.Error(t, err, `service "source" has 2 containers, use --index to select the one to copy from or to`)
+}
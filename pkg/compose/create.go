@@ -43,6 +43,7 @@ import (
 	cdi "tags.cncf.io/container-device-interface/pkg/parser"
 
 	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/compose/v5/pkg/utils"
 )
 
 type createOptions struct {
@@ -80,6 +81,13 @@ func (s *composeService) create(ctx context.Context, project *types.Project, opt
 		return err
 	}
 
+	if options.ImagesLock != nil {
+		err = s.verifyImagesLock(ctx, project, options.ImagesLock)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = s.ensureModels(ctx, project, options.QuietPull)
 	if err != nil {
 		return err
@@ -110,12 +118,25 @@ func (s *composeService) create(ctx context.Context, project *types.Project, opt
 	observed.setResolvedNetworks(networks, project)
 	observed.setResolvedVolumes(volumes)
 
+	// only confirm when running interactively, so --renew-anon-volumes still runs unattended, e.g. in CI
+	if len(options.RenewAnonVolumes) > 0 && s.stdin().IsTerminal() {
+		if err := s.confirmRenewAnonymousVolumes(project, observed, options.RenewAnonVolumes); err != nil {
+			return err
+		}
+	}
+
 	if len(observed.Orphans) > 0 && !options.IgnoreOrphans && !options.RemoveOrphans {
 		logrus.Warnf("Found orphan containers (%s) for this project. If "+
 			"you removed or renamed this service in your compose "+
 			"file, you can run this command with the "+
 			"--remove-orphans flag to clean it up.", observed.orphanNames())
 	}
+	if s.dryRun && options.RemoveOrphans {
+		// preview orphans to be removed, with the reason they are considered orphaned
+		for _, orphan := range observed.Orphans {
+			s.events.On(newEvent("Orphan "+orphan.Name, api.Done, "Would be removed", orphan.OrphanReason))
+		}
+	}
 
 	plan, err := reconcile(ctx, project, observed, toReconcileOptions(options), s.prompt)
 	if err != nil {
@@ -376,7 +397,11 @@ func getAliases(project *types.Project, service types.ServiceConfig, serviceInde
 	if useNetworkAliases {
 		aliases = append(aliases, service.Name)
 		if cfg != nil {
-			aliases = append(aliases, cfg.Aliases...)
+			for _, alias := range cfg.Aliases {
+				if !slices.Contains(aliases, alias) {
+					aliases = append(aliases, alias)
+				}
+			}
 		}
 	}
 	return aliases
@@ -497,6 +522,46 @@ func parseSecurityOpts(p *types.Project, securityOpts []string) ([]string, bool,
 	return parsed, unconfined, nil
 }
 
+// confirmRenewAnonymousVolumes lists the anonymous volumes of existing containers for services to be recreated with
+// new anonymous volumes, and asks user to confirm their data will be discarded
+func (s *composeService) confirmRenewAnonymousVolumes(project *types.Project, observed *ObservedState, services []string) error {
+	discarded := anonymousVolumes(project, observed, services)
+	if len(discarded) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("Going to discard anonymous volumes:\n  %s\nData they contain will be lost. Continue?", strings.Join(discarded, "\n  "))
+	confirm, err := s.prompt(msg, false)
+	if err != nil {
+		return err
+	}
+	if !confirm {
+		return api.ErrCanceled
+	}
+	return nil
+}
+
+// anonymousVolumes returns the volumes mounted by containers of services which are not declared by the project,
+// as "container: volume (target)"
+func anonymousVolumes(project *types.Project, observed *ObservedState, services []string) []string {
+	named := utils.Set[string]{}
+	for _, volume := range project.Volumes {
+		named.Add(volume.Name)
+	}
+	var volumes []string
+	for _, service := range services {
+		for _, oc := range observed.Containers[service] {
+			for _, m := range oc.Summary.Mounts {
+				if m.Type != mount.TypeVolume || named.Has(m.Name) {
+					continue
+				}
+				volumes = append(volumes, fmt.Sprintf("%s: %s (%s)", getCanonicalContainerName(oc.Summary), m.Name, m.Destination))
+			}
+		}
+	}
+	slices.Sort(volumes)
+	return volumes
+}
+
 func (s *composeService) prepareLabels(labels types.Labels, service types.ServiceConfig, number int) (map[string]string, error) {
 	hash, err := ServiceHash(service)
 	if err != nil {
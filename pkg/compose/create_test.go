@@ -365,6 +365,16 @@ func TestCreateEndpointSettings(t *testing.T) {
 	}, cmpopts.EquateComparable(netip.Addr{})))
 }
 
+func TestGetAliasesOneOff(t *testing.T) {
+	project := &composetypes.Project{Name: "myproject"}
+	// as run with `--name debug`
+	service := composetypes.ServiceConfig{Name: "web", ContainerName: "debug"}
+	cfg := &composetypes.ServiceNetworkConfig{Aliases: []string{"web", "frontend"}}
+
+	assert.DeepEqual(t, getAliases(project, service, -1, cfg, false), []string{"debug"})
+	assert.DeepEqual(t, getAliases(project, service, -1, cfg, true), []string{"debug", "web", "frontend"})
+}
+
 func Test_buildContainerVolumes(t *testing.T) {
 	pwd, err := os.Getwd()
 	assert.NilError(t, err)
@@ -484,3 +494,48 @@ volumes:
 		})
 	}
 }
+
+func TestConfirmRenewAnonymousVolumes(t *testing.T) {
+	project := &composetypes.Project{
+		Name: "myproject",
+		Volumes: composetypes.Volumes{
+			"data": {Name: "myproject_data"},
+		},
+	}
+	observed := &ObservedState{
+		Containers: map[string][]ObservedContainer{
+			"web": {{
+				Summary: container.Summary{
+					Names: []string{"/myproject-web-1"},
+					Mounts: []container.MountPoint{
+						{Type: mountTypes.TypeVolume, Name: "myproject_data", Destination: "/data"},
+						{Type: mountTypes.TypeVolume, Name: "4f1c2d", Destination: "/cache"},
+						{Type: mountTypes.TypeBind, Source: "/src", Destination: "/src"},
+					},
+				},
+			}},
+			"db": {{
+				Summary: container.Summary{
+					Names:  []string{"/myproject-db-1"},
+					Mounts: []container.MountPoint{{Type: mountTypes.TypeVolume, Name: "9a8b7c", Destination: "/var/lib/db"}},
+				},
+			}},
+		},
+	}
+	assert.DeepEqual(t, anonymousVolumes(project, observed, []string{"web"}), []string{"myproject-web-1: 4f1c2d (/cache)"})
+
+	var prompted string
+	s := &composeService{prompt: func(message string, defaultValue bool) (bool, error) {
+		prompted = message
+		return false, nil
+	}}
+	err := s.confirmRenewAnonymousVolumes(project, observed, []string{"web", "db"})
+	assert.ErrorIs(t, err, api.ErrCanceled)
+	assert.Equal(t, prompted, `Going to discard anonymous volumes:
+  myproject-db-1: 9a8b7c (/var/lib/db)
+  myproject-web-1: 4f1c2d (/cache)
+Data they contain will be lost. Continue?`)
+
+	// nothing to confirm when services have no anonymous volume
+	assert.NilError(t, s.confirmRenewAnonymousVolumes(project, observed, []string{"api"}))
+}
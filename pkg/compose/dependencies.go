@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"slices"
-	"strings"
 	"sync"
 
 	"github.com/compose-spec/compose-go/v2/types"
@@ -428,7 +427,9 @@ func (g *Graph) visit(key string, path []string, discovered []string, finished [
 	for _, v := range g.Vertices[key].Children {
 		path := append(path, v.Key)
 		if slices.Contains(discovered, v.Key) {
-			return nil, nil, fmt.Errorf("cycle found: %s", strings.Join(path, " -> "))
+			// only report the services forming the cycle, not the ones leading to it
+			cycle := path[slices.Index(path, v.Key):]
+			return nil, nil, &api.DependencyCycleError{Path: slices.Clone(cycle)}
 		}
 
 		if !slices.Contains(finished, v.Key) {
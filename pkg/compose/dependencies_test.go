@@ -18,8 +18,11 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 
@@ -27,6 +30,7 @@ import (
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 
+	"github.com/docker/compose/v5/pkg/api"
 	"github.com/docker/compose/v5/pkg/utils"
 )
 
@@ -426,3 +430,32 @@ func TestWith_RootNodesAndUp(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildGraphWithCycle(t *testing.T) {
+	dependsOn := func(name string) types.DependsOnConfig {
+		return types.DependsOnConfig{name: {Condition: types.ServiceConditionStarted, Required: true}}
+	}
+	project := &types.Project{
+		Services: types.Services{
+			"front": {Name: "front", DependsOn: dependsOn("a")},
+			"a":     {Name: "a", DependsOn: dependsOn("b")},
+			"b":     {Name: "b", DependsOn: dependsOn("c")},
+			"c":     {Name: "c", DependsOn: dependsOn("a")},
+		},
+	}
+
+	_, err := NewGraph(project, ServiceStopped)
+	var cycleErr *api.DependencyCycleError
+	assert.Assert(t, errors.As(err, &cycleErr), "expected a dependency cycle error, got %v", err)
+	// the cycle can be reported starting from any of its services, but not from services leading to it
+	assert.Equal(t, len(cycleErr.Path), 4)
+	assert.Equal(t, cycleErr.Path[0], cycleErr.Path[3])
+	assert.DeepEqual(t, slices.Sorted(slices.Values(cycleErr.Path[:3])), []string{"a", "b", "c"})
+	assert.ErrorContains(t, err, "dependency cycle found: "+strings.Join(cycleErr.Path, " -> "))
+
+	err = InDependencyOrder(t.Context(), project, func(ctx context.Context, s string) error {
+		t.Fatalf("service %s should not be processed", s)
+		return nil
+	})
+	assert.Assert(t, errors.As(err, &cycleErr))
+}
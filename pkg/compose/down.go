@@ -18,8 +18,11 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
@@ -36,6 +39,19 @@ import (
 type downOp func() error
 
 func (s *composeService) Down(ctx context.Context, projectName string, options api.DownOptions) error {
+	if options.RemoveExternalVolumes && options.Project != nil {
+		if volumes := externalVolumes(options.Project); len(volumes) > 0 {
+			msg := fmt.Sprintf("Going to remove external volumes %s, which are not managed by Compose. Continue?", strings.Join(volumes, ", "))
+			confirm, err := s.prompt(msg, false)
+			if err != nil {
+				return err
+			}
+			if !confirm {
+				// only keep external volumes, the rest of the application still gets removed
+				options.RemoveExternalVolumes = false
+			}
+		}
+	}
 	return Run(ctx, func(ctx context.Context) error {
 		return s.down(ctx, strings.ToLower(projectName), options)
 	}, "down", s.events)
@@ -78,10 +94,22 @@ func (s *composeService) down(ctx context.Context, projectName string, options a
 		resourceToRemove = true
 	}
 
+	var (
+		providerErrs []error
+		providerMux  sync.Mutex
+	)
 	err = InReverseDependencyOrder(ctx, project, func(c context.Context, service string) error {
 		serv := project.Services[service]
 		if serv.Provider != nil {
-			return s.runPlugin(ctx, project, serv, "down")
+			err := s.runPlugin(c, project, serv, "down")
+			if err != nil && !options.AbortOnProviderFailure {
+				// external resources are released best-effort, so a failing provider doesn't prevent teardown
+				providerMux.Lock()
+				providerErrs = append(providerErrs, err)
+				providerMux.Unlock()
+				return nil
+			}
+			return err
 		}
 		serviceContainers := containers.filter(isService(service))
 		err := s.removeContainers(ctx, serviceContainers, &serv, options.Timeout, options.Volumes)
@@ -113,6 +141,10 @@ func (s *composeService) down(ctx context.Context, projectName string, options a
 		ops = append(ops, s.ensureVolumesDown(ctx, project)...)
 	}
 
+	if options.RemoveExternalVolumes {
+		ops = append(ops, s.ensureExternalVolumesDown(ctx, project)...)
+	}
+
 	if !resourceToRemove && len(ops) == 0 {
 		logrus.Warnf("Warning: No resource found to remove for project %q.", projectName)
 	}
@@ -121,7 +153,7 @@ func (s *composeService) down(ctx context.Context, projectName string, options a
 	for _, op := range ops {
 		eg.Go(op)
 	}
-	return eg.Wait()
+	return errors.Join(append(providerErrs, eg.Wait())...)
 }
 
 func checkSelectedServices(options api.DownOptions, project *types.Project) ([]string, error) {
@@ -156,6 +188,46 @@ func (s *composeService) ensureVolumesDown(ctx context.Context, project *types.P
 	return ops
 }
 
+// externalVolumes returns the sorted names of the volumes declared as external by the project
+func externalVolumes(project *types.Project) []string {
+	var names []string
+	for _, vol := range project.Volumes {
+		if vol.External {
+			names = append(names, vol.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (s *composeService) ensureExternalVolumesDown(ctx context.Context, project *types.Project) []downOp {
+	var ops []downOp
+	for _, name := range externalVolumes(project) {
+		ops = append(ops, func() error {
+			return s.removeExternalVolume(ctx, project.Name, name)
+		})
+	}
+	return ops
+}
+
+// removeExternalVolume removes an external volume, unless it is used by a container from another project
+func (s *composeService) removeExternalVolume(ctx context.Context, projectName string, name string) error {
+	res, err := s.apiClient().ContainerList(ctx, client.ContainerListOptions{
+		All:     true,
+		Filters: make(client.Filters).Add("volume", name),
+	})
+	if err != nil {
+		return err
+	}
+	for _, ctr := range res.Items {
+		if ctr.Labels[api.ProjectLabel] != projectName {
+			s.events.On(newEvent(fmt.Sprintf("Volume %s", name), api.Warning, "Not removed, in use by container "+getCanonicalContainerName(ctr)))
+			return nil
+		}
+	}
+	return s.removeVolume(ctx, name)
+}
+
 func (s *composeService) ensureImagesDown(ctx context.Context, project *types.Project, options api.DownOptions) ([]downOp, error) {
 	imagePruner := NewImagePruner(s.apiClient(), project)
 	pruneOpts := ImagePruneOptions{
@@ -312,17 +384,39 @@ func (s *composeService) stopContainer(ctx context.Context, service *types.Servi
 		}
 	}
 
-	_, err := s.apiClient().ContainerStop(ctx, ctr.ID, client.ContainerStopOptions{
-		Timeout: utils.DurationSecondToInt(timeout),
-	})
+	options := containerStopOptions(service, timeout)
+	started := s.clock.Now()
+	_, err := s.apiClient().ContainerStop(ctx, ctr.ID, options)
 	if err != nil {
 		s.events.On(errorEvent(eventName, "Error while Stopping"))
 		return err
 	}
+	if options.Timeout != nil && *options.Timeout > 0 {
+		if grace := time.Duration(*options.Timeout) * time.Second; s.clock.Since(started) >= grace {
+			logrus.Warnf("%s did not stop within %s and was killed", eventName, grace)
+		}
+	}
 	s.events.On(newEvent(eventName, api.Done, api.StatusStopped))
 	return nil
 }
 
+// containerStopOptions returns the options to stop a container for service, applying the stop_signal
+// and stop_grace_period declared by the service, unless timeout is explicitly set
+func containerStopOptions(service *types.ServiceConfig, timeout *time.Duration) client.ContainerStopOptions {
+	options := client.ContainerStopOptions{
+		Timeout: utils.DurationSecondToInt(timeout),
+	}
+	if service == nil {
+		return options
+	}
+	options.Signal = service.StopSignal
+	if timeout == nil && service.StopGracePeriod != nil {
+		grace := time.Duration(*service.StopGracePeriod)
+		options.Timeout = utils.DurationSecondToInt(&grace)
+	}
+	return options
+}
+
 func (s *composeService) stopContainers(ctx context.Context, serv *types.ServiceConfig, containers []containerType.Summary, timeout *time.Duration, listener api.ContainerEventListener) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	for _, ctr := range containers {
@@ -21,6 +21,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/containerd/errdefs"
@@ -236,6 +237,135 @@ func TestDownRemoveOrphans(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func TestDownStopGracePeriod(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	fast := types.Duration(2 * time.Second)
+	slow := types.Duration(30 * time.Second)
+	project := &types.Project{
+		Name: strings.ToLower(testProject),
+		Services: types.Services{
+			"service1": {Name: "service1", StopGracePeriod: &fast},
+			"service2": {Name: "service2", StopGracePeriod: &slow, StopSignal: "SIGINT"},
+		},
+	}
+
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt(false)).Return(
+		client.ContainerListResult{Items: []container.Summary{
+			testContainer("service1", "123", false),
+			testContainer("service2", "456", false),
+		}}, nil)
+
+	api.EXPECT().ContainerStop(gomock.Any(), "123", client.ContainerStopOptions{Timeout: intPtr(2)}).Return(client.ContainerStopResult{}, nil)
+	api.EXPECT().ContainerStop(gomock.Any(), "456", client.ContainerStopOptions{Timeout: intPtr(30), Signal: "SIGINT"}).Return(client.ContainerStopResult{}, nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "123", client.ContainerRemoveOptions{Force: true}).Return(client.ContainerRemoveResult{}, nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "456", client.ContainerRemoveOptions{Force: true}).Return(client.ContainerRemoveResult{}, nil)
+
+	err = tested.Down(t.Context(), strings.ToLower(testProject), compose.DownOptions{Project: project})
+	assert.NilError(t, err)
+}
+
+func TestDownTimeoutOverridesStopGracePeriod(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	slow := types.Duration(30 * time.Second)
+	project := &types.Project{
+		Name: strings.ToLower(testProject),
+		Services: types.Services{
+			"service1": {Name: "service1", StopGracePeriod: &slow},
+			"service2": {Name: "service2", StopSignal: "SIGINT"},
+		},
+	}
+
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt(false)).Return(
+		client.ContainerListResult{Items: []container.Summary{
+			testContainer("service1", "123", false),
+			testContainer("service2", "456", false),
+		}}, nil)
+
+	api.EXPECT().ContainerStop(gomock.Any(), "123", client.ContainerStopOptions{Timeout: intPtr(5)}).Return(client.ContainerStopResult{}, nil)
+	api.EXPECT().ContainerStop(gomock.Any(), "456", client.ContainerStopOptions{Timeout: intPtr(5), Signal: "SIGINT"}).Return(client.ContainerStopResult{}, nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "123", client.ContainerRemoveOptions{Force: true}).Return(client.ContainerRemoveResult{}, nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "456", client.ContainerRemoveOptions{Force: true}).Return(client.ContainerRemoveResult{}, nil)
+
+	timeout := 5 * time.Second
+	err = tested.Down(t.Context(), strings.ToLower(testProject), compose.DownOptions{Project: project, Timeout: &timeout})
+	assert.NilError(t, err)
+}
+
+func TestContainerStopOptions(t *testing.T) {
+	grace := types.Duration(30 * time.Second)
+	service := &types.ServiceConfig{Name: "service1", StopGracePeriod: &grace, StopSignal: "SIGINT"}
+
+	assert.DeepEqual(t, containerStopOptions(nil, nil), client.ContainerStopOptions{})
+	assert.DeepEqual(t, containerStopOptions(service, nil), client.ContainerStopOptions{Timeout: intPtr(30), Signal: "SIGINT"})
+
+	// explicit timeout takes precedence over stop_grace_period
+	timeout := 5 * time.Second
+	assert.DeepEqual(t, containerStopOptions(service, &timeout), client.ContainerStopOptions{Timeout: intPtr(5), Signal: "SIGINT"})
+}
+
+func TestDownProviderFailure(t *testing.T) {
+	for _, abort := range []bool{false, true} {
+		t.Run(fmt.Sprintf("abort=%t", abort), func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			api, cli := prepareMocks(mockCtrl)
+			tested, err := NewComposeService(cli)
+			assert.NilError(t, err)
+
+			project := &types.Project{
+				Name: strings.ToLower(testProject),
+				Services: types.Services{
+					"service1": {
+						Name:      "service1",
+						DependsOn: types.DependsOnConfig{"database": {Condition: types.ServiceConditionStarted, Required: true}},
+					},
+					// "compose" is not a valid provider type, so running the provider fails
+					"database": {Name: "database", Provider: &types.ServiceProviderConfig{Type: "compose"}},
+				},
+				Networks: types.Networks{"default": {Name: "myProject_default"}},
+			}
+
+			api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt(false)).Return(
+				client.ContainerListResult{Items: []container.Summary{testContainer("service1", "123", false)}}, nil)
+
+			// dependent service is removed before the provider service
+			api.EXPECT().ContainerStop(gomock.Any(), "123", client.ContainerStopOptions{}).Return(client.ContainerStopResult{}, nil)
+			api.EXPECT().ContainerRemove(gomock.Any(), "123", client.ContainerRemoveOptions{Force: true}).Return(client.ContainerRemoveResult{}, nil)
+
+			if !abort {
+				api.EXPECT().NetworkList(gomock.Any(), client.NetworkListOptions{
+					Filters: projectFilter(strings.ToLower(testProject)).Add("label", networkFilter("default")),
+				}).Return(client.NetworkListResult{
+					Items: []network.Summary{{Network: network.Network{ID: "abc123", Name: "myProject_default"}}},
+				}, nil)
+				api.EXPECT().NetworkInspect(gomock.Any(), "abc123", gomock.Any()).Return(client.NetworkInspectResult{
+					Network: network.Inspect{Network: network.Network{ID: "abc123"}},
+				}, nil)
+				api.EXPECT().NetworkRemove(gomock.Any(), "abc123", gomock.Any()).Return(client.NetworkRemoveResult{}, nil)
+			}
+
+			err = tested.Down(t.Context(), strings.ToLower(testProject), compose.DownOptions{
+				Project:                project,
+				AbortOnProviderFailure: abort,
+			})
+			assert.ErrorContains(t, err, "'compose' is not a valid provider type")
+		})
+	}
+}
+
 func TestDownRemoveVolumes(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -419,5 +549,94 @@ func prepareMocks(mockCtrl *gomock.Controller) (*mocks.MockAPIClient, *mocks.Moc
 	cli.EXPECT().Client().Return(api).AnyTimes()
 	cli.EXPECT().Err().Return(streams.NewOut(os.Stderr)).AnyTimes()
 	cli.EXPECT().Out().Return(streams.NewOut(os.Stdout)).AnyTimes()
+	cli.EXPECT().CurrentContext().Return("default").AnyTimes()
 	return api, cli
 }
+
+func TestDownRemoveExternalVolumes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	var prompted string
+	tested, err := NewComposeService(cli, WithPrompt(func(message string, defaultValue bool) (bool, error) {
+		prompted = message
+		return true, nil
+	}))
+	assert.NilError(t, err)
+
+	project := &types.Project{
+		Name:     strings.ToLower(testProject),
+		Services: types.Services{"service1": {Name: "service1"}},
+		Volumes: types.Volumes{
+			"data":   {Name: "myProject_data"},
+			"shared": {Name: "shared", External: true},
+			"used":   {Name: "used", External: true},
+		},
+	}
+
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt(false)).Return(
+		client.ContainerListResult{
+			Items: []container.Summary{testContainer("service1", "123", false)},
+		}, nil)
+	api.EXPECT().ContainerStop(gomock.Any(), "123", client.ContainerStopOptions{}).Return(client.ContainerStopResult{}, nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "123", client.ContainerRemoveOptions{Force: true}).Return(client.ContainerRemoveResult{}, nil)
+
+	api.EXPECT().ContainerList(gomock.Any(), client.ContainerListOptions{
+		All:     true,
+		Filters: make(client.Filters).Add("volume", "shared"),
+	}).Return(client.ContainerListResult{}, nil)
+	api.EXPECT().VolumeInspect(gomock.Any(), "shared", gomock.Any()).Return(client.VolumeInspectResult{}, nil)
+	api.EXPECT().VolumeRemove(gomock.Any(), "shared", client.VolumeRemoveOptions{Force: true}).Return(client.VolumeRemoveResult{}, nil)
+
+	// used by a container from another project
+	otherProject := container.Summary{
+		ID:     "456",
+		Names:  []string{"/other-app-1"},
+		Labels: map[string]string{compose.ProjectLabel: "other"},
+	}
+	api.EXPECT().ContainerList(gomock.Any(), client.ContainerListOptions{
+		All:     true,
+		Filters: make(client.Filters).Add("volume", "used"),
+	}).Return(client.ContainerListResult{Items: []container.Summary{otherProject}}, nil)
+
+	err = tested.Down(t.Context(), strings.ToLower(testProject), compose.DownOptions{
+		Project:               project,
+		RemoveExternalVolumes: true,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, prompted, "Going to remove external volumes shared, used, which are not managed by Compose. Continue?")
+}
+
+func TestDownRemoveExternalVolumesNotConfirmed(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli, WithPrompt(func(message string, defaultValue bool) (bool, error) {
+		return false, nil
+	}))
+	assert.NilError(t, err)
+
+	project := &types.Project{
+		Name:     strings.ToLower(testProject),
+		Services: types.Services{"service1": {Name: "service1"}},
+		Volumes: types.Volumes{
+			"shared": {Name: "shared", External: true},
+		},
+	}
+
+	// containers are still removed, but external volumes are kept
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt(false)).Return(
+		client.ContainerListResult{
+			Items: []container.Summary{testContainer("service1", "123", false)},
+		}, nil)
+	api.EXPECT().ContainerStop(gomock.Any(), "123", client.ContainerStopOptions{}).Return(client.ContainerStopResult{}, nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "123", client.ContainerRemoveOptions{Force: true}).Return(client.ContainerRemoveResult{}, nil)
+
+	err = tested.Down(t.Context(), strings.ToLower(testProject), compose.DownOptions{
+		Project:               project,
+		RemoveExternalVolumes: true,
+	})
+	assert.NilError(t, err)
+}
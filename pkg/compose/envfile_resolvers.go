@@ -0,0 +1,162 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/dotenv"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+// envFileReference returns the reference for an env_file path using a scheme with a registered resolver.
+// As relative paths get resolved while the model is loaded, `scheme://path` can have been turned into
+// `<working_dir>/scheme:/path`, so the scheme is the path segment right before the first `:/` separator
+// it is followed by.
+func (s *composeService) envFileReference(path string) (string, api.EnvFileResolver, bool) {
+	path = filepath.ToSlash(path)
+	for offset := 0; ; {
+		i := strings.Index(path[offset:], ":/")
+		if i < 0 {
+			return "", nil, false
+		}
+		i += offset
+		scheme := path[strings.LastIndex(path[:i], "/")+1 : i]
+		if resolver, ok := s.envFileResolvers[scheme]; ok && scheme != "" {
+			ref := strings.TrimPrefix(path[i+len(":/"):], "/")
+			return scheme + "://" + ref, resolver, true
+		}
+		offset = i + len(":/")
+	}
+}
+
+// envFileValues caches values of env_file references, so a reference shared by services is only resolved once
+type envFileValues map[string]types.Mapping
+
+// resolve returns the values of the env_file reference, or nil when an optional reference can't be resolved
+func (v envFileValues) resolve(ctx context.Context, service string, envFile types.EnvFile, ref string, resolver api.EnvFileResolver) (types.Mapping, error) {
+	if values, ok := v[ref]; ok {
+		return values, nil
+	}
+	pairs, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		if !envFile.Required {
+			logrus.Debugf("skipping env_file %s: %v", ref, err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to resolve env_file %s for service %q: %w", ref, service, err)
+	}
+	values := types.NewMapping(pairs)
+	v[ref] = values
+	return values, nil
+}
+
+// withServicesEnvironmentResolved resolves the service environment like compose-go does, but env_file
+// entries using a registered scheme are resolved in order with local env files: they have the same
+// precedence, and their values can be used to interpolate the following env files. Those entries are
+// removed from the service env_file entries so they don't get loaded as local files.
+func (s *composeService) withServicesEnvironmentResolved(ctx context.Context, project *types.Project) (*types.Project, error) {
+	resolved := envFileValues{}
+	for name, service := range project.Services {
+		service.Environment = service.Environment.Resolve(project.Environment.Resolve)
+		environment := service.Environment.ToMapping()
+		lookup := func(k string) (string, bool) {
+			// project environment has precedence doing interpolation
+			if v, ok := project.Environment.Resolve(k); ok {
+				return v, true
+			}
+			if v, ok := service.Environment[k]; ok && v != nil {
+				return *v, true
+			}
+			return "", false
+		}
+		var envFiles []types.EnvFile
+		for _, envFile := range service.EnvFiles {
+			ref, resolver, ok := s.envFileReference(envFile.Path)
+			if !ok {
+				if err := loadEnvFile(envFile, environment, lookup); err != nil {
+					return nil, err
+				}
+				envFiles = append(envFiles, envFile)
+				continue
+			}
+			values, err := resolved.resolve(ctx, name, envFile, ref, resolver)
+			if err != nil {
+				return nil, err
+			}
+			maps.Copy(environment, values)
+		}
+		service.Environment = environment.ToMappingWithEquals().OverrideBy(service.Environment)
+		service.EnvFiles = envFiles
+		project.Services[name] = service
+	}
+	return project, nil
+}
+
+// loadEnvFile loads a local env file into environment, as compose-go does
+func loadEnvFile(envFile types.EnvFile, environment types.Mapping, lookup dotenv.LookupFn) error {
+	file, err := os.Open(envFile.Path)
+	if os.IsNotExist(err) {
+		if envFile.Required {
+			return fmt.Errorf("env file %s not found: %w", envFile.Path, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	return dotenv.ParseWithFormat(file, envFile.Path, environment, lookup, envFile.Format)
+}
+
+// resolveEnvFileReferences resolves env_file entries using a registered scheme into the service
+// environment, when the service environment isn't resolved, and removes them from the service env_file
+// entries so they don't get loaded as local files.
+func (s *composeService) resolveEnvFileReferences(ctx context.Context, project *types.Project) (*types.Project, error) {
+	resolved := envFileValues{}
+	for name, service := range project.Services {
+		var envFiles []types.EnvFile
+		environment := types.MappingWithEquals{}
+		for _, envFile := range service.EnvFiles {
+			ref, resolver, ok := s.envFileReference(envFile.Path)
+			if !ok {
+				envFiles = append(envFiles, envFile)
+				continue
+			}
+			values, err := resolved.resolve(ctx, name, envFile, ref, resolver)
+			if err != nil {
+				return nil, err
+			}
+			maps.Copy(environment, values.ToMappingWithEquals())
+		}
+		if len(envFiles) == len(service.EnvFiles) {
+			continue
+		}
+		service.Environment = environment.OverrideBy(service.Environment)
+		service.EnvFiles = envFiles
+		project.Services[name] = service
+	}
+	return project, nil
+}
@@ -17,13 +17,20 @@
 package compose
 
 import (
+	"archive/tar"
+	"bufio"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"path"
 	"strings"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command/container"
 	containerType "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"github.com/sirupsen/logrus"
 
 	"github.com/docker/compose/v5/pkg/api"
 )
@@ -41,7 +48,10 @@ func (s *composeService) Exec(ctx context.Context, projectName string, options a
 	exec.Detach = options.Detach
 	exec.User = options.User
 	exec.Privileged = options.Privileged
-	exec.Workdir = options.WorkingDir
+	exec.Workdir, err = s.resolveWorkingDir(ctx, target.ID, options.User, options.WorkingDir)
+	if err != nil {
+		return 0, err
+	}
 	exec.Command = options.Command
 	for _, v := range options.Environment {
 		err := exec.Env.Set(v)
@@ -61,3 +71,73 @@ func (s *composeService) Exec(ctx context.Context, projectName string, options a
 func (s *composeService) getExecTarget(ctx context.Context, projectName string, opts api.RunOptions) (containerType.Summary, error) {
 	return s.getSpecifiedContainer(ctx, projectName, oneOffInclude, false, opts.Service, opts.Index)
 }
+
+// resolveWorkingDir expands a leading `~` or `~name` in workingDir to the home directory of the user running the
+// command or of the named user, as declared by the container's /etc/passwd. Falls back to `/` when the home directory
+// can't be determined.
+func (s *composeService) resolveWorkingDir(ctx context.Context, containerID string, user string, workingDir string) (string, error) {
+	if !strings.HasPrefix(workingDir, "~") {
+		return workingDir, nil
+	}
+	name, rest, _ := strings.Cut(workingDir[1:], "/")
+	if name == "" {
+		name = user
+		if name == "" {
+			res, err := s.apiClient().ContainerInspect(ctx, containerID, client.ContainerInspectOptions{})
+			if err != nil {
+				return "", err
+			}
+			if res.Container.Config != nil {
+				name = res.Container.Config.User
+			}
+		}
+		// user can be set as user:group
+		name, _, _ = strings.Cut(name, ":")
+		if name == "" {
+			name = "root"
+		}
+	}
+
+	home, err := s.userHome(ctx, containerID, name)
+	if err != nil {
+		logrus.Warnf("can't resolve home directory of user %q, using / as working directory: %v", name, err)
+		home = "/"
+	}
+	return path.Join(home, rest), nil
+}
+
+// userHome returns the home directory of user, either a name or a uid, from the container's /etc/passwd
+func (s *composeService) userHome(ctx context.Context, containerID string, user string) (string, error) {
+	res, err := s.apiClient().CopyFromContainer(ctx, containerID, client.CopyFromContainerOptions{
+		SourcePath: "/etc/passwd",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer res.Content.Close() //nolint:errcheck
+
+	tr := tar.NewReader(res.Content)
+	if _, err := tr.Next(); err != nil {
+		return "", err
+	}
+	return lookupHome(tr, user)
+}
+
+// lookupHome returns the home directory of user, either a name or a uid, from a passwd file
+func lookupHome(passwd io.Reader, user string) (string, error) {
+	scanner := bufio.NewScanner(passwd)
+	for scanner.Scan() {
+		// name:password:uid:gid:gecos:home:shell
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) < 6 {
+			continue
+		}
+		if (fields[0] == user || fields[2] == user) && fields[5] != "" {
+			return fields[5], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("user %q not found in /etc/passwd", user)
+}
@@ -0,0 +1,106 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+const testPasswd = `root:x:0:0:root:/root:/bin/sh
+daemon:x:1:1:daemon:/usr/sbin:/usr/sbin/nologin
+node:x:1000:1000::/home/node:/bin/sh
+`
+
+func passwdArchive(t *testing.T) io.ReadCloser {
+	t.Helper()
+	var content bytes.Buffer
+	tw := tar.NewWriter(&content)
+	assert.NilError(t, tw.WriteHeader(&tar.Header{Name: "passwd", Mode: 0o644, Size: int64(len(testPasswd))}))
+	_, err := tw.Write([]byte(testPasswd))
+	assert.NilError(t, err)
+	assert.NilError(t, tw.Close())
+	return io.NopCloser(&content)
+}
+
+func TestLookupHome(t *testing.T) {
+	home, err := lookupHome(strings.NewReader(testPasswd), "node")
+	assert.NilError(t, err)
+	assert.Equal(t, home, "/home/node")
+
+	home, err = lookupHome(strings.NewReader(testPasswd), "1000")
+	assert.NilError(t, err)
+	assert.Equal(t, home, "/home/node")
+
+	_, err = lookupHome(strings.NewReader(testPasswd), "nobody")
+	assert.Error(t, err, `user "nobody" not found in /etc/passwd`)
+}
+
+func TestResolveWorkingDir(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested := composeService{dockerCli: cli}
+
+	// absolute and relative paths are left unchanged
+	for _, dir := range []string{"", "/app", "app", "app/~"} {
+		resolved, err := tested.resolveWorkingDir(t.Context(), "123", "", dir)
+		assert.NilError(t, err)
+		assert.Equal(t, resolved, dir)
+	}
+
+	passwd := client.CopyFromContainerOptions{SourcePath: "/etc/passwd"}
+
+	// explicit user
+	apiClient.EXPECT().CopyFromContainer(gomock.Any(), "123", passwd).
+		Return(client.CopyFromContainerResult{Content: passwdArchive(t)}, nil)
+	resolved, err := tested.resolveWorkingDir(t.Context(), "123", "node", "~/app")
+	assert.NilError(t, err)
+	assert.Equal(t, resolved, "/home/node/app")
+
+	// container user, set as uid:gid
+	apiClient.EXPECT().ContainerInspect(gomock.Any(), "123", gomock.Any()).
+		Return(client.ContainerInspectResult{Container: container.InspectResponse{Config: &container.Config{User: "1000:1000"}}}, nil)
+	apiClient.EXPECT().CopyFromContainer(gomock.Any(), "123", passwd).
+		Return(client.CopyFromContainerResult{Content: passwdArchive(t)}, nil)
+	resolved, err = tested.resolveWorkingDir(t.Context(), "123", "", "~")
+	assert.NilError(t, err)
+	assert.Equal(t, resolved, "/home/node")
+
+	// home directory of another user
+	apiClient.EXPECT().CopyFromContainer(gomock.Any(), "123", passwd).
+		Return(client.CopyFromContainerResult{Content: passwdArchive(t)}, nil)
+	resolved, err = tested.resolveWorkingDir(t.Context(), "123", "node", "~root/app")
+	assert.NilError(t, err)
+	assert.Equal(t, resolved, "/root/app")
+
+	// unknown user falls back to /
+	apiClient.EXPECT().CopyFromContainer(gomock.Any(), "123", passwd).
+		Return(client.CopyFromContainerResult{Content: passwdArchive(t)}, nil)
+	resolved, err = tested.resolveWorkingDir(t.Context(), "123", "nobody", "~/app")
+	assert.NilError(t, err)
+	assert.Equal(t, resolved, "/app")
+}
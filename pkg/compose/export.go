@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
 	"github.com/moby/moby/client"
 	"github.com/moby/sys/atomicwriter"
 
@@ -38,6 +39,12 @@ func (s *composeService) Export(ctx context.Context, projectName string, options
 func (s *composeService) export(ctx context.Context, projectName string, options api.ExportOptions) error {
 	projectName = strings.ToLower(projectName)
 
+	if options.Index == 0 {
+		if err := s.ensureSingleContainer(ctx, projectName, options.Service); err != nil {
+			return err
+		}
+	}
+
 	container, err := s.getSpecifiedContainer(ctx, projectName, oneOffInclude, false, options.Service, options.Index)
 	if err != nil {
 		return err
@@ -71,16 +78,10 @@ func (s *composeService) export(ctx context.Context, projectName string, options
 
 	if !s.dryRun {
 		if options.Output == "" {
-			_, err := io.Copy(s.stdout(), responseBody)
-			return err
-		} else {
-			writer, err := atomicwriter.New(options.Output, 0o600)
-			if err != nil {
+			if _, err := io.Copy(s.stdout(), responseBody); err != nil {
 				return err
 			}
-			defer func() { _ = writer.Close() }()
-
-			_, err = io.Copy(writer, responseBody)
+		} else if err := s.exportToFile(name, options.Output, responseBody); err != nil {
 			return err
 		}
 	}
@@ -93,3 +94,43 @@ func (s *composeService) export(ctx context.Context, projectName string, options
 
 	return nil
 }
+
+func (s *composeService) exportToFile(name string, output string, archive io.Reader) error {
+	writer, err := atomicwriter.New(output, 0o600)
+	if err != nil {
+		return err
+	}
+	// atomicwriter only moves the file in place on Close, failure to do so must be reported
+	progress := &exportProgress{name: name, events: s.events}
+	if _, err := io.Copy(io.MultiWriter(writer, progress), archive); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// exportProgressStep is the amount of exported data between two progress events
+const exportProgressStep = 10 * units.MiB
+
+// exportProgress reports the amount of data written by a container export
+type exportProgress struct {
+	name     string
+	events   api.EventProcessor
+	current  int64
+	reported int64
+}
+
+func (p *exportProgress) Write(b []byte) (int, error) {
+	p.current += int64(len(b))
+	if p.current-p.reported >= exportProgressStep {
+		p.reported = p.current
+		p.events.On(api.Resource{
+			ID:      p.name,
+			Text:    api.StatusExporting,
+			Status:  api.Working,
+			Current: p.current,
+			Details: units.HumanSize(float64(p.current)),
+		})
+	}
+	return len(b), nil
+}
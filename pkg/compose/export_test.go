@@ -0,0 +1,102 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/go-units"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	compose "github.com/docker/compose/v5/pkg/api"
+)
+
+func TestExportScaledServiceRequiresIndex(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	first := testContainer("service1", "123", false)
+	first.Labels[compose.ContainerNumberLabel] = "1"
+	second := testContainer("service1", "456", false)
+	second.Labels[compose.ContainerNumberLabel] = "2"
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude, "service1"),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{second, first},
+	}, nil)
+
+	err = tested.Export(t.Context(), name, compose.ExportOptions{Service: "service1", Output: filepath.Join(t.TempDir(), "out.tar")})
+	assert.Error(t, err, `service "service1" has 2 running containers, use --index to select one of: 1, 2`)
+}
+
+func TestExportIndexToFile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	replica := testContainer("service1", "456", false)
+	replica.Labels[compose.ContainerNumberLabel] = "2"
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffInclude, "service1").Add("label", containerNumberFilter(2)),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{replica},
+	}, nil)
+	api.EXPECT().ContainerExport(gomock.Any(), "456", client.ContainerExportOptions{}).
+		Return(io.NopCloser(strings.NewReader("archive")), nil)
+
+	output := filepath.Join(t.TempDir(), "out.tar")
+	err = tested.Export(t.Context(), name, compose.ExportOptions{Service: "service1", Index: 2, Output: output})
+	assert.NilError(t, err)
+
+	content, err := os.ReadFile(output)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "archive")
+}
+
+func TestExportProgress(t *testing.T) {
+	events := &capturingEvents{}
+	progress := &exportProgress{name: "service1", events: events}
+
+	_, err := io.Copy(progress, bytes.NewReader(make([]byte, exportProgressStep/2)))
+	assert.NilError(t, err)
+	assert.Equal(t, len(events.resources), 0)
+
+	_, err = io.Copy(progress, bytes.NewReader(make([]byte, exportProgressStep)))
+	assert.NilError(t, err)
+	assert.Equal(t, len(events.resources), 1)
+	assert.Equal(t, events.resources[0].Current, int64(exportProgressStep*3/2))
+	assert.Equal(t, events.resources[0].Details, units.HumanSize(float64(exportProgressStep*3/2)))
+}
@@ -30,7 +30,10 @@ func ServiceHash(o types.ServiceConfig) (string, error) {
 	o.PullPolicy = ""
 	o.Scale = nil
 	if o.Deploy != nil {
-		o.Deploy.Replicas = nil
+		// copy so the replicas of the hashed service are left untouched
+		deploy := *o.Deploy
+		deploy.Replicas = nil
+		o.Deploy = &deploy
 	}
 	o.DependsOn = nil
 	o.Profiles = nil
@@ -62,3 +65,56 @@ func VolumeHash(o types.VolumeConfig) (string, error) {
 	}
 	return digest.SHA256.FromBytes(bytes).Encoded(), nil
 }
+
+// ProjectHash computes a digest of the project configuration, so that tooling can detect changes before running `Up`.
+// It is deterministic for an identical project and covers:
+//   - the project name
+//   - enabled services, as hashed by ServiceHash (build configuration excluded), and their number of replicas
+//   - networks and volumes, as hashed by NetworkHash and VolumeHash
+//
+// Runtime state, like containers or images already built, is not included.
+func ProjectHash(project *types.Project) (string, error) {
+	type serviceHash struct {
+		ConfigHash string
+		Replicas   int
+	}
+	hashes := struct {
+		Name     string
+		Services map[string]serviceHash
+		Networks map[string]string
+		Volumes  map[string]string
+	}{
+		Name:     project.Name,
+		Services: map[string]serviceHash{},
+		Networks: map[string]string{},
+		Volumes:  map[string]string{},
+	}
+	for name, service := range project.Services {
+		hash, err := ServiceHash(service)
+		if err != nil {
+			return "", err
+		}
+		hashes.Services[name] = serviceHash{ConfigHash: hash, Replicas: service.GetScale()}
+	}
+	for name, network := range project.Networks {
+		hash, err := NetworkHash(&network)
+		if err != nil {
+			return "", err
+		}
+		hashes.Networks[name] = hash
+	}
+	for name, volume := range project.Volumes {
+		hash, err := VolumeHash(volume)
+		if err != nil {
+			return "", err
+		}
+		hashes.Volumes[name] = hash
+	}
+
+	// json.Marshal sorts map keys, so the digest doesn't depend on map iteration order
+	bytes, err := json.Marshal(hashes)
+	if err != nil {
+		return "", err
+	}
+	return digest.SHA256.FromBytes(bytes).Encoded(), nil
+}
@@ -31,6 +31,13 @@ func TestServiceHash(t *testing.T) {
 	assert.Equal(t, hash1, hash2)
 }
 
+func TestServiceHashKeepsReplicas(t *testing.T) {
+	service := serviceConfig(3)
+	_, err := ServiceHash(service)
+	assert.NilError(t, err)
+	assert.Equal(t, *service.Deploy.Replicas, 3)
+}
+
 func serviceConfig(replicas int) types.ServiceConfig {
 	return types.ServiceConfig{
 		Scale: &replicas,
@@ -41,3 +48,67 @@ func serviceConfig(replicas int) types.ServiceConfig {
 		Image: "bar",
 	}
 }
+
+func TestProjectHash(t *testing.T) {
+	newProject := func() *types.Project {
+		return &types.Project{
+			Name: "test",
+			Services: types.Services{
+				"web": {Name: "web", Image: "nginx"},
+				"db":  {Name: "db", Image: "postgres", Build: &types.BuildConfig{Context: "."}},
+			},
+			Networks: types.Networks{
+				"default": {Name: "test_default"},
+			},
+			Volumes: types.Volumes{
+				"data": {Name: "test_data"},
+			},
+		}
+	}
+
+	hash, err := ProjectHash(newProject())
+	assert.NilError(t, err)
+	for range 10 {
+		same, err := ProjectHash(newProject())
+		assert.NilError(t, err)
+		assert.Equal(t, hash, same)
+	}
+
+	changes := map[string]func(p *types.Project){
+		"service": func(p *types.Project) {
+			web := p.Services["web"]
+			web.Image = "nginx:alpine"
+			p.Services["web"] = web
+		},
+		"replicas": func(p *types.Project) {
+			web := p.Services["web"]
+			replicas := 3
+			web.Scale = &replicas
+			p.Services["web"] = web
+		},
+		"network": func(p *types.Project) {
+			p.Networks["back"] = types.NetworkConfig{Name: "test_back"}
+		},
+		"volume": func(p *types.Project) {
+			p.Volumes["data"] = types.VolumeConfig{Name: "test_data", Driver: "nfs"}
+		},
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			project := newProject()
+			change(project)
+			changed, err := ProjectHash(project)
+			assert.NilError(t, err)
+			assert.Assert(t, hash != changed)
+		})
+	}
+
+	// build configuration is not part of the hash
+	project := newProject()
+	db := project.Services["db"]
+	db.Build = &types.BuildConfig{Context: "./db"}
+	project.Services["db"] = db
+	unchanged, err := ProjectHash(project)
+	assert.NilError(t, err)
+	assert.Equal(t, hash, unchanged)
+}
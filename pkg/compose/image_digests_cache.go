@@ -0,0 +1,204 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/config"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose/v5/pkg/utils"
+)
+
+// imageDigestsCacheFile is where image digests resolved from registries are cached
+const imageDigestsCacheFile = "compose/cache/image-digests.json"
+
+// imageDigestsResolveLimit bounds concurrent requests to registries to resolve image digests
+const imageDigestsResolveLimit = 4
+
+// ResolveImageDigests pins images of project services to their digest, as resolved by resolver. Images are
+// resolved concurrently, with up to imageDigestsResolveLimit requests running, and once when used by multiple
+// services.
+func ResolveImageDigests(project *types.Project, resolver func(named reference.Named) (digest.Digest, error)) (*types.Project, error) {
+	var (
+		mu      sync.Mutex
+		digests = map[string]digest.Digest{}
+		seen    = utils.Set[string]{}
+		eg      errgroup.Group
+	)
+	eg.SetLimit(imageDigestsResolveLimit)
+	for _, service := range project.Services {
+		if service.Image == "" {
+			continue
+		}
+		named, err := reference.ParseDockerRef(service.Image)
+		if err != nil {
+			return nil, err
+		}
+		key := named.String()
+		if _, ok := named.(reference.Canonical); ok || seen.Has(key) {
+			continue
+		}
+		seen.Add(key)
+		eg.Go(func() error {
+			d, err := resolver(named)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			digests[key] = d
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return project.WithImagesResolved(func(named reference.Named) (digest.Digest, error) {
+		return digests[named.String()], nil
+	})
+}
+
+type imageDigestsCacheEntry struct {
+	Digest   digest.Digest `json:"digest"`
+	Resolved time.Time     `json:"resolved"`
+}
+
+// ImageDigestsCache caches image digests resolved from registries on disk, keyed by image reference,
+// so they can be reused by subsequent commands
+type ImageDigestsCache struct {
+	path    string
+	ttl     time.Duration
+	refresh bool
+	now     func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]imageDigestsCacheEntry
+	resolved map[string]imageDigestsCacheEntry
+}
+
+// NewImageDigestsCache loads the image digests cache from the docker config directory. Digests
+// resolved for longer than ttl are ignored, and all are when refresh is set.
+func NewImageDigestsCache(ttl time.Duration, refresh bool) (*ImageDigestsCache, error) {
+	c := &ImageDigestsCache{
+		path:     filepath.Join(config.Dir(), imageDigestsCacheFile),
+		ttl:      ttl,
+		refresh:  refresh,
+		now:      time.Now,
+		resolved: map[string]imageDigestsCacheEntry{},
+	}
+	entries, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	c.entries = entries
+	return c, nil
+}
+
+func (c *ImageDigestsCache) load() (map[string]imageDigestsCacheEntry, error) {
+	entries := map[string]imageDigestsCacheEntry{}
+	content, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &entries); err != nil {
+		// a corrupted cache is not an issue, digests will be resolved again
+		return map[string]imageDigestsCacheEntry{}, nil
+	}
+	return entries, nil
+}
+
+// Resolver wraps resolver so digests are looked up in cache first, and resolved digests are added
+// to the cache
+func (c *ImageDigestsCache) Resolver(resolver func(named reference.Named) (digest.Digest, error)) func(named reference.Named) (digest.Digest, error) {
+	return func(named reference.Named) (digest.Digest, error) {
+		key := named.String()
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		c.mu.Unlock()
+		if ok && !c.refresh && c.now().Sub(entry.Resolved) < c.ttl {
+			return entry.Digest, nil
+		}
+
+		d, err := resolver(named)
+		if err != nil {
+			return "", err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		entry = imageDigestsCacheEntry{Digest: d, Resolved: c.now()}
+		c.entries[key] = entry
+		c.resolved[key] = entry
+		return d, nil
+	}
+}
+
+// Save persists digests resolved since the cache was loaded, preserving those recorded by other
+// commands in the meantime
+func (c *ImageDigestsCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.resolved) == 0 {
+		return nil
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	entries, err := c.load()
+	if err != nil {
+		return err
+	}
+	for key, entry := range c.resolved {
+		entries[key] = entry
+	}
+	content, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	// write to a temporary file first, so a concurrent command never reads a partial cache
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path))
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,107 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/config"
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/v3/assert"
+)
+
+func TestImageDigestsCache(t *testing.T) {
+	previous := config.Dir()
+	config.SetDir(t.TempDir())
+	t.Cleanup(func() { config.SetDir(previous) })
+
+	var calls atomic.Int32
+	resolver := func(named reference.Named) (digest.Digest, error) {
+		calls.Add(1)
+		return digest.FromString(named.String()), nil
+	}
+	alpine, err := reference.ParseDockerRef("alpine:3")
+	assert.NilError(t, err)
+
+	cache, err := NewImageDigestsCache(time.Minute, false)
+	assert.NilError(t, err)
+	d, err := cache.Resolver(resolver)(alpine)
+	assert.NilError(t, err)
+	assert.Equal(t, d, digest.FromString("docker.io/library/alpine:3"))
+	assert.NilError(t, cache.Save())
+	assert.Equal(t, calls.Load(), int32(1))
+
+	// digest is reused from disk within TTL
+	cache, err = NewImageDigestsCache(time.Minute, false)
+	assert.NilError(t, err)
+	_, err = cache.Resolver(resolver)(alpine)
+	assert.NilError(t, err)
+	assert.Equal(t, calls.Load(), int32(1))
+
+	// expired digest is resolved again
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, err = cache.Resolver(resolver)(alpine)
+	assert.NilError(t, err)
+	assert.Equal(t, calls.Load(), int32(2))
+
+	// refresh ignores cached digests
+	cache, err = NewImageDigestsCache(time.Minute, true)
+	assert.NilError(t, err)
+	_, err = cache.Resolver(resolver)(alpine)
+	assert.NilError(t, err)
+	assert.Equal(t, calls.Load(), int32(3))
+}
+
+func TestResolveImageDigests(t *testing.T) {
+	var calls, running, maxRunning atomic.Int32
+	resolver := func(named reference.Named) (digest.Digest, error) {
+		calls.Add(1)
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return digest.FromString(named.String()), nil
+	}
+
+	pinned := "alpine@" + digest.FromString("alpine").String()
+	project := &types.Project{Services: types.Services{
+		"pinned": {Name: "pinned", Image: pinned},
+		"build":  {Name: "build"},
+		"same":   {Name: "same", Image: "a"},
+	}}
+	for _, image := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		project.Services[image] = types.ServiceConfig{Name: image, Image: image}
+	}
+
+	resolved, err := ResolveImageDigests(project, resolver)
+	assert.NilError(t, err)
+	assert.Equal(t, calls.Load(), int32(10), "each image is resolved once")
+	assert.Assert(t, maxRunning.Load() > 1, "images are resolved concurrently")
+	assert.Assert(t, maxRunning.Load() <= imageDigestsResolveLimit)
+	assert.Equal(t, resolved.Services["same"].Image, "docker.io/library/a:latest@"+digest.FromString("docker.io/library/a:latest").String())
+	assert.Equal(t, resolved.Services["pinned"].Image, "docker.io/library/"+pinned)
+	assert.Equal(t, resolved.Services["build"].Image, "")
+}
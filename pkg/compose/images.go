@@ -92,6 +92,12 @@ func (s *composeService) Images(ctx context.Context, projectName string, options
 				}
 			}
 
+			// images built locally have no repo digest
+			var digest string
+			if len(image.RepoDigests) > 0 {
+				digest = image.RepoDigests[0]
+			}
+
 			var created *time.Time
 			if image.Created != "" {
 				t, err := time.Parse(time.RFC3339Nano, image.Created)
@@ -105,8 +111,10 @@ func (s *composeService) Images(ctx context.Context, projectName string, options
 			defer mux.Unlock()
 			summary[getCanonicalContainerName(c)] = api.ImageSummary{
 				ID:         id,
+				Service:    c.Labels[api.ServiceLabel],
 				Repository: repository,
 				Tag:        tag,
+				Digest:     digest,
 				Platform: platforms.Platform{
 					Architecture: image.Architecture,
 					OS:           image.Os,
@@ -0,0 +1,147 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/containerd/errdefs"
+	"github.com/distribution/reference"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+// ImagesLockFile is the name of the file recording the expected image digests, next to the Compose file
+const ImagesLockFile = "compose.lock"
+
+type imagesLockFile struct {
+	Images api.ImagesLock `yaml:"images"`
+}
+
+// LoadImagesLock reads the images lockfile at path. The returned error wraps fs.ErrNotExist if the file is missing
+func LoadImagesLock(path string) (api.ImagesLock, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lock imagesLockFile
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("invalid lockfile %s: %w", path, err)
+	}
+	if lock.Images == nil {
+		lock.Images = api.ImagesLock{}
+	}
+	return lock.Images, nil
+}
+
+// SaveImagesLock writes images digests to the lockfile at path, merged with the ones already locked
+func SaveImagesLock(path string, images api.ImagesLock) error {
+	lock, err := LoadImagesLock(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		lock = api.ImagesLock{}
+	} else if err != nil {
+		return err
+	}
+	for image, digest := range images {
+		lock[image] = digest
+	}
+	content, err := yaml.Marshal(imagesLockFile{Images: lock})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+// verifyImagesLock checks the local images used by services match the digests recorded in lock. Images built
+// locally are only verified if they are locked.
+func (s *composeService) verifyImagesLock(ctx context.Context, project *types.Project, lock api.ImagesLock) error {
+	var (
+		mismatches []api.ImageDigestMismatch
+		seen       = map[string]bool{}
+	)
+	for _, service := range project.Services {
+		if service.Image == "" || seen[service.Image] {
+			continue
+		}
+		seen[service.Image] = true
+		expected, locked := lock[service.Image]
+		if !locked && service.Build != nil {
+			continue
+		}
+		actual, err := s.localImageDigest(ctx, service.Image, expected)
+		if err != nil {
+			return err
+		}
+		if !locked || actual != expected {
+			mismatches = append(mismatches, api.ImageDigestMismatch{
+				Image:    service.Image,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	slices.SortFunc(mismatches, func(a, b api.ImageDigestMismatch) int {
+		return strings.Compare(a.Image, b.Image)
+	})
+	return &api.ImagesLockMismatchError{Mismatches: mismatches}
+}
+
+// localImageDigest returns the repository digest of the local image, preferring the expected one if the image
+// has been pulled under multiple digests
+func (s *composeService) localImageDigest(ctx context.Context, image string, expected string) (string, error) {
+	named, err := reference.ParseDockerRef(image)
+	if err != nil {
+		return "", err
+	}
+	inspect, err := s.apiClient().ImageInspect(ctx, image)
+	if errdefs.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var digests []string
+	for _, repoDigest := range inspect.RepoDigests {
+		ref, err := reference.ParseNormalizedNamed(repoDigest)
+		if err != nil {
+			continue
+		}
+		canonical, ok := ref.(reference.Canonical)
+		if !ok || canonical.Name() != named.Name() {
+			continue
+		}
+		digests = append(digests, canonical.Digest().String())
+	}
+	if len(digests) == 0 {
+		return "", nil
+	}
+	if slices.Contains(digests, expected) {
+		return expected, nil
+	}
+	return digests[0], nil
+}
@@ -0,0 +1,104 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestImagesLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ImagesLockFile)
+
+	_, err := LoadImagesLock(path)
+	assert.Assert(t, errors.Is(err, fs.ErrNotExist))
+
+	assert.NilError(t, SaveImagesLock(path, api.ImagesLock{
+		"nginx:latest":   "sha256:aaa",
+		"redis:7-alpine": "sha256:bbb",
+	}))
+	assert.NilError(t, SaveImagesLock(path, api.ImagesLock{
+		"nginx:latest": "sha256:ccc",
+	}))
+
+	lock, err := LoadImagesLock(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lock, api.ImagesLock{
+		"nginx:latest":   "sha256:ccc",
+		"redis:7-alpine": "sha256:bbb",
+	})
+}
+
+func TestVerifyImagesLock(t *testing.T) {
+	var (
+		digestOld = "sha256:" + strings.Repeat("0", 64)
+		digestA   = "sha256:" + strings.Repeat("a", 64)
+		digestB   = "sha256:" + strings.Repeat("b", 64)
+		digestD   = "sha256:" + strings.Repeat("d", 64)
+	)
+	mockCtrl := gomock.NewController(t)
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	apiClient.EXPECT().ImageInspect(gomock.Any(), "nginx:latest").Return(client.ImageInspectResult{
+		InspectResponse: image.InspectResponse{RepoDigests: []string{"nginx@" + digestOld, "nginx@" + digestA}},
+	}, nil)
+	apiClient.EXPECT().ImageInspect(gomock.Any(), "redis:7-alpine").Return(client.ImageInspectResult{
+		InspectResponse: image.InspectResponse{RepoDigests: []string{"redis@" + digestD}},
+	}, nil)
+	apiClient.EXPECT().ImageInspect(gomock.Any(), "postgres:16").Return(client.ImageInspectResult{}, errdefs.ErrNotFound)
+
+	project := &types.Project{
+		Name: "test",
+		Services: types.Services{
+			"web":   {Name: "web", Image: "nginx:latest"},
+			"cache": {Name: "cache", Image: "redis:7-alpine"},
+			"db":    {Name: "db", Image: "postgres:16"},
+			"app":   {Name: "app", Image: "test-app", Build: &types.BuildConfig{Context: "."}},
+		},
+	}
+	err = tested.(*composeService).verifyImagesLock(t.Context(), project, api.ImagesLock{
+		"nginx:latest":   digestA,
+		"redis:7-alpine": digestB,
+	})
+	var mismatch *api.ImagesLockMismatchError
+	assert.Assert(t, errors.As(err, &mismatch))
+	assert.DeepEqual(t, mismatch.Mismatches, []api.ImageDigestMismatch{
+		{Image: "postgres:16"},
+		{Image: "redis:7-alpine", Expected: digestB, Actual: digestD},
+	})
+	assert.Error(t, err, `images do not match the locked digests:
+  postgres:16
+    - <not locked> (expected)
+    + <no digest> (actual)
+  redis:7-alpine
+    - `+digestB+` (expected)
+    + `+digestD+` (actual)`)
+}
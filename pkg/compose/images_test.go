@@ -48,6 +48,7 @@ func TestImages(t *testing.T) {
 	timeStr2 := "2025-03-03T03:03:03.000000000Z"
 	created2, _ := time.Parse(time.RFC3339Nano, timeStr2)
 	image1 := imageInspect("image1", "foo:1", 12345, timeStr1)
+	image1.RepoDigests = []string{"foo@sha256:e8f4d0b5a1a4c3b1f2a2e8b3c0d0c8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1"}
 	image2 := imageInspect("image2", "bar:2", 67890, timeStr2)
 	api.EXPECT().ImageInspect(anyCancellableContext(), "foo:1").Return(client.ImageInspectResult{InspectResponse: image1}, nil).MaxTimes(2)
 	api.EXPECT().ImageInspect(anyCancellableContext(), "bar:2").Return(client.ImageInspectResult{InspectResponse: image2}, nil)
@@ -64,13 +65,16 @@ func TestImages(t *testing.T) {
 	expected := map[string]compose.ImageSummary{
 		"123": {
 			ID:         "image1",
+			Service:    "service1",
 			Repository: "foo",
 			Tag:        "1",
+			Digest:     "foo@sha256:e8f4d0b5a1a4c3b1f2a2e8b3c0d0c8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1",
 			Size:       12345,
 			Created:    &created1,
 		},
 		"456": {
 			ID:         "image2",
+			Service:    "service1",
 			Repository: "bar",
 			Tag:        "2",
 			Size:       67890,
@@ -78,8 +82,10 @@ func TestImages(t *testing.T) {
 		},
 		"789": {
 			ID:         "image1",
+			Service:    "service2",
 			Repository: "foo",
 			Tag:        "1",
+			Digest:     "foo@sha256:e8f4d0b5a1a4c3b1f2a2e8b3c0d0c8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1",
 			Size:       12345,
 			Created:    &created1,
 		},
@@ -18,7 +18,9 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/client"
@@ -26,6 +28,9 @@ import (
 	"github.com/docker/compose/v5/pkg/api"
 )
 
+// killRemoveTimeout is the maximum delay to wait for killed containers to exit before they're removed
+var killRemoveTimeout = 10 * time.Second
+
 func (s *composeService) Kill(ctx context.Context, projectName string, options api.KillOptions) error {
 	return Run(ctx, func(ctx context.Context) error {
 		return s.kill(ctx, strings.ToLower(projectName), options)
@@ -36,9 +41,21 @@ func (s *composeService) kill(ctx context.Context, projectName string, options a
 	services := options.Services
 
 	var containers Containers
-	containers, err := s.getContainers(ctx, projectName, oneOffInclude, options.All, services...)
-	if err != nil {
-		return err
+	var err error
+	if options.Index > 0 {
+		if len(services) != 1 {
+			return errors.New("--index requires a service")
+		}
+		ctr, err := s.getSpecifiedContainer(ctx, projectName, oneOffExclude, options.All, services[0], options.Index)
+		if err != nil {
+			return err
+		}
+		containers = append(containers, ctr)
+	} else {
+		containers, err = s.getContainers(ctx, projectName, oneOffInclude, options.All, services...)
+		if err != nil {
+			return err
+		}
 	}
 
 	project := options.Project
@@ -56,7 +73,7 @@ func (s *composeService) kill(ctx context.Context, projectName string, options a
 		return api.ErrNoResources
 	}
 
-	return forEachContainerConcurrent(ctx, containers, func(ctx context.Context, ctr container.Summary) error {
+	err = forEachContainerConcurrent(ctx, containers, func(ctx context.Context, ctr container.Summary) error {
 		eventName := getContainerProgressName(ctr)
 		s.events.On(newEvent(eventName, api.Working, api.StatusKilling))
 		_, err := s.apiClient().ContainerKill(ctx, ctr.ID, client.ContainerKillOptions{
@@ -67,6 +84,33 @@ func (s *composeService) kill(ctx context.Context, projectName string, options a
 			return err
 		}
 		s.events.On(newEvent(eventName, api.Done, api.StatusKilled))
-		return nil
+		if !options.Remove {
+			return nil
+		}
+		// signal might not terminate the container immediately, wait for it to exit before removal. As it might
+		// also be handled without exiting (e.g. SIGHUP), only wait for killRemoveTimeout, removal being forced
+		waitCtx, cancel := context.WithTimeout(ctx, killRemoveTimeout)
+		defer cancel()
+		res := s.apiClient().ContainerWait(waitCtx, ctr.ID, client.ContainerWaitOptions{
+			Condition: container.WaitConditionNotRunning,
+		})
+		select {
+		case <-res.Result:
+			return nil
+		case <-waitCtx.Done():
+			return ctx.Err()
+		case err := <-res.Error:
+			if ctx.Err() == nil && waitCtx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	})
+	if err != nil || !options.Remove {
+		return err
+	}
+	return s.remove(ctx, containers, api.RemoveOptions{
+		Volumes: options.RemoveVolumes,
+		Force:   true,
 	})
 }
@@ -21,7 +21,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/client"
@@ -154,3 +156,145 @@ func projectFilterListOpt(withOneOff bool) client.ContainerListOptions {
 		All:     true,
 	}
 }
+
+func TestKillRemove(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	project := &types.Project{
+		Name:     name,
+		Services: types.Services{"service1": {Name: "service1"}},
+	}
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: projectFilter(name).Add("label", compose.ConfigHashLabel),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{
+			testContainer("service1", "123", false),
+			testContainer("service1", "456", false),
+		},
+	}, nil)
+	for _, id := range []string{"123", "456"} {
+		kill := api.EXPECT().ContainerKill(anyCancellableContext(), id, client.ContainerKillOptions{Signal: "SIGTERM"}).Return(client.ContainerKillResult{}, nil)
+		wait := api.EXPECT().ContainerWait(gomock.Any(), id, client.ContainerWaitOptions{Condition: container.WaitConditionNotRunning}).
+			Return(waitResultExit(143)).After(kill)
+		api.EXPECT().ContainerRemove(gomock.Any(), id, client.ContainerRemoveOptions{RemoveVolumes: true, Force: true}).
+			Return(client.ContainerRemoveResult{}, nil).After(wait)
+	}
+
+	err = tested.Kill(t.Context(), name, compose.KillOptions{
+		Project:       project,
+		Signal:        "SIGTERM",
+		Remove:        true,
+		RemoveVolumes: true,
+	})
+	assert.NilError(t, err)
+}
+
+func TestKillRemoveSignalIgnored(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	defer func(timeout time.Duration) { killRemoveTimeout = timeout }(killRemoveTimeout)
+	killRemoveTimeout = 10 * time.Millisecond
+
+	name := strings.ToLower(testProject)
+	project := &types.Project{
+		Name:     name,
+		Services: types.Services{"service1": {Name: "service1"}},
+	}
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: projectFilter(name).Add("label", compose.ConfigHashLabel),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{testContainer("service1", "123", false)},
+	}, nil)
+	kill := api.EXPECT().ContainerKill(anyCancellableContext(), "123", client.ContainerKillOptions{Signal: "SIGHUP"}).Return(client.ContainerKillResult{}, nil)
+	// container keeps running, wait never completes
+	wait := api.EXPECT().ContainerWait(gomock.Any(), "123", client.ContainerWaitOptions{Condition: container.WaitConditionNotRunning}).
+		Return(client.ContainerWaitResult{Result: make(chan container.WaitResponse), Error: make(chan error)}).After(kill)
+	api.EXPECT().ContainerRemove(gomock.Any(), "123", client.ContainerRemoveOptions{Force: true}).
+		Return(client.ContainerRemoveResult{}, nil).After(wait)
+
+	err = tested.Kill(t.Context(), name, compose.KillOptions{
+		Project: project,
+		Signal:  "SIGHUP",
+		Remove:  true,
+	})
+	assert.NilError(t, err)
+}
+
+func TestKillIndex(t *testing.T) {
+	const serviceName = "service1"
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	replica := testContainer(serviceName, "456", false)
+	replica.Labels[compose.ContainerNumberLabel] = "2"
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude, serviceName).Add("label", containerNumberFilter(2)),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{replica},
+	}, nil)
+	api.EXPECT().VolumeList(gomock.Any(), gomock.Any()).Return(client.VolumeListResult{}, nil)
+	api.EXPECT().NetworkList(gomock.Any(), gomock.Any()).Return(client.NetworkListResult{}, nil)
+	api.EXPECT().ContainerKill(anyCancellableContext(), "456", client.ContainerKillOptions{}).Return(client.ContainerKillResult{}, nil)
+
+	err = tested.Kill(t.Context(), name, compose.KillOptions{Services: []string{serviceName}, Index: 2})
+	assert.NilError(t, err)
+}
+
+func TestKillMissingIndex(t *testing.T) {
+	const serviceName = "service1"
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	first := testContainer(serviceName, "123", false)
+	first.Labels[compose.ContainerNumberLabel] = "1"
+	second := testContainer(serviceName, "456", false)
+	second.Labels[compose.ContainerNumberLabel] = "2"
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude, serviceName).Add("label", containerNumberFilter(3)),
+	}).Return(client.ContainerListResult{}, nil)
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude, serviceName),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{second, first},
+	}, nil)
+
+	err = tested.Kill(t.Context(), name, compose.KillOptions{Services: []string{serviceName}, Index: 3})
+	assert.Error(t, err, `service "service1" is not running container #3, available indices: 1, 2`)
+}
+
+func TestKillIndexRequiresService(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	_, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	err = tested.Kill(t.Context(), testProject, compose.KillOptions{Index: 2})
+	assert.Error(t, err, "--index requires a service")
+}
@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/consts"
 	"github.com/compose-spec/compose-go/v2/loader"
 	"github.com/compose-spec/compose-go/v2/types"
 
@@ -49,6 +51,18 @@ func (s *composeService) LoadProject(ctx context.Context, options api.ProjectLoa
 		}
 	}
 
+	// env_file entries using a registered scheme must be resolved before the service environment
+	resolveEnvironment := false
+	if len(s.envFileResolvers) > 0 {
+		err = cli.WithLoadOptions(func(o *loader.Options) {
+			resolveEnvironment = !o.SkipResolveEnvironment
+			o.SkipResolveEnvironment = true
+		})(projectOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if options.Compatibility || utils.StringToBool(projectOptions.Environment[api.ComposeCompatibility]) {
 		api.Separator = "_"
 	}
@@ -58,6 +72,15 @@ func (s *composeService) LoadProject(ctx context.Context, options api.ProjectLoa
 		return nil, err
 	}
 
+	if resolveEnvironment {
+		project, err = s.withServicesEnvironmentResolved(ctx, project)
+	} else if len(s.envFileResolvers) > 0 {
+		project, err = s.resolveEnvFileReferences(ctx, project)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	// Post-processing: service selection, environment resolution, etc.
 	project, err = s.postProcessProject(project, options)
 	if err != nil {
@@ -67,14 +90,15 @@ func (s *composeService) LoadProject(ctx context.Context, options api.ProjectLoa
 	return project, nil
 }
 
-// createRemoteLoaders creates Git and OCI remote loaders if not in offline mode
+// createRemoteLoaders creates Git, OCI and HTTP remote loaders if not in offline mode
 func (s *composeService) createRemoteLoaders(options api.ProjectLoadOptions) []loader.ResourceLoader {
 	if options.Offline {
 		return nil
 	}
 	git := remote.NewGitRemoteLoader(s.dockerCli, options.Offline)
 	oci := remote.NewOCIRemoteLoader(s.dockerCli, options.Offline, options.OCI)
-	return []loader.ResourceLoader{git, oci}
+	http := remote.NewHTTPRemoteLoader(options.Offline)
+	return []loader.ResourceLoader{git, oci, http}
 }
 
 // buildProjectOptions constructs compose-go ProjectOptions from API options
@@ -109,13 +133,27 @@ func (s *composeService) buildProjectOptions(options api.ProjectLoadOptions, rem
 		cli.WithEnvFiles(options.EnvFiles...), //nolint:gocritic // intentionally applying cli.WithEnvFiles twice.
 		cli.WithDotEnv,                        //nolint:gocritic // intentionally applying cli.WithDotEnv twice.
 		// eventually COMPOSE_PROFILES should have been set
-		cli.WithDefaultProfiles(options.Profiles...),
+		WithMergedProfiles(options.Profiles...),
 		cli.WithName(options.ProjectName),
 	)
 
 	return cli.NewProjectOptions(options.ConfigPaths, append(options.ProjectOptionsFns, opts...)...)
 }
 
+// WithMergedProfiles activates profiles along with those set by COMPOSE_PROFILES in the project
+// environment, so the environment variable doesn't need to be repeated as flags
+func WithMergedProfiles(profiles ...string) cli.ProjectOptionsFn {
+	return func(o *cli.ProjectOptions) error {
+		merged := slices.Clone(profiles)
+		for profile := range strings.SplitSeq(o.Environment[consts.ComposeProfiles], ",") {
+			if profile = strings.TrimSpace(profile); profile != "" && !slices.Contains(merged, profile) {
+				merged = append(merged, profile)
+			}
+		}
+		return cli.WithProfiles(merged)(o)
+	}
+}
+
 // postProcessProject applies post-loading transformations to the project
 func (s *composeService) postProcessProject(project *types.Project, options api.ProjectLoadOptions) (*types.Project, error) {
 	if project.Name == "" {
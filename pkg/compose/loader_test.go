@@ -17,6 +17,8 @@
 package compose
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -189,6 +191,38 @@ services:
 	})
 }
 
+func TestLoadProject_WithProfilesFromEnvironment(t *testing.T) {
+	tmpDir := t.TempDir()
+	composeFile := filepath.Join(tmpDir, "compose.yaml")
+	composeContent := `
+services:
+  web:
+    image: nginx:latest
+  debug:
+    image: busybox:latest
+    profiles: ["debug"]
+  dev:
+    image: busybox:latest
+    profiles: ["dev"]
+  test:
+    image: busybox:latest
+    profiles: ["test"]
+`
+	err := os.WriteFile(composeFile, []byte(composeContent), 0o644)
+	assert.NilError(t, err)
+	t.Setenv("COMPOSE_PROFILES", "dev, debug")
+
+	service, err := NewComposeService(nil)
+	assert.NilError(t, err)
+
+	project, err := service.LoadProject(t.Context(), api.ProjectLoadOptions{
+		ConfigPaths: []string{composeFile},
+		Profiles:    []string{"debug", "test"},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, project.ServiceNames(), []string{"debug", "dev", "test", "web"})
+}
+
 func TestLoadProject_WithLoadListeners(t *testing.T) {
 	tmpDir := t.TempDir()
 	composeFile := filepath.Join(tmpDir, "compose.yaml")
@@ -319,3 +353,129 @@ func TestLoadProject_MissingComposeFile(t *testing.T) {
 	assert.Assert(t, err != nil)
 	assert.Assert(t, project == nil)
 }
+
+type fakeEnvFileResolver struct {
+	values map[string][]string
+	calls  int
+}
+
+func (r *fakeEnvFileResolver) Resolve(_ context.Context, reference string) ([]string, error) {
+	r.calls++
+	values, ok := r.values[reference]
+	if !ok {
+		return nil, fmt.Errorf("no such secret %s", reference)
+	}
+	return values, nil
+}
+
+func TestEnvFileReference(t *testing.T) {
+	vault, db := &fakeEnvFileResolver{}, &fakeEnvFileResolver{}
+	s := &composeService{envFileResolvers: map[string]api.EnvFileResolver{"vault": vault, "db": db}}
+
+	for path, expected := range map[string]string{
+		"vault://app/web":                "vault://app/web",
+		"/project/vault:/app/web":        "vault://app/web",
+		"/project/db:/main":              "db://main",
+		"/project/mydb:/main":            "",
+		"/project/local.env":             "",
+		"C:/project/vault:/app/web":      "vault://app/web",
+		"/project/vault.d/db:/app/web":   "db://app/web",
+		"/project/db:/vault:/nested/ref": "db://vault:/nested/ref",
+	} {
+		ref, _, ok := s.envFileReference(path)
+		assert.Equal(t, ok, expected != "", path)
+		assert.Equal(t, ref, expected, path)
+	}
+}
+
+func TestLoadProject_WithEnvFileResolver(t *testing.T) {
+	tmpDir := t.TempDir()
+	composeFile := filepath.Join(tmpDir, "compose.yaml")
+	composeContent := `
+name: test-project
+services:
+  web:
+    image: nginx:latest
+    env_file:
+      - vault://app/web
+      - local.env
+  worker:
+    image: nginx:latest
+    env_file:
+      - vault://app/web
+      - path: vault://app/optional
+        required: false
+    environment:
+      TOKEN: overridden
+`
+	assert.NilError(t, os.WriteFile(composeFile, []byte(composeContent), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(tmpDir, "local.env"), []byte("URL=https://${HOST}/\n"), 0o644))
+
+	resolver := &fakeEnvFileResolver{values: map[string][]string{
+		"vault://app/web": {"TOKEN=s3cr3t", "HOST=example.com"},
+	}}
+	service, err := NewComposeService(nil, WithEnvFileResolver("vault", resolver))
+	assert.NilError(t, err)
+
+	project, err := service.LoadProject(t.Context(), api.ProjectLoadOptions{
+		ConfigPaths: []string{composeFile},
+	})
+	assert.NilError(t, err)
+	// vault://app/web is shared by services but resolved once, vault://app/optional is missing
+	assert.Equal(t, resolver.calls, 2)
+
+	web := project.Services["web"]
+	assert.Equal(t, *web.Environment["TOKEN"], "s3cr3t")
+	assert.Equal(t, *web.Environment["URL"], "https://example.com/")
+	assert.Equal(t, len(web.EnvFiles), 1)
+	assert.Equal(t, web.EnvFiles[0].Path, filepath.Join(tmpDir, "local.env"))
+
+	worker := project.Services["worker"]
+	assert.Equal(t, *worker.Environment["TOKEN"], "overridden")
+	assert.Equal(t, *worker.Environment["HOST"], "example.com")
+	assert.Equal(t, len(worker.EnvFiles), 0)
+
+	t.Run("env files precedence", func(t *testing.T) {
+		assert.NilError(t, os.WriteFile(filepath.Join(tmpDir, "token.env"), []byte("TOKEN=local\n"), 0o644))
+		override := filepath.Join(tmpDir, "compose.override.yaml")
+		assert.NilError(t, os.WriteFile(override, []byte(`
+services:
+  web:
+    env_file: !override
+      - vault://app/web
+      - token.env
+  worker:
+    environment: !reset {}
+    env_file: !override
+      - token.env
+      - vault://app/web
+`), 0o644))
+		project, err := service.LoadProject(t.Context(), api.ProjectLoadOptions{
+			ConfigPaths: []string{composeFile, override},
+		})
+		assert.NilError(t, err)
+		// like local env files, the last one declared wins
+		assert.Equal(t, *project.Services["web"].Environment["TOKEN"], "local")
+		assert.Equal(t, *project.Services["worker"].Environment["TOKEN"], "s3cr3t")
+	})
+
+	t.Run("without environment resolution", func(t *testing.T) {
+		project, err := service.LoadProject(t.Context(), api.ProjectLoadOptions{
+			ConfigPaths:       []string{composeFile},
+			ProjectOptionsFns: []cli.ProjectOptionsFn{cli.WithoutEnvironmentResolution},
+		})
+		assert.NilError(t, err)
+		web := project.Services["web"]
+		assert.Equal(t, *web.Environment["TOKEN"], "s3cr3t")
+		assert.Check(t, is.Nil(web.Environment["URL"]))
+		assert.Equal(t, len(web.EnvFiles), 1)
+	})
+
+	t.Run("required reference fails", func(t *testing.T) {
+		resolver.values = nil
+		_, err := service.LoadProject(t.Context(), api.ProjectLoadOptions{
+			ConfigPaths: []string{composeFile},
+		})
+		assert.ErrorContains(t, err, `failed to resolve env_file vault://app/web`)
+	})
+}
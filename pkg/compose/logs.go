@@ -18,7 +18,11 @@ package compose
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/containerd/errdefs"
 	"github.com/moby/moby/api/pkg/stdcopy"
@@ -37,8 +41,14 @@ func (s *composeService) Logs(
 	consumer api.LogConsumer,
 	options api.LogOptions,
 ) error {
+	since, until, err := parseLogTimeRange(options.Since, options.Until, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	// relative values are resolved once, so all services are filtered by the same point in time
+	options.Since, options.Until = formatLogTime(since), formatLogTime(until)
+
 	var containers Containers
-	var err error
 
 	if options.Index > 0 {
 		ctr, err := s.getSpecifiedContainer(ctx, projectName, oneOffExclude, true, options.Services[0], options.Index)
@@ -59,6 +69,14 @@ func (s *composeService) Logs(
 		containers = containers.filter(isService(options.Services...))
 	}
 
+	if options.Index == 0 {
+		// provider services have no container, report messages persisted while running the provider
+		filter := providerLogFilter{since: since, until: until, timestamps: options.Timestamps}
+		if err := logProviders(consumer, s.providerKey(projectName, options.Project), options.Services, filter); err != nil {
+			return err
+		}
+	}
+
 	eg, ctx := errgroup.WithContext(ctx)
 	for _, ctr := range containers {
 		eg.Go(func() error {
@@ -123,6 +141,7 @@ func (s *composeService) logContainer(ctx context.Context, consumer api.LogConsu
 }
 
 func (s *composeService) doLogContainer(ctx context.Context, consumer api.LogConsumer, name string, ctr container.InspectResponse, options api.LogOptions) error {
+	records, structured := consumer.(api.LogRecordConsumer)
 	r, err := s.apiClient().ContainerLogs(ctx, ctr.ID, client.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
@@ -130,20 +149,117 @@ func (s *composeService) doLogContainer(ctx context.Context, consumer api.LogCon
 		Since:      options.Since,
 		Until:      options.Until,
 		Tail:       options.Tail,
-		Timestamps: options.Timestamps,
+		// timestamps are required to populate LogRecord
+		Timestamps: options.Timestamps || structured,
 	})
 	if err != nil {
 		return err
 	}
 	defer r.Close() //nolint:errcheck
 
-	w := utils.GetWriter(func(line string) {
+	stdout := utils.GetWriter(func(line string) {
 		consumer.Log(name, line)
 	})
+	stderr := stdout
+	if structured {
+		stdout = utils.GetWriter(logRecordWriter(records, name, ctr, api.StdoutStream))
+		stderr = utils.GetWriter(logRecordWriter(records, name, ctr, api.StderrStream))
+	}
 	if ctr.Config.Tty {
-		_, err = io.Copy(w, r)
+		_, err = io.Copy(stdout, r)
 	} else {
-		_, err = stdcopy.StdCopy(w, w, r)
+		_, err = stdcopy.StdCopy(stdout, stderr, r)
 	}
 	return err
 }
+
+// logRecordWriter returns a function sending log lines written by container to stream as LogRecord.
+// Lines are expected to be prefixed by the timestamp set by the engine.
+func logRecordWriter(consumer api.LogRecordConsumer, name string, ctr container.InspectResponse, stream string) func(string) {
+	return func(line string) {
+		record := api.LogRecord{
+			Service:     ctr.Config.Labels[api.ServiceLabel],
+			Container:   name,
+			ContainerID: ctr.ID,
+			Stream:      stream,
+			Message:     line,
+		}
+		if timestamp, message, ok := strings.Cut(line, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+				record.Timestamp, record.Message = t, message
+			}
+		}
+		consumer.LogRecord(record)
+	}
+}
+
+// logTimeLayouts are the layouts accepted for an absolute `since` or `until` time, as supported by
+// `docker logs`. Layouts without a timezone are interpreted in local time.
+var logTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02T15",
+	"2006-01-02Z07:00",
+	"2006-01-02",
+}
+
+// parseLogTime parses a `since` or `until` value, which can be a Unix timestamp, a duration relative
+// to now (e.g. 42m) or a timestamp (e.g. 2013-01-02T13:23:37Z). A bare number is a Unix timestamp, as
+// `time.ParseDuration` would accept 0 as a duration.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, ok := parseUnixTimestamp(value); ok {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range logTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time value %q: must be a duration, a timestamp or a Unix timestamp", value)
+}
+
+// parseUnixTimestamp parses a Unix timestamp, in seconds with an optional fraction
+func parseUnixTimestamp(value string) (time.Time, bool) {
+	seconds, fraction, _ := strings.Cut(value, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var nsec int64
+	if fraction != "" {
+		fraction = (fraction + "000000000")[:9]
+		if nsec, err = strconv.ParseInt(fraction, 10, 64); err != nil {
+			return time.Time{}, false
+		}
+	}
+	return time.Unix(sec, nsec), true
+}
+
+func parseLogTimeRange(sinceValue, untilValue string, now time.Time) (time.Time, time.Time, error) {
+	since, err := parseLogTime(sinceValue, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid since: %w", err)
+	}
+	until, err := parseLogTime(untilValue, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid until: %w", err)
+	}
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		return time.Time{}, time.Time{}, fmt.Errorf("until (%s) must not be before since (%s)", formatLogTime(until), formatLogTime(since))
+	}
+	return since, until, nil
+}
+
+func formatLogTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
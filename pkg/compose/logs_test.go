@@ -24,6 +24,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/moby/moby/api/pkg/stdcopy"
@@ -86,6 +87,47 @@ func newStdWriter(w io.Writer, streamType stdcopy.StdType) io.Writer {
 	}
 }
 
+func TestParseLogTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{value: "", expected: time.Time{}},
+		{value: "15m", expected: now.Add(-15 * time.Minute)},
+		{value: "2h", expected: now.Add(-2 * time.Hour)},
+		{value: "2013-01-02T13:23:37Z", expected: time.Date(2013, 1, 2, 13, 23, 37, 0, time.UTC)},
+		{value: "2013-01-02T13:23:37.5+01:00", expected: time.Date(2013, 1, 2, 12, 23, 37, 500000000, time.UTC)},
+		{value: "2013-01-02T13:23:37", expected: time.Date(2013, 1, 2, 13, 23, 37, 0, time.Local)},
+		{value: "2013-01-02", expected: time.Date(2013, 1, 2, 0, 0, 0, 0, time.Local)},
+		{value: "1357133017", expected: time.Unix(1357133017, 0)},
+		{value: "1357133017.25", expected: time.Unix(1357133017, 250000000)},
+		{value: "0", expected: time.Unix(0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			actual, err := parseLogTime(tt.value, now)
+			assert.NilError(t, err)
+			assert.Assert(t, actual.Equal(tt.expected), "expected %s, got %s", tt.expected, actual)
+		})
+	}
+
+	_, err := parseLogTime("yesterday", now)
+	assert.ErrorContains(t, err, `invalid time value "yesterday"`)
+}
+
+func TestParseLogTimeRange(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	since, until, err := parseLogTimeRange("1h", "10m", now)
+	assert.NilError(t, err)
+	assert.Equal(t, formatLogTime(since), "2024-01-01T11:00:00Z")
+	assert.Equal(t, formatLogTime(until), "2024-01-01T11:50:00Z")
+
+	_, _, err = parseLogTimeRange("10m", "2023-12-31T00:00:00Z", now)
+	assert.Error(t, err, "until (2023-12-31T00:00:00Z) must not be before since (2024-01-01T11:50:00Z)")
+}
+
 func TestComposeService_Logs_Demux(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -147,6 +189,69 @@ func TestComposeService_Logs_Demux(t *testing.T) {
 	assert.DeepEqual(t, []string{"hello stdout", "hello stderr"}, consumer.LogsForContainer("c"))
 }
 
+func TestComposeService_Logs_Records(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+
+	api.EXPECT().ContainerList(t.Context(), gomock.Any()).Return(
+		client.ContainerListResult{
+			Items: []containerType.Summary{
+				testContainer("service", "c", false),
+			},
+		},
+		nil,
+	)
+	api.EXPECT().
+		ContainerInspect(anyCancellableContext(), "c", gomock.Any()).
+		Return(client.ContainerInspectResult{
+			Container: containerType.InspectResponse{
+				ID: "c",
+				Config: &containerType.Config{
+					Tty:    false,
+					Labels: map[string]string{compose.ServiceLabel: "service"},
+				},
+			},
+		}, nil)
+
+	var logs bytes.Buffer
+	_, err = newStdWriter(&logs, stdcopy.Stdout).Write([]byte("2024-01-02T13:23:37.123456789Z hello stdout\n"))
+	assert.NilError(t, err)
+	_, err = newStdWriter(&logs, stdcopy.Stderr).Write([]byte("2024-01-02T13:23:38Z hello stderr\n"))
+	assert.NilError(t, err)
+	// timestamps are requested from the engine even when not displayed
+	api.EXPECT().ContainerLogs(anyCancellableContext(), "c", gomock.Cond(func(opts client.ContainerLogsOptions) bool {
+		return opts.Timestamps
+	})).Return(io.NopCloser(&logs), nil)
+
+	consumer := &testLogRecordConsumer{}
+	err = tested.Logs(t.Context(), name, consumer, compose.LogOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, consumer.records, []compose.LogRecord{
+		{
+			Service:     "service",
+			Container:   "c",
+			ContainerID: "c",
+			Timestamp:   time.Date(2024, 1, 2, 13, 23, 37, 123456789, time.UTC),
+			Stream:      compose.StdoutStream,
+			Message:     "hello stdout",
+		},
+		{
+			Service:     "service",
+			Container:   "c",
+			ContainerID: "c",
+			Timestamp:   time.Date(2024, 1, 2, 13, 23, 38, 0, time.UTC),
+			Stream:      compose.StderrStream,
+			Message:     "hello stderr",
+		},
+	})
+}
+
 // TestComposeService_Logs_ServiceFiltering ensures that we do not include
 // logs from out-of-scope services based on the Compose file vs actual state.
 //
@@ -245,3 +350,14 @@ func (l *testLogConsumer) LogsForContainer(containerName string) []string {
 	defer l.mu.Unlock()
 	return l.logs[containerName]
 }
+
+type testLogRecordConsumer struct {
+	testLogConsumer
+	records []compose.LogRecord
+}
+
+func (l *testLogRecordConsumer) LogRecord(record compose.LogRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, record)
+}
@@ -19,6 +19,7 @@ package compose
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"sort"
 	"strings"
@@ -55,11 +56,15 @@ func containersToStacks(containers []container.Summary) ([]api.Stack, error) {
 			configFiles = "N/A"
 		}
 
+		states := containerToState(containersByLabel[project])
 		projects = append(projects, api.Stack{
 			ID:          project,
 			Name:        project,
-			Status:      combinedStatus(containerToState(containersByLabel[project])),
+			Status:      combinedStatus(states),
 			ConfigFiles: configFiles,
+			Running:     countRunning(states),
+			States:      slices.Compact(slices.Sorted(slices.Values(states))),
+			Labels:      commonLabels(containersByLabel[project]),
 		})
 	}
 	return projects, nil
@@ -84,6 +89,31 @@ func combinedConfigFiles(containers []container.Summary) (string, error) {
 	return strings.Join(configFiles, ","), nil
 }
 
+// commonLabels returns the labels set with the same value on all containers
+func commonLabels(containers []container.Summary) map[string]string {
+	if len(containers) == 0 {
+		return nil
+	}
+	labels := maps.Clone(containers[0].Labels)
+	for _, c := range containers[1:] {
+		maps.DeleteFunc(labels, func(key, value string) bool {
+			v, ok := c.Labels[key]
+			return !ok || v != value
+		})
+	}
+	return labels
+}
+
+func countRunning(states []string) int {
+	running := 0
+	for _, state := range states {
+		if state == string(container.StateRunning) {
+			running++
+		}
+	}
+	return running
+}
+
 func containerToState(containers []container.Summary) []string {
 	statuses := []string{}
 	for _, c := range containers {
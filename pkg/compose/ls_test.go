@@ -18,9 +18,12 @@ package compose
 
 import (
 	"fmt"
+	"maps"
 	"testing"
 
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
 	"gotest.tools/v3/assert"
 
 	"github.com/docker/compose/v5/pkg/api"
@@ -52,16 +55,44 @@ func TestContainersToStacks(t *testing.T) {
 			Name:        "project1",
 			Status:      "running(2)",
 			ConfigFiles: "/home/docker-compose.yaml",
+			Running:     2,
+			States:      []string{"running"},
+			Labels:      map[string]string{api.ProjectLabel: "project1", api.ConfigFilesLabel: "/home/docker-compose.yaml"},
 		},
 		{
 			ID:          "project2",
 			Name:        "project2",
 			Status:      "running(1)",
 			ConfigFiles: "/home/project2-docker-compose.yaml",
+			Running:     1,
+			States:      []string{"running"},
+			Labels:      map[string]string{api.ProjectLabel: "project2", api.ConfigFilesLabel: "/home/project2-docker-compose.yaml"},
 		},
 	})
 }
 
+func TestContainersToStacksStatesAndLabels(t *testing.T) {
+	labels := func(extra map[string]string) map[string]string {
+		l := map[string]string{api.ProjectLabel: "project1", api.ConfigFilesLabel: "/home/docker-compose.yaml"}
+		maps.Copy(l, extra)
+		return l
+	}
+	containers := []container.Summary{
+		{ID: "service1", State: "running", Labels: labels(map[string]string{"env": "prod", "team": "web"})},
+		{ID: "service2", State: "exited", Labels: labels(map[string]string{"env": "prod", "team": "db"})},
+		{ID: "service3", State: "exited", Labels: labels(map[string]string{"env": "prod"})},
+	}
+	stacks, err := containersToStacks(containers)
+	assert.NilError(t, err)
+	assert.Equal(t, len(stacks), 1)
+	assert.Equal(t, stacks[0].Status, "exited(2), running(1)")
+	assert.Equal(t, stacks[0].Running, 1)
+	// states used by the status filter are deduplicated
+	assert.DeepEqual(t, stacks[0].States, []string{"exited", "running"})
+	// only labels set with the same value on all containers are used by the label filter
+	assert.DeepEqual(t, stacks[0].Labels, labels(map[string]string{"env": "prod"}))
+}
+
 func TestStacksMixedStatus(t *testing.T) {
 	assert.Equal(t, combinedStatus([]string{"running"}), "running(1)")
 	assert.Equal(t, combinedStatus([]string{"running", "running", "running"}), "running(3)")
@@ -120,3 +151,33 @@ func TestCombinedConfigFiles(t *testing.T) {
 		assert.Equal(t, configFiles, expected.ConfigFiles)
 	}
 }
+
+func TestListAll(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	stopped := func(id, project string) container.Summary {
+		return container.Summary{
+			ID:     id,
+			State:  container.StateExited,
+			Labels: map[string]string{api.ProjectLabel: project, api.ConfigFilesLabel: "/home/api.yaml"},
+		}
+	}
+	apiClient.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: make(client.Filters).Add("label", api.ProjectLabel).Add("label", api.ConfigHashLabel),
+		All:     true,
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{stopped("123", "stopped"), stopped("456", "stopped")},
+	}, nil)
+
+	stacks, err := tested.List(t.Context(), api.ListOptions{All: true})
+	assert.NilError(t, err)
+	assert.Equal(t, len(stacks), 1)
+	assert.Equal(t, stacks[0].Name, "stopped")
+	assert.Equal(t, stacks[0].Status, "exited(2)")
+	assert.Equal(t, stacks[0].Running, 0)
+}
@@ -50,6 +50,10 @@ type ObservedContainer struct {
 	ImageDigest string                   // label com.docker.compose.image
 	Number      int                      // label com.docker.compose.container-number
 
+	// OrphanReason explains why a container listed in ObservedState.Orphans is
+	// considered orphaned.
+	OrphanReason string
+
 	// ConnectedNetworks maps network IDs found in the container's network
 	// settings. Key is the network name as seen by Docker, value is the
 	// network ID.
@@ -106,12 +110,15 @@ func (s *composeService) collectObservedState(ctx context.Context, project *type
 		knownServices[ds.Name] = true
 	}
 
+	services := append(project.ServiceNames(), project.DisabledServiceNames()...)
 	for _, c := range raw {
 		svcName := c.Labels[api.ServiceLabel]
 		if isNotOneOff(c) && knownServices[svcName] {
 			state.Containers[svcName] = append(state.Containers[svcName], toObservedContainer(c))
-		} else if isOrphaned(project)(c) {
-			state.Orphans = append(state.Orphans, toObservedContainer(c))
+		} else if reason := orphanReason(services, c); reason != "" {
+			orphan := toObservedContainer(c)
+			orphan.OrphanReason = reason
+			state.Orphans = append(state.Orphans, orphan)
 		}
 	}
 
@@ -186,6 +186,7 @@ func TestCollectObservedState(t *testing.T) {
 	// Orphan container (service "old" not in project)
 	assert.Equal(t, len(state.Orphans), 1)
 	assert.Equal(t, state.Orphans[0].ID, "c3")
+	assert.Equal(t, state.Orphans[0].OrphanReason, `service "old" is not defined in the project`)
 
 	// Networks
 	assert.Equal(t, len(state.Networks), 1)
@@ -18,7 +18,10 @@ package compose
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/client"
@@ -33,16 +36,12 @@ func (s *composeService) Pause(ctx context.Context, projectName string, options
 }
 
 func (s *composeService) pause(ctx context.Context, projectName string, options api.PauseOptions) error {
-	containers, err := s.getContainers(ctx, projectName, oneOffExclude, false, options.Services...)
+	containers, err := s.getPauseTargets(ctx, projectName, options)
 	if err != nil {
 		return err
 	}
 
-	if options.Project != nil {
-		containers = containers.filter(isService(options.Project.ServiceNames()...))
-	}
-
-	return forEachContainerConcurrent(ctx, containers, func(ctx context.Context, ctr container.Summary) error {
+	return s.forEachPauseTarget(ctx, containers, "pause", container.StateRunning, container.StatePaused, func(ctx context.Context, ctr container.Summary) error {
 		_, err := s.apiClient().ContainerPause(ctx, ctr.ID, client.ContainerPauseOptions{})
 		if err == nil {
 			s.events.On(newEvent(getContainerProgressName(ctr), api.Done, "Paused"))
@@ -58,16 +57,12 @@ func (s *composeService) UnPause(ctx context.Context, projectName string, option
 }
 
 func (s *composeService) unPause(ctx context.Context, projectName string, options api.PauseOptions) error {
-	containers, err := s.getContainers(ctx, projectName, oneOffExclude, false, options.Services...)
+	containers, err := s.getPauseTargets(ctx, projectName, options)
 	if err != nil {
 		return err
 	}
 
-	if options.Project != nil {
-		containers = containers.filter(isService(options.Project.ServiceNames()...))
-	}
-
-	return forEachContainerConcurrent(ctx, containers, func(ctx context.Context, ctr container.Summary) error {
+	return s.forEachPauseTarget(ctx, containers, "unpause", container.StatePaused, container.StateRunning, func(ctx context.Context, ctr container.Summary) error {
 		_, err := s.apiClient().ContainerUnpause(ctx, ctr.ID, client.ContainerUnpauseOptions{})
 		if err == nil {
 			s.events.On(newEvent(getContainerProgressName(ctr), api.Done, "Unpaused"))
@@ -75,3 +70,56 @@ func (s *composeService) unPause(ctx context.Context, projectName string, option
 		return err
 	})
 }
+
+// getPauseTargets resolves the containers selected by options, a single replica when an index is set
+func (s *composeService) getPauseTargets(ctx context.Context, projectName string, options api.PauseOptions) (Containers, error) {
+	if options.Index > 0 {
+		if len(options.Services) != 1 {
+			return nil, errors.New("--index requires a service")
+		}
+		// also look up stopped containers so the selected replica gets reported with its actual state
+		ctr, err := s.getSpecifiedContainer(ctx, projectName, oneOffExclude, true, options.Services[0], options.Index)
+		if err != nil {
+			return nil, err
+		}
+		return Containers{ctr}, nil
+	}
+
+	containers, err := s.getContainers(ctx, projectName, oneOffExclude, false, options.Services...)
+	if err != nil {
+		return nil, err
+	}
+	if options.Project != nil && !options.All {
+		containers = containers.filter(isService(options.Project.ServiceNames()...))
+	}
+	return containers, nil
+}
+
+// forEachPauseTarget runs fn on all containers in the expected state and reports the others as errors. A failure on
+// one container doesn't prevent the others from being processed, errors are all returned once done.
+func (s *composeService) forEachPauseTarget(ctx context.Context, containers Containers, action string, state, target container.ContainerState, fn func(context.Context, container.Summary) error) error {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	_ = forEachContainerConcurrent(ctx, containers, func(ctx context.Context, ctr container.Summary) error {
+		eventName := getContainerProgressName(ctr)
+		var err error
+		switch ctr.State {
+		case state:
+			err = fn(ctx, ctr)
+		case target:
+			err = fmt.Errorf("container is already %s", target)
+		default:
+			err = fmt.Errorf("container is %s", ctr.State)
+		}
+		if err != nil {
+			s.events.On(errorEvent(eventName, err.Error()))
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("cannot %s container %s: %w", action, getCanonicalContainerName(ctr), err))
+			mu.Unlock()
+		}
+		return nil
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,128 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	compose "github.com/docker/compose/v5/pkg/api"
+)
+
+func TestPauseIndex(t *testing.T) {
+	const serviceName = "service1"
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	replica := testContainer(serviceName, "456", false)
+	replica.State = container.StateRunning
+	replica.Labels[compose.ContainerNumberLabel] = "2"
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude, serviceName).Add("label", containerNumberFilter(2)),
+		All:     true,
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{replica},
+	}, nil)
+	api.EXPECT().ContainerPause(anyCancellableContext(), "456", client.ContainerPauseOptions{}).Return(client.ContainerPauseResult{}, nil)
+
+	err = tested.Pause(t.Context(), name, compose.PauseOptions{Services: []string{serviceName}, Index: 2})
+	assert.NilError(t, err)
+}
+
+func TestPauseIndexRequiresService(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	_, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	err = tested.Pause(t.Context(), testProject, compose.PauseOptions{Index: 2})
+	assert.Error(t, err, "--index requires a service")
+	err = tested.UnPause(t.Context(), testProject, compose.PauseOptions{Services: []string{"a", "b"}, Index: 2})
+	assert.Error(t, err, "--index requires a service")
+}
+
+func TestPauseReportsContainerErrors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	running := testContainer("service1", "123", false)
+	running.State = container.StateRunning
+	paused := testContainer("service2", "456", false)
+	paused.State = container.StatePaused
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{running, paused},
+	}, nil)
+	api.EXPECT().ContainerPause(anyCancellableContext(), "123", client.ContainerPauseOptions{}).Return(client.ContainerPauseResult{}, nil)
+
+	err = tested.Pause(t.Context(), name, compose.PauseOptions{})
+	assert.Error(t, err, "cannot pause container 456: container is already paused")
+}
+
+func TestUnpauseAll(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	name := strings.ToLower(testProject)
+	declared := testContainer("service1", "123", false)
+	declared.State = container.StatePaused
+	undeclared := testContainer("service2", "456", false)
+	undeclared.State = container.StatePaused
+
+	api.EXPECT().ContainerList(t.Context(), client.ContainerListOptions{
+		Filters: getDefaultFilters(name, oneOffExclude),
+	}).Return(client.ContainerListResult{
+		Items: []container.Summary{declared, undeclared},
+	}, nil).Times(2)
+	api.EXPECT().ContainerUnpause(anyCancellableContext(), "123", client.ContainerUnpauseOptions{}).Return(client.ContainerUnpauseResult{}, nil).Times(2)
+	api.EXPECT().ContainerUnpause(anyCancellableContext(), "456", client.ContainerUnpauseOptions{}).Return(client.ContainerUnpauseResult{}, nil)
+
+	project := &types.Project{
+		Name:     name,
+		Services: types.Services{"service1": {Name: "service1"}},
+	}
+	err = tested.UnPause(t.Context(), name, compose.PauseOptions{Project: project})
+	assert.NilError(t, err)
+
+	err = tested.UnPause(t.Context(), name, compose.PauseOptions{Project: project, All: true})
+	assert.NilError(t, err)
+}
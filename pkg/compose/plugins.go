@@ -23,11 +23,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/containerd/errdefs"
@@ -35,24 +41,80 @@ import (
 	"github.com/docker/cli/cli/config"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/docker/compose/v5/internal/tracing"
 	"github.com/docker/compose/v5/pkg/api"
 )
 
 type JsonMessage struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
+	// Percent is the completion percentage reported by a progress message
+	Percent json.RawMessage `json:"percent,omitempty"`
+	// Variables can be used by setenv and rawsetenv messages to set multiple variables at once
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// mergeVariables adds variables set by a setenv or rawsetenv message, either as a single `KEY=VALUE`
+// message or as a set of variables
+func (m JsonMessage) mergeVariables(variables types.Mapping) error {
+	if m.Message == "" && len(m.Variables) == 0 {
+		return fmt.Errorf("invalid response from plugin: %s message without variables", m.Type)
+	}
+	if m.Message != "" {
+		key, val, found := strings.Cut(m.Message, "=")
+		if !found {
+			return fmt.Errorf("invalid response from plugin: %s", m.Message)
+		}
+		variables[key] = val
+	}
+	maps.Copy(variables, m.Variables)
+	return nil
 }
 
 const (
-	ErrorType                 = "error"
-	InfoType                  = "info"
-	SetEnvType                = "setenv"
-	RawSetEnvType             = "rawsetenv"
-	DebugType                 = "debug"
-	providerMetadataDirectory = "compose/providers"
+	ErrorType                   = "error"
+	InfoType                    = "info"
+	WarningType                 = "warning"
+	ProgressType                = "progress"
+	SetEnvType                  = "setenv"
+	RawSetEnvType               = "rawsetenv"
+	DebugType                   = "debug"
+	providerMetadataDirectory   = "compose/providers"
+	providerTimeoutOption       = "timeout"
+	providerEnvPrefixOption     = "env_prefix"
+	providerRetriesOption       = "retries"
+	providerRetryIntervalOption = "retry_interval"
+	defaultRetryInterval        = time.Second
+	providerDryRunParameter     = "dry-run"
 )
 
+// providerReservedOptions are provider options consumed by Compose, which are not passed to the
+// provider command unless declared by its metadata
+var providerReservedOptions = []string{
+	providerTimeoutOption, providerEnvPrefixOption, providerRetriesOption, providerRetryIntervalOption, providerEnvFileOption,
+}
+
+var (
+	envPrefixPattern      = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	providerOptionPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+)
+
+// ProviderPathLookupEnabled is the environment variable used to opt in to lookup
+// of provider binaries from PATH when no Docker CLI plugin matches the provider type
+const ProviderPathLookupEnabled = "COMPOSE_PROVIDER_PATH_LOOKUP"
+
+// providerPlugin describes the executable backing a provider service, either a
+// Docker CLI plugin or a plain binary resolved from PATH
+type providerPlugin struct {
+	Name string
+	Path string
+}
+
 type pluginVariables struct {
 	prefixed types.Mapping
 	raw      types.Mapping
@@ -61,65 +123,222 @@ type pluginVariables struct {
 var mux sync.Mutex
 
 func (s *composeService) runPlugin(ctx context.Context, project *types.Project, service types.ServiceConfig, command string) error {
+	spanName := fmt.Sprintf("provider/%s/%s", service.Provider.Type, command)
+	ctx, span := otel.Tracer("").Start(ctx, spanName, tracing.ServiceOptions(service).SpanStartOptions()...)
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("provider.type", service.Provider.Type),
+		attribute.String("provider.command", command),
+	)
+
+	variables, err := s.runProvider(ctx, project, service, command)
+	recordProviderSpan(span, variables, err)
+	return err
+}
+
+// recordProviderSpan sets the outcome of a provider command on its span
+func recordProviderSpan(span trace.Span, variables pluginVariables, err error) {
+	span.SetAttributes(attribute.Int("provider.variables", len(variables.prefixed)+len(variables.raw)))
+	if err == nil {
+		span.SetAttributes(attribute.Int("provider.exit_code", 0))
+		span.SetStatus(codes.Ok, "")
+		return
+	}
+	var providerErr *api.ProviderError
+	if errors.As(err, &providerErr) {
+		span.SetAttributes(attribute.Int("provider.exit_code", providerErr.ExitCode))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *composeService) runProvider(ctx context.Context, project *types.Project, service types.ServiceConfig, command string) (pluginVariables, error) {
 	provider := *service.Provider
 
 	plugin, err := s.getPluginBinaryPath(provider.Type)
 	if err != nil {
-		return err
+		return pluginVariables{}, err
 	}
 
-	cmd, err := s.setupPluginCommand(ctx, project, service, plugin, command)
+	timeout, err := providerDuration(provider, providerTimeoutOption, 0)
 	if err != nil {
-		return err
+		return pluginVariables{}, err
+	}
+	prefix, err := providerEnvPrefix(service)
+	if err != nil {
+		return pluginVariables{}, err
+	}
+	var retry retryPolicy
+	if command == "up" {
+		retry, err = providerRetryPolicy(provider)
+		if err != nil {
+			return pluginVariables{}, err
+		}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd, err := s.setupPluginCommand(ctx, project, service, plugin.Path, command)
+	if err != nil {
+		return pluginVariables{}, err
 	}
 	if cmd == nil {
-		return nil
+		return pluginVariables{}, nil
 	}
 
-	variables, err := s.executePlugin(cmd, command, service)
+	setup := func() (*exec.Cmd, error) {
+		return s.setupPluginCommand(ctx, project, service, plugin.Path, command)
+	}
+	variables, err := s.runPluginCommand(ctx, cmd, setup, command, project, service, retry)
 	if err != nil {
-		return err
+		if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			s.events.On(errorEventf(service.Name, "timed out after %s", timeout))
+			return variables, fmt.Errorf("provider %q timed out after %s", service.Name, timeout)
+		}
+		return variables, err
 	}
 
-	if command == "stop" {
-		return nil
+	if command != "up" {
+		// dependent services are not re-created, so variables can't be injected
+		return variables, nil
 	}
 
+	injectProviderVariables(project, service.Name, prefix, variables)
+
+	if path := providerEnvFile(project, service); path != "" && !s.dryRun {
+		if err := writeProviderEnvFile(path, service.Name, prefix, variables); err != nil {
+			return variables, fmt.Errorf("failed to write variables of provider service %q: %w", service.Name, err)
+		}
+	}
+	return variables, nil
+}
+
+// runPluginCommand executes the provider command and persists its outcome, unless running in dry-run mode
+func (s *composeService) runPluginCommand(ctx context.Context, cmd *exec.Cmd, setup func() (*exec.Cmd, error), command string, project *types.Project, service types.ServiceConfig, retry retryPolicy) (pluginVariables, error) {
+	if s.dryRun {
+		return s.executePluginWithRetry(ctx, cmd, setup, command, service, io.Discard, retry)
+	}
+	key := s.providerKey(project.Name, project)
+	logs := openProviderLog(key, service.Name, command)
+	variables, err := s.executePluginWithRetry(ctx, cmd, setup, command, service, logs, retry)
+	_ = logs.Close()
+	recordProviderState(key, service, command, err)
+	if err == nil && command == "down" {
+		removeProviderLog(key, service.Name)
+	}
+	return variables, err
+}
+
+// retryPolicy defines how many times a failed provider command is retried, with an exponential backoff
+// starting with interval
+type retryPolicy struct {
+	retries  int
+	interval time.Duration
+}
+
+// executePluginWithRetry runs the provider command, then runs it again on failure as long as the retry
+// policy allows it. An exec.Cmd can only run once, so setup creates the command of each new attempt.
+func (s *composeService) executePluginWithRetry(ctx context.Context, cmd *exec.Cmd, setup func() (*exec.Cmd, error), command string, service types.ServiceConfig, logs io.Writer, retry retryPolicy) (pluginVariables, error) {
+	delay := retry.interval
+	for attempt := 1; ; attempt++ {
+		variables, err := s.executePlugin(cmd, command, service, logs)
+		if err == nil || attempt > retry.retries || ctx.Err() != nil {
+			if err != nil && attempt > 1 {
+				err = fmt.Errorf("%w (after %d attempts)", err, attempt)
+			}
+			return variables, err
+		}
+
+		s.events.On(newEvent(service.Name, api.Working, "Retrying",
+			fmt.Sprintf("attempt %d/%d failed, retrying in %s", attempt, retry.retries+1, delay)))
+		select {
+		case <-ctx.Done():
+			return pluginVariables{}, fmt.Errorf("%w (after %d attempts)", err, attempt)
+		case <-s.clock.After(delay):
+		}
+		delay *= 2
+
+		cmd, err = setup()
+		if err != nil {
+			return pluginVariables{}, err
+		}
+		if cmd == nil {
+			return pluginVariables{}, nil
+		}
+	}
+}
+
+// injectProviderVariables sets variables returned by a provider into the environment of all services
+// depending on it, either directly or transitively. Overridden values are reported as warnings.
+func injectProviderVariables(project *types.Project, provider, prefix string, variables pluginVariables) {
 	mux.Lock()
 	defer mux.Unlock()
-	for name, s := range project.Services {
-		if _, ok := s.DependsOn[service.Name]; ok {
-			prefix := strings.ToUpper(service.Name) + "_"
-			for key, val := range variables.prefixed {
-				s.Environment[prefix+key] = &val
-			}
-			for key, val := range variables.raw {
-				if existing, ok := s.Environment[key]; ok && (existing == nil || *existing != val) {
-					logrus.Warnf("provider %q overrides environment variable %q in service %q", service.Name, key, name)
-				}
-				s.Environment[key] = &val
+	for _, name := range transitiveDependents(project, provider) {
+		s := project.Services[name]
+		for key, val := range variables.prefixed {
+			setProviderVariable(s, provider, prefix+key, val)
+		}
+		for key, val := range variables.raw {
+			setProviderVariable(s, provider, key, val)
+		}
+		project.Services[name] = s
+	}
+}
+
+func setProviderVariable(s types.ServiceConfig, provider, key, val string) {
+	if existing, ok := s.Environment[key]; ok && (existing == nil || *existing != val) {
+		logrus.Warnf("provider %q overrides environment variable %q in service %q", provider, key, s.Name)
+	}
+	s.Environment[key] = &val
+}
+
+// transitiveDependents returns the sorted names of services depending on the named service,
+// either directly or through other services
+func transitiveDependents(project *types.Project, name string) []string {
+	seen := map[string]bool{}
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, s := range project.Services {
+			if _, ok := s.DependsOn[current]; ok && !seen[s.Name] {
+				seen[s.Name] = true
+				queue = append(queue, s.Name)
 			}
-			project.Services[name] = s
 		}
 	}
-	return nil
+	delete(seen, name)
+	return slices.Sorted(maps.Keys(seen))
 }
 
-func (s *composeService) executePlugin(cmd *exec.Cmd, command string, service types.ServiceConfig) (pluginVariables, error) { //nolint:gocyclo
-	var action string
+func (s *composeService) executePlugin(cmd *exec.Cmd, command string, service types.ServiceConfig, logs io.Writer) (pluginVariables, error) { //nolint:gocyclo
+	var (
+		action  string
+		working api.Resource
+	)
 	switch command {
 	case "up":
-		s.events.On(creatingEvent(service.Name))
+		working = creatingEvent(service.Name)
 		action = "create"
 	case "down":
-		s.events.On(removingEvent(service.Name))
+		working = removingEvent(service.Name)
 		action = "remove"
 	case "stop":
-		s.events.On(stoppingEvent(service.Name))
+		working = stoppingEvent(service.Name)
 		action = "stop"
+	case "start":
+		working = startingEvent(service.Name)
+		action = "start"
+	case "restart":
+		working = restartingEvent(service.Name)
+		action = "restart"
 	default:
 		return pluginVariables{}, fmt.Errorf("unsupported plugin command: %s", command)
 	}
+	s.events.On(working)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -150,22 +369,34 @@ func (s *composeService) executePlugin(cmd *exec.Cmd, command string, service ty
 		}
 		switch msg.Type {
 		case ErrorType:
+			_, _ = fmt.Fprintln(logs, msg.Message)
 			s.events.On(newEvent(service.Name, api.Error, firstLine(msg.Message)))
 			return pluginVariables{}, errors.New(msg.Message)
 		case InfoType:
-			s.events.On(newEvent(service.Name, api.Working, firstLine(msg.Message)))
+			_, _ = fmt.Fprintln(logs, msg.Message)
+			s.events.On(infoEvent(service.Name, firstLine(msg.Message)))
+		case ProgressType:
+			percent, err := parsePercent(msg.Percent)
+			if err != nil {
+				logrus.Warnf("%s: ignoring invalid progress percentage %q: %v", service.Name, string(msg.Percent), err)
+				continue
+			}
+			text := working.Text
+			if msg.Message != "" {
+				text = firstLine(msg.Message)
+			}
+			s.events.On(progressEvent(service.Name, text, percent))
+		case WarningType:
+			_, _ = fmt.Fprintln(logs, msg.Message)
+			s.events.On(warningEvent(service.Name, firstLine(msg.Message)))
 		case SetEnvType:
-			key, val, found := strings.Cut(msg.Message, "=")
-			if !found {
-				return pluginVariables{}, fmt.Errorf("invalid response from plugin: %s", msg.Message)
+			if err := msg.mergeVariables(variables.prefixed); err != nil {
+				return pluginVariables{}, err
 			}
-			variables.prefixed[key] = val
 		case RawSetEnvType:
-			key, val, found := strings.Cut(msg.Message, "=")
-			if !found {
-				return pluginVariables{}, fmt.Errorf("invalid response from plugin: %s", msg.Message)
+			if err := msg.mergeVariables(variables.raw); err != nil {
+				return pluginVariables{}, err
 			}
-			variables.raw[key] = val
 		case DebugType:
 			logrus.Debugf("%s: %s", service.Name, msg.Message)
 		default:
@@ -176,7 +407,17 @@ func (s *composeService) executePlugin(cmd *exec.Cmd, command string, service ty
 	err = cmd.Wait()
 	if err != nil {
 		s.events.On(errorEvent(service.Name, err.Error()))
-		return pluginVariables{}, fmt.Errorf("failed to %s service provider: %s", action, err.Error())
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		return pluginVariables{}, &api.ProviderError{
+			Service:  service.Name,
+			Action:   action,
+			ExitCode: exitCode,
+			Err:      err,
+		}
 	}
 	switch command {
 	case "up":
@@ -185,25 +426,54 @@ func (s *composeService) executePlugin(cmd *exec.Cmd, command string, service ty
 		s.events.On(removedEvent(service.Name))
 	case "stop":
 		s.events.On(stoppedEvent(service.Name))
+	case "start":
+		s.events.On(startedEvent(service.Name))
+	case "restart":
+		s.events.On(restartedEvent(service.Name))
 	}
 	return variables, nil
 }
 
-func (s *composeService) getPluginBinaryPath(provider string) (path string, err error) {
+func (s *composeService) getPluginBinaryPath(provider string) (*providerPlugin, error) {
 	if provider == "compose" {
-		return "", errors.New("'compose' is not a valid provider type")
+		return nil, errors.New("'compose' is not a valid provider type")
 	}
 	plugin, err := manager.GetPlugin(provider, s.dockerCli, &cobra.Command{})
 	if err == nil {
-		path = plugin.Path
+		return &providerPlugin{Name: plugin.Name, Path: plugin.Path}, nil
 	}
-	if errdefs.IsNotFound(err) {
-		path, err = exec.LookPath(executable(provider))
+	if !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+	enabled, lookupErr := providerPathLookupEnabled()
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	if !enabled {
+		return nil, fmt.Errorf("%w, set %s=true to also look up provider executables in PATH", err, ProviderPathLookupEnabled)
+	}
+	path, err := exec.LookPath(executable(provider))
+	if err != nil {
+		return nil, err
+	}
+	return &providerPlugin{Name: provider, Path: path}, nil
+}
+
+func providerPathLookupEnabled() (bool, error) {
+	if v := os.Getenv(ProviderPathLookupEnabled); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("%s environment variable expects boolean value: %w", ProviderPathLookupEnabled, err)
+		}
+		return enabled, nil
 	}
-	return path, err
+	return false, nil
 }
 
 func (s *composeService) setupPluginCommand(ctx context.Context, project *types.Project, service types.ServiceConfig, path, command string) (*exec.Cmd, error) {
+	if err := validateProviderOptions(*service.Provider); err != nil {
+		return nil, err
+	}
 	cmdOptionsMetadata := s.getPluginMetadata(path, service.Provider.Type, project)
 	var currentCommandMetadata CommandMetadata
 	switch command {
@@ -211,11 +481,13 @@ func (s *composeService) setupPluginCommand(ctx context.Context, project *types.
 		currentCommandMetadata = cmdOptionsMetadata.Up
 	case "down":
 		currentCommandMetadata = cmdOptionsMetadata.Down
-	case "stop":
-		if cmdOptionsMetadata.Stop == nil {
+	case "stop", "start", "restart":
+		// those commands are opt-in, only run them when advertised by the provider
+		optional := cmdOptionsMetadata.optionalCommand(command)
+		if optional == nil {
 			return nil, nil
 		}
-		currentCommandMetadata = *cmdOptionsMetadata.Stop
+		currentCommandMetadata = *optional
 	}
 
 	provider := *service.Provider
@@ -223,11 +495,32 @@ func (s *composeService) setupPluginCommand(ctx context.Context, project *types.
 	if err := currentCommandMetadata.CheckRequiredParameters(provider); !commandMetadataIsEmpty && err != nil {
 		return nil, err
 	}
+	if cmdOptionsMetadata.RequiresDesktop && command == "up" {
+		active, err := s.isDesktopIntegrationActive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			return nil, fmt.Errorf("provider %q used by service %q requires Docker Desktop", provider.Type, service.Name)
+		}
+	}
 
 	args := []string{"compose", fmt.Sprintf("--project-name=%s", project.Name), command}
-	for k, v := range provider.Options {
-		for _, value := range v {
-			if _, ok := currentCommandMetadata.GetParameter(k); commandMetadataIsEmpty || ok {
+	if s.dryRun {
+		// providers opt in to dry-run by declaring a dry-run parameter, others must not run at all
+		if _, ok := currentCommandMetadata.GetParameter(providerDryRunParameter); !ok {
+			s.events.On(skippedEvent(service.Name, "provider doesn't support dry-run"))
+			return nil, nil
+		}
+		args = append(args, "--"+providerDryRunParameter)
+	}
+	for _, k := range slices.Sorted(maps.Keys(provider.Options)) {
+		_, declared := currentCommandMetadata.GetParameter(k)
+		if slices.Contains(providerReservedOptions, k) && !declared {
+			continue
+		}
+		for _, value := range provider.Options[k] {
+			if commandMetadataIsEmpty || declared {
 				args = append(args, fmt.Sprintf("--%s=%s", k, value))
 			}
 		}
@@ -236,13 +529,84 @@ func (s *composeService) setupPluginCommand(ctx context.Context, project *types.
 
 	cmd := exec.CommandContext(ctx, path, args...)
 
-	err := s.prepareShellOut(ctx, project.Environment, cmd)
+	references, err := s.providerFileReferences(project, service)
+	if err != nil {
+		return nil, err
+	}
+	env := project.Environment.Clone()
+	maps.Copy(env, references)
+	err = s.prepareShellOut(ctx, env, cmd)
 	if err != nil {
 		return nil, err
 	}
 	return cmd, nil
 }
 
+// validateProviderOptions checks provider options can safely be passed as `--key=value` flags
+func validateProviderOptions(provider types.ServiceProviderConfig) error {
+	for key, values := range provider.Options {
+		if !providerOptionPattern.MatchString(key) {
+			return fmt.Errorf("invalid option %q for provider %q: option names must only contain alphanumeric characters, '_', '.' or '-'", key, provider.Type)
+		}
+		for _, value := range values {
+			if strings.ContainsFunc(value, unicode.IsControl) {
+				return fmt.Errorf("invalid value for option %q of provider %q: control characters are not allowed", key, provider.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// providerDuration returns the duration set by a provider option, or defaultValue when not set
+func providerDuration(provider types.ServiceProviderConfig, option string, defaultValue time.Duration) (time.Duration, error) {
+	values := provider.Options[option]
+	if len(values) == 0 {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(values[len(values)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s option for provider %q: %w", option, provider.Type, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid %s option for provider %q: must be positive", option, provider.Type)
+	}
+	return d, nil
+}
+
+// providerRetryPolicy returns the retry policy set by the `retries` and `retry_interval` provider options.
+// By default, failed commands are not retried.
+func providerRetryPolicy(provider types.ServiceProviderConfig) (retryPolicy, error) {
+	var policy retryPolicy
+	if values := provider.Options[providerRetriesOption]; len(values) > 0 {
+		retries, err := strconv.Atoi(values[len(values)-1])
+		if err != nil || retries < 0 {
+			return policy, fmt.Errorf("invalid %s option for provider %q: expected a positive integer, got %q", providerRetriesOption, provider.Type, values[len(values)-1])
+		}
+		policy.retries = retries
+	}
+	interval, err := providerDuration(provider, providerRetryIntervalOption, defaultRetryInterval)
+	if err != nil {
+		return policy, err
+	}
+	policy.interval = interval
+	return policy, nil
+}
+
+// providerEnvPrefix returns the prefix for variables set by the provider with `setenv`. It defaults
+// to the upper-cased service name, and can be overridden by the `env_prefix` provider option, an
+// empty value disabling the prefix.
+func providerEnvPrefix(service types.ServiceConfig) (string, error) {
+	values, ok := service.Provider.Options[providerEnvPrefixOption]
+	if !ok || len(values) == 0 {
+		return strings.ToUpper(service.Name) + "_", nil
+	}
+	prefix := values[len(values)-1]
+	if prefix != "" && !envPrefixPattern.MatchString(prefix) {
+		return "", fmt.Errorf("invalid env_prefix option for provider service %q: %q is not a valid environment variable prefix", service.Name, prefix)
+	}
+	return prefix, nil
+}
+
 func (s *composeService) getPluginMetadata(path, command string, project *types.Project) ProviderMetadata {
 	cmd := exec.Command(path, "compose", "metadata")
 	err := s.prepareShellOut(context.Background(), project.Environment, cmd)
@@ -283,12 +647,31 @@ type ProviderMetadata struct {
 	Up          CommandMetadata  `json:"up"`
 	Down        CommandMetadata  `json:"down"`
 	Stop        *CommandMetadata `json:"stop,omitempty"`
+	Start       *CommandMetadata `json:"start,omitempty"`
+	Restart     *CommandMetadata `json:"restart,omitempty"`
+	// RequiresDesktop declares the provider can only create resources when Docker Desktop is the active engine
+	RequiresDesktop bool `json:"requires_desktop,omitempty"`
 }
 
 func (p ProviderMetadata) IsEmpty() bool {
 	return p.Description == "" && p.Up.Parameters == nil && p.Down.Parameters == nil
 }
 
+// optionalCommand returns metadata for an opt-in provider command, or nil if the provider
+// doesn't support it
+func (p ProviderMetadata) optionalCommand(command string) *CommandMetadata {
+	switch command {
+	case "stop":
+		return p.Stop
+	case "start":
+		return p.Start
+	case "restart":
+		return p.Restart
+	default:
+		return nil
+	}
+}
+
 type CommandMetadata struct {
 	Parameters []ParameterMetadata `json:"parameters"`
 }
@@ -321,6 +704,15 @@ func (c CommandMetadata) CheckRequiredParameters(provider types.ServiceProviderC
 	return nil
 }
 
+// parsePercent decodes the completion percentage reported by a provider, clamped to 0-100
+func parsePercent(raw json.RawMessage) (int, error) {
+	var percent float64
+	if err := json.Unmarshal(raw, &percent); err != nil {
+		return 0, err
+	}
+	return int(min(max(percent, 0), 100)), nil
+}
+
 // firstLine returns the first line of s, stripping any trailing newlines.
 func firstLine(s string) string {
 	s = strings.TrimRight(s, "\n")
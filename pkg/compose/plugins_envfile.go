@@ -0,0 +1,124 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/dotenv"
+	"github.com/compose-spec/compose-go/v2/types"
+)
+
+// providerEnvFileOption is the provider option used to set a file Compose writes variables
+// exposed by the provider to
+const providerEnvFileOption = "env_file"
+
+// providerEnvFile returns the path of the env file variables are written to for a provider service,
+// resolved relative to the project working directory, or an empty string if none is set
+func providerEnvFile(project *types.Project, service types.ServiceConfig) string {
+	values, ok := service.Provider.Options[providerEnvFileOption]
+	if !ok || len(values) == 0 || values[len(values)-1] == "" {
+		return ""
+	}
+	path := values[len(values)-1]
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(project.WorkingDir, path)
+	}
+	return path
+}
+
+// providerEnvFileSection is the comment line starting the variables written by a provider service
+const providerEnvFileSection = "# provider service: "
+
+// writeProviderEnvFile writes variables set by a provider with `setenv` to path. As multiple providers
+// can share the same file, variables are written in a section per provider service: the section of the
+// service is replaced, others are preserved. Variables set with `rawsetenv` are not written, as they
+// typically are secrets.
+func writeProviderEnvFile(path, service, prefix string, variables pluginVariables) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	sections, err := readProviderEnvFile(path)
+	if err != nil {
+		return err
+	}
+	section := types.Mapping{}
+	for key, val := range variables.prefixed {
+		section[prefix+key] = val
+	}
+	sections[service] = section
+
+	var sb strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(sections)) {
+		env := sections[name]
+		if len(env) == 0 {
+			continue
+		}
+		if name != "" {
+			sb.WriteString(providerEnvFileSection + name + "\n")
+		}
+		for _, key := range slices.Sorted(maps.Keys(env)) {
+			// single-quoted values are not interpolated when the file is read back
+			fmt.Fprintf(&sb, "%s='%s'\n", key, strings.ReplaceAll(env[key], "'", `\'`))
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0o600)
+}
+
+// readProviderEnvFile reads the variables of a provider env file by provider service. Variables which
+// are not in a provider section are reported for an empty service name.
+func readProviderEnvFile(path string) (map[string]types.Mapping, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]types.Mapping{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	blocks := map[string]*strings.Builder{}
+	current := ""
+	for _, line := range strings.SplitAfter(string(b), "\n") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(line), providerEnvFileSection); ok {
+			current = name
+			continue
+		}
+		if blocks[current] == nil {
+			blocks[current] = &strings.Builder{}
+		}
+		blocks[current].WriteString(line)
+	}
+	sections := map[string]types.Mapping{}
+	for name, block := range blocks {
+		env, err := dotenv.UnmarshalWithLookup(block.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read provider env file %s: %w", path, err)
+		}
+		sections[name] = env
+	}
+	return sections, nil
+}
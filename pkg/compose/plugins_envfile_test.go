@@ -0,0 +1,98 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/dotenv"
+	"github.com/compose-spec/compose-go/v2/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestProviderEnvFile(t *testing.T) {
+	project := &types.Project{WorkingDir: "/work"}
+	service := func(options types.MultiOptions) types.ServiceConfig {
+		return types.ServiceConfig{Name: "database", Provider: &types.ServiceProviderConfig{Type: "awesomecloud", Options: options}}
+	}
+
+	assert.Equal(t, providerEnvFile(project, service(nil)), "")
+	assert.Equal(t, providerEnvFile(project, service(types.MultiOptions{"env_file": {""}})), "")
+	assert.Equal(t, providerEnvFile(project, service(types.MultiOptions{"env_file": {"providers.env"}})), filepath.Join("/work", "providers.env"))
+	abs := filepath.Join(t.TempDir(), "providers.env")
+	assert.Equal(t, providerEnvFile(project, service(types.MultiOptions{"env_file": {abs}})), abs)
+}
+
+func TestWriteProviderEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "providers.env")
+
+	err := writeProviderEnvFile(path, "database", "DATABASE_", pluginVariables{
+		prefixed: types.Mapping{"URL": "https://magic.cloud/db", "PASSWORD": "it's $ecret"},
+		raw:      types.Mapping{"SECRET_KEY": "xxx"},
+	})
+	assert.NilError(t, err)
+	err = writeProviderEnvFile(path, "queue", "QUEUE_", pluginVariables{prefixed: types.Mapping{"URL": "amqp://magic.cloud/queue"}})
+	assert.NilError(t, err)
+
+	env, err := dotenv.Read(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, env, map[string]string{
+		"DATABASE_URL":      "https://magic.cloud/db",
+		"DATABASE_PASSWORD": "it's $ecret",
+		"QUEUE_URL":         "amqp://magic.cloud/queue",
+	})
+
+	// running the provider again replaces variables it previously wrote
+	err = writeProviderEnvFile(path, "database", "DATABASE_", pluginVariables{prefixed: types.Mapping{"URL": "https://magic.cloud/db2"}})
+	assert.NilError(t, err)
+
+	env, err = dotenv.Read(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, env, map[string]string{
+		"DATABASE_URL": "https://magic.cloud/db2",
+		"QUEUE_URL":    "amqp://magic.cloud/queue",
+	})
+
+	content, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "# provider service: database\nDATABASE_URL='https://magic.cloud/db2'\n"+
+		"# provider service: queue\nQUEUE_URL='amqp://magic.cloud/queue'\n")
+}
+
+func TestWriteProviderEnvFileSharedPrefix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "providers.env")
+	assert.NilError(t, os.WriteFile(path, []byte("USER_DEFINED=kept\n"), 0o600))
+
+	err := writeProviderEnvFile(path, "db", "DB_", pluginVariables{prefixed: types.Mapping{"URL": "https://magic.cloud/db"}})
+	assert.NilError(t, err)
+	err = writeProviderEnvFile(path, "db-replica", "DB_REPLICA_", pluginVariables{prefixed: types.Mapping{"URL": "https://magic.cloud/replica"}})
+	assert.NilError(t, err)
+
+	// DB_REPLICA_URL has the DB_ prefix, but was written by another provider service
+	err = writeProviderEnvFile(path, "db", "DB_", pluginVariables{prefixed: types.Mapping{"HOST": "magic.cloud"}})
+	assert.NilError(t, err)
+
+	env, err := dotenv.Read(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, env, map[string]string{
+		"USER_DEFINED":   "kept",
+		"DB_HOST":        "magic.cloud",
+		"DB_REPLICA_URL": "https://magic.cloud/replica",
+	})
+}
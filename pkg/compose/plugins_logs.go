@@ -0,0 +1,177 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+// providerLogsDirectory is where messages reported by provider services are persisted,
+// so they can be retrieved by `compose logs` once the provider command has completed
+const providerLogsDirectory = "compose/providers/logs"
+
+func providerLogFile(key providerKey, serviceName string) string {
+	return filepath.Join(key.dir(providerLogsDirectory), serviceName+".log")
+}
+
+// openProviderLog opens the log file for a provider service. Running `up` starts a new log,
+// other commands append to the existing one. As logs are not critical for the main flow,
+// errors are only reported in debug logs and messages are then discarded.
+func openProviderLog(key providerKey, serviceName, command string) io.WriteCloser {
+	path := providerLogFile(key, serviceName)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		logrus.Debugf("failed to create provider logs directory: %v", err)
+		return nopWriteCloser{io.Discard}
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if command == "up" {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0o600)
+	if err != nil {
+		logrus.Debugf("failed to open provider log file: %v", err)
+		return nopWriteCloser{io.Discard}
+	}
+	return timestampWriter{WriteCloser: f, now: time.Now}
+}
+
+// timestampWriter prefixes lines with the time they were written, so persisted messages can be
+// filtered by time when retrieved
+type timestampWriter struct {
+	io.WriteCloser
+	now func() time.Time
+}
+
+func (w timestampWriter) Write(p []byte) (int, error) {
+	timestamp := formatLogTime(w.now())
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(string(p), "\n") {
+		if line != "" {
+			b.WriteString(timestamp + " " + line)
+		}
+	}
+	if _, err := io.WriteString(w.WriteCloser, b.String()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// providerLogFilter selects persisted provider messages by the time they were written
+type providerLogFilter struct {
+	since      time.Time
+	until      time.Time
+	timestamps bool
+}
+
+func removeProviderLog(key providerKey, serviceName string) {
+	if err := os.Remove(providerLogFile(key, serviceName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logrus.Debugf("failed to remove provider log file: %v", err)
+	}
+}
+
+// logProviders sends persisted provider messages to the log consumer. When no service is
+// selected, all provider services with persisted messages for the project are considered.
+func logProviders(consumer api.LogConsumer, key providerKey, services []string, filter providerLogFilter) error {
+	dirs, err := key.dirs(providerLogsDirectory)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		selected := services
+		if len(selected) == 0 {
+			entries, err := os.ReadDir(dir)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				if name, ok := strings.CutSuffix(entry.Name(), ".log"); ok && !entry.IsDir() {
+					selected = append(selected, name)
+				}
+			}
+		}
+		for _, service := range slices.Sorted(slices.Values(selected)) {
+			if err := logProvider(consumer, filepath.Join(dir, service+".log"), service, filter); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func logProvider(consumer api.LogConsumer, path, serviceName string, filter providerLogFilter) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		timestamp, message, _ := strings.Cut(line, " ")
+		written, err := time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil {
+			// messages persisted without a timestamp can't be filtered
+			consumer.Log(serviceName, line)
+			continue
+		}
+		if (!filter.since.IsZero() && written.Before(filter.since)) || (!filter.until.IsZero() && written.After(filter.until)) {
+			continue
+		}
+		if records, ok := consumer.(api.LogRecordConsumer); ok {
+			records.LogRecord(api.LogRecord{
+				Service:   serviceName,
+				Container: serviceName,
+				Timestamp: written,
+				Stream:    api.StdoutStream,
+				Message:   message,
+			})
+			continue
+		}
+		if filter.timestamps {
+			message = line
+		}
+		consumer.Log(serviceName, message)
+	}
+	return scanner.Err()
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
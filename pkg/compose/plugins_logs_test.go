@@ -0,0 +1,131 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/cli/config"
+	"gotest.tools/v3/assert"
+)
+
+func TestProviderLogs(t *testing.T) {
+	previous := config.Dir()
+	config.SetDir(t.TempDir())
+	t.Cleanup(func() { config.SetDir(previous) })
+
+	key := providerKey{context: "default", projectName: "myproject", workingDir: "/src/myproject"}
+	logs := openProviderLog(key, "database", "up")
+	_, _ = fmt.Fprintln(logs, "creating database")
+	assert.NilError(t, logs.Close())
+
+	logs = openProviderLog(key, "database", "stop")
+	_, _ = fmt.Fprintln(logs, "stopping database")
+	assert.NilError(t, logs.Close())
+
+	logs = openProviderLog(key, "queue", "up")
+	_, _ = fmt.Fprintln(logs, "creating queue")
+	assert.NilError(t, logs.Close())
+
+	consumer := &testLogConsumer{}
+	assert.NilError(t, logProviders(consumer, key, nil, providerLogFilter{}))
+	assert.DeepEqual(t, consumer.LogsForContainer("database"), []string{"creating database", "stopping database"})
+	assert.DeepEqual(t, consumer.LogsForContainer("queue"), []string{"creating queue"})
+
+	consumer = &testLogConsumer{}
+	assert.NilError(t, logProviders(consumer, key, []string{"queue", "web"}, providerLogFilter{}))
+	assert.Equal(t, len(consumer.LogsForContainer("database")), 0)
+	assert.DeepEqual(t, consumer.LogsForContainer("queue"), []string{"creating queue"})
+
+	// a new `up` starts a fresh log
+	logs = openProviderLog(key, "database", "up")
+	_, _ = fmt.Fprintln(logs, "database is up to date")
+	assert.NilError(t, logs.Close())
+
+	removeProviderLog(key, "queue")
+
+	consumer = &testLogConsumer{}
+	assert.NilError(t, logProviders(consumer, key, nil, providerLogFilter{}))
+	assert.DeepEqual(t, consumer.LogsForContainer("database"), []string{"database is up to date"})
+	assert.Equal(t, len(consumer.LogsForContainer("queue")), 0)
+
+	assert.NilError(t, logProviders(&testLogConsumer{}, providerKey{context: "default", projectName: "unknown"}, nil, providerLogFilter{}))
+
+	// a project with the same name in another directory or on another context has its own logs
+	other := providerKey{context: "default", projectName: "myproject", workingDir: "/src/other"}
+	logs = openProviderLog(other, "database", "up")
+	_, _ = fmt.Fprintln(logs, "creating other database")
+	assert.NilError(t, logs.Close())
+	remote := providerKey{context: "remote", projectName: "myproject", workingDir: "/src/myproject"}
+	logs = openProviderLog(remote, "database", "up")
+	_, _ = fmt.Fprintln(logs, "creating remote database")
+	assert.NilError(t, logs.Close())
+
+	consumer = &testLogConsumer{}
+	assert.NilError(t, logProviders(consumer, key, nil, providerLogFilter{}))
+	assert.DeepEqual(t, consumer.LogsForContainer("database"), []string{"database is up to date"})
+
+	// with just a project name, all directories it was run from are considered
+	consumer = &testLogConsumer{}
+	assert.NilError(t, logProviders(consumer, providerKey{context: "default", projectName: "myproject"}, nil, providerLogFilter{}))
+	assert.Equal(t, len(consumer.LogsForContainer("database")), 2)
+}
+
+func TestProviderLogsFilter(t *testing.T) {
+	previous := config.Dir()
+	config.SetDir(t.TempDir())
+	t.Cleanup(func() { config.SetDir(previous) })
+
+	key := providerKey{context: "default", projectName: "myproject", workingDir: "/src/myproject"}
+	path := providerLogFile(key, "database")
+	assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.NilError(t, os.WriteFile(path, []byte(`legacy message
+2024-01-01T10:00:00Z creating database
+2024-01-01T11:00:00Z database created
+2024-01-01T12:00:00Z stopping database
+`), 0o600))
+
+	consumer := &testLogConsumer{}
+	assert.NilError(t, logProviders(consumer, key, nil, providerLogFilter{
+		since: time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC),
+		until: time.Date(2024, 1, 1, 11, 30, 0, 0, time.UTC),
+	}))
+	assert.DeepEqual(t, consumer.LogsForContainer("database"), []string{"legacy message", "database created"})
+
+	consumer = &testLogConsumer{}
+	assert.NilError(t, logProviders(consumer, key, nil, providerLogFilter{
+		since:      time.Date(2024, 1, 1, 11, 30, 0, 0, time.UTC),
+		timestamps: true,
+	}))
+	assert.DeepEqual(t, consumer.LogsForContainer("database"), []string{"legacy message", "2024-01-01T12:00:00Z stopping database"})
+}
+
+func TestTimestampWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := timestampWriter{
+		WriteCloser: nopWriteCloser{buf},
+		now:         func() time.Time { return time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC) },
+	}
+	_, err := fmt.Fprintln(w, "first line\nsecond line")
+	assert.NilError(t, err)
+	assert.Equal(t, buf.String(), "2024-01-01T10:00:00Z first line\n2024-01-01T10:00:00Z second line\n")
+}
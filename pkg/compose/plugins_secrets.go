@@ -0,0 +1,63 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+)
+
+var providerEnvNameReplacer = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// providerFileReferences returns the environment variables exposing secrets and configs used by a
+// provider service to the provider command. As a provider doesn't run in a container, secrets and
+// configs can't be mounted: content is set as COMPOSE_SECRET_<NAME> (resp. COMPOSE_CONFIG_<NAME>),
+// and the path to a file source is set as COMPOSE_SECRET_<NAME>_FILE, so values never show up in
+// the command line.
+func (s *composeService) providerFileReferences(project *types.Project, service types.ServiceConfig) (types.Mapping, error) {
+	env := types.Mapping{}
+	for _, mountType := range []mountType{secretMount, configMount} {
+		mounts, sources := s.getFilesAndMap(project, service, mountType)
+		for _, mount := range mounts {
+			source, ok := sources[mount.Source]
+			if !ok {
+				return nil, fmt.Errorf("service %q refers to undefined %s %s", service.Name, mountType, mount.Source)
+			}
+			name := mount.Source
+			if mount.Target != "" {
+				name = path.Base(mount.Target)
+			}
+			key := fmt.Sprintf("COMPOSE_%s_%s", strings.ToUpper(string(mountType)), providerEnvNameReplacer.ReplaceAllString(strings.ToUpper(name), "_"))
+
+			content, err := s.resolveFileContent(project, source, mountType)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case content != "":
+				env[key] = content
+			case source.File != "":
+				env[key+"_FILE"] = source.File
+			}
+		}
+	}
+	return env, nil
+}
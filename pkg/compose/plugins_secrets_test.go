@@ -0,0 +1,61 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestProviderFileReferences(t *testing.T) {
+	project := &types.Project{
+		Environment: types.Mapping{"TOKEN": "s3cr3t"},
+		Secrets: types.Secrets{
+			"api-token":   {Name: "api-token", Environment: "TOKEN"},
+			"certificate": {Name: "certificate", File: "/work/cert.pem"},
+		},
+		Configs: types.Configs{
+			"settings": {Name: "settings", Content: "debug: true"},
+		},
+	}
+	service := types.ServiceConfig{
+		Name:     "database",
+		Provider: &types.ServiceProviderConfig{Type: "awesomecloud"},
+		Secrets: []types.ServiceSecretConfig{
+			{Source: "api-token"},
+			{Source: "certificate", Target: "/run/secrets/tls.pem"},
+		},
+		Configs: []types.ServiceConfigObjConfig{
+			{Source: "settings"},
+		},
+	}
+
+	s := &composeService{}
+	env, err := s.providerFileReferences(project, service)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, env, types.Mapping{
+		"COMPOSE_SECRET_API_TOKEN":    "s3cr3t",
+		"COMPOSE_SECRET_TLS_PEM_FILE": "/work/cert.pem",
+		"COMPOSE_CONFIG_SETTINGS":     "debug: true",
+	})
+
+	delete(project.Environment, "TOKEN")
+	_, err = s.providerFileReferences(project, service)
+	assert.Error(t, err, `environment variable "TOKEN" required by secret "api-token" is not set`)
+}
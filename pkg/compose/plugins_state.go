@@ -0,0 +1,232 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/cli/cli/config"
+	"github.com/moby/moby/api/types/container"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+// providerStateDirectory is where the last known state of provider services is persisted, so
+// they can be listed by `compose ps` while they have no container
+const providerStateDirectory = "compose/providers/state"
+
+const (
+	providerStatusRunning = "running"
+	providerStatusStopped = "stopped"
+	providerStatusFailed  = "failed"
+)
+
+// providerState is the last known state of a provider service
+type providerState struct {
+	Type    string    `json:"type"`
+	Status  string    `json:"status"`
+	Created time.Time `json:"created"`
+}
+
+// providerKey identifies the project provider services belong to when persisting their state and
+// logs. As provider services have no container, projects sharing a name are told apart by the docker
+// context and the project working directory.
+type providerKey struct {
+	context     string
+	projectName string
+	// workingDir is the project working directory, unknown when running with just a project name
+	workingDir string
+}
+
+func (s *composeService) providerKey(projectName string, project *types.Project) providerKey {
+	key := providerKey{context: s.dockerCli.CurrentContext(), projectName: projectName}
+	if project != nil {
+		key.workingDir = project.WorkingDir
+	}
+	return key
+}
+
+func (k providerKey) projectDir(directory string) string {
+	return filepath.Join(config.Dir(), directory, fmt.Sprintf("%x", sha256.Sum256([]byte(k.context))), k.projectName)
+}
+
+// dir returns the directory under directory where files for the project provider services are persisted
+func (k providerKey) dir(directory string) string {
+	return filepath.Join(k.projectDir(directory), fmt.Sprintf("%x", sha256.Sum256([]byte(k.workingDir))))
+}
+
+// dirs returns the directories under directory where files for the project provider services are
+// persisted. With an unknown working directory, all the directories the project was run from are
+// considered.
+func (k providerKey) dirs(directory string) ([]string, error) {
+	if k.workingDir != "" {
+		return []string{k.dir(directory)}, nil
+	}
+	entries, err := os.ReadDir(k.projectDir(directory))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(k.projectDir(directory), entry.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+func providerStateFile(key providerKey, serviceName string) string {
+	return filepath.Join(key.dir(providerStateDirectory), serviceName+".json")
+}
+
+// recordProviderState updates the persisted state of a provider service after running command.
+// As state is not critical for the main flow, errors are only reported in debug logs.
+func recordProviderState(key providerKey, service types.ServiceConfig, command string, cmdErr error) {
+	path := providerStateFile(key, service.Name)
+	if command == "down" {
+		if cmdErr == nil {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				logrus.Debugf("failed to remove provider state: %v", err)
+			}
+		}
+		return
+	}
+
+	state, _ := readProviderState(path)
+	state.Type = service.Provider.Type
+	switch {
+	case cmdErr != nil:
+		state.Status = providerStatusFailed
+	case command == "stop":
+		state.Status = providerStatusStopped
+	default:
+		state.Status = providerStatusRunning
+	}
+	if command == "up" {
+		state.Created = time.Now()
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		logrus.Debugf("failed to encode provider state: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		logrus.Debugf("failed to create provider state directory: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		logrus.Debugf("failed to save provider state: %v", err)
+	}
+}
+
+func readProviderState(path string) (providerState, error) {
+	var state providerState
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(b, &state)
+	return state, err
+}
+
+// providerSummaries returns a summary for provider services of the project with a persisted state.
+// When no service is selected, all provider services with a persisted state are considered. Like
+// stopped containers, provider services which are not running are only included when all is set.
+func providerSummaries(key providerKey, services []string, all bool) ([]api.ContainerSummary, error) {
+	dirs, err := key.dirs(providerStateDirectory)
+	if err != nil {
+		return nil, err
+	}
+	var summaries []api.ContainerSummary
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			service, ok := strings.CutSuffix(entry.Name(), ".json")
+			if !ok || entry.IsDir() {
+				continue
+			}
+			if len(services) > 0 && !slices.Contains(services, service) {
+				continue
+			}
+			state, err := readProviderState(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				logrus.Debugf("failed to read provider state for service %q: %v", service, err)
+				continue
+			}
+			summary := state.summary(key.projectName, service)
+			if !all && summary.State != container.StateRunning {
+				continue
+			}
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// summary describes a provider service as a container would be, using a synthetic state
+func (p providerState) summary(projectName, service string) api.ContainerSummary {
+	labels := map[string]string{
+		api.ProjectLabel: projectName,
+		api.ServiceLabel: service,
+	}
+
+	var (
+		state  container.ContainerState
+		health container.HealthStatus
+	)
+	switch p.Status {
+	case providerStatusRunning:
+		state, health = container.StateRunning, container.Healthy
+	case providerStatusStopped:
+		state = container.StateExited
+	default:
+		state, health = container.StateDead, container.Unhealthy
+	}
+
+	return api.ContainerSummary{
+		Name:    projectName + api.Separator + service,
+		Image:   p.Type,
+		Project: projectName,
+		Service: service,
+		Created: p.Created.Unix(),
+		State:   state,
+		Status:  p.Status + " (external)",
+		Health:  health,
+		Labels:  labels,
+	}
+}
@@ -0,0 +1,99 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/cli/cli/config"
+	"github.com/moby/moby/api/types/container"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestProviderSummaries(t *testing.T) {
+	previous := config.Dir()
+	config.SetDir(t.TempDir())
+	t.Cleanup(func() { config.SetDir(previous) })
+
+	database := types.ServiceConfig{Name: "database", Provider: &types.ServiceProviderConfig{Type: "awesomecloud"}}
+	queue := types.ServiceConfig{Name: "queue", Provider: &types.ServiceProviderConfig{Type: "awesomequeue"}}
+
+	key := providerKey{context: "default", projectName: "myproject", workingDir: "/src/myproject"}
+	recordProviderState(key, database, "up", nil)
+	recordProviderState(key, queue, "up", errors.New("quota exceeded"))
+
+	summaries, err := providerSummaries(key, nil, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 2)
+
+	db := summaries[0]
+	assert.Equal(t, db.Name, "myproject-database")
+	assert.Equal(t, db.Service, "database")
+	assert.Equal(t, db.Image, "awesomecloud")
+	assert.Equal(t, db.State, container.StateRunning)
+	assert.Equal(t, db.Status, "running (external)")
+	assert.Equal(t, db.Health, container.Healthy)
+	assert.Equal(t, db.Labels[api.ProjectLabel], "myproject")
+	assert.DeepEqual(t, db.Labels, map[string]string{api.ProjectLabel: "myproject", api.ServiceLabel: "database"})
+
+	assert.Equal(t, summaries[1].State, container.StateDead)
+	assert.Equal(t, summaries[1].Health, container.Unhealthy)
+
+	// like stopped containers, failed provider services require all
+	summaries, err = providerSummaries(key, nil, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 1)
+	assert.Equal(t, summaries[0].Service, "database")
+
+	recordProviderState(key, database, "stop", nil)
+	summaries, err = providerSummaries(key, []string{"database"}, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 0)
+	summaries, err = providerSummaries(key, []string{"database"}, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 1)
+	assert.Equal(t, summaries[0].State, container.StateExited)
+	assert.Equal(t, summaries[0].Status, "stopped (external)")
+
+	// a project with the same name in another directory or on another context has its own state
+	other := providerKey{context: "default", projectName: "myproject", workingDir: "/src/other"}
+	recordProviderState(other, database, "up", nil)
+	remote := providerKey{context: "remote", projectName: "myproject", workingDir: "/src/myproject"}
+	recordProviderState(remote, database, "up", nil)
+	summaries, err = providerSummaries(key, []string{"database"}, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 1)
+	assert.Equal(t, summaries[0].State, container.StateExited)
+
+	// with just a project name, all directories it was run from are considered
+	summaries, err = providerSummaries(providerKey{context: "default", projectName: "myproject"}, []string{"database"}, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 2)
+
+	recordProviderState(key, database, "down", nil)
+	summaries, err = providerSummaries(key, []string{"database"}, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 0)
+
+	summaries, err = providerSummaries(providerKey{context: "default", projectName: "unknown"}, nil, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 0)
+}
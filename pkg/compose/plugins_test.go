@@ -18,9 +18,17 @@ package compose
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/compose-spec/compose-go/v2/types"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
 )
 
 func TestProviderMetadata_IsEmpty(t *testing.T) {
@@ -105,3 +113,331 @@ func TestProviderMetadata_StopAdvertisedWithoutParameters(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Assert(t, metadata.Stop != nil, "Stop should be non-nil when key present even with null parameters")
 }
+
+func TestProviderMetadata_OptionalCommand(t *testing.T) {
+	raw := `{"up":{"parameters":[]},"down":{"parameters":[]},"start":{"parameters":[{"name":"a"}]},"restart":{"parameters":null}}`
+
+	var metadata ProviderMetadata
+	err := json.Unmarshal([]byte(raw), &metadata)
+	assert.NilError(t, err)
+	assert.Assert(t, metadata.optionalCommand("stop") == nil, "stop is not advertised")
+	assert.Equal(t, metadata.optionalCommand("start").Parameters[0].Name, "a")
+	assert.Assert(t, metadata.optionalCommand("restart") != nil, "restart is advertised without parameters")
+	assert.Assert(t, metadata.optionalCommand("up") == nil, "up is not an optional command")
+}
+
+func TestProviderPathLookupEnabled(t *testing.T) {
+	t.Setenv(ProviderPathLookupEnabled, "")
+	enabled, err := providerPathLookupEnabled()
+	assert.NilError(t, err)
+	assert.Assert(t, !enabled, "PATH lookup should be disabled by default")
+
+	t.Setenv(ProviderPathLookupEnabled, "true")
+	enabled, err = providerPathLookupEnabled()
+	assert.NilError(t, err)
+	assert.Assert(t, enabled)
+
+	t.Setenv(ProviderPathLookupEnabled, "false")
+	enabled, err = providerPathLookupEnabled()
+	assert.NilError(t, err)
+	assert.Assert(t, !enabled)
+
+	t.Setenv(ProviderPathLookupEnabled, "not-a-bool")
+	_, err = providerPathLookupEnabled()
+	assert.ErrorContains(t, err, "expects boolean value")
+}
+
+func TestProviderDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		options types.MultiOptions
+		want    time.Duration
+		wantErr string
+	}{
+		{
+			name: "no timeout option",
+			want: 0,
+		},
+		{
+			name:    "valid duration",
+			options: types.MultiOptions{"timeout": {"90s"}},
+			want:    90 * time.Second,
+		},
+		{
+			name:    "last value wins",
+			options: types.MultiOptions{"timeout": {"10s", "2m"}},
+			want:    2 * time.Minute,
+		},
+		{
+			name:    "invalid duration",
+			options: types.MultiOptions{"timeout": {"forever"}},
+			wantErr: `invalid timeout option for provider "cloud"`,
+		},
+		{
+			name:    "negative duration",
+			options: types.MultiOptions{"timeout": {"-1s"}},
+			wantErr: "must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			timeout, err := providerDuration(types.ServiceProviderConfig{Type: "cloud", Options: tc.options}, "timeout", 0)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, timeout, tc.want)
+		})
+	}
+}
+
+func TestInjectProviderVariables(t *testing.T) {
+	existing := "user-defined"
+	project := &types.Project{
+		Services: types.Services{
+			"database": {Name: "database", Environment: types.MappingWithEquals{}},
+			"api": {
+				Name:        "api",
+				DependsOn:   types.DependsOnConfig{"database": {}},
+				Environment: types.MappingWithEquals{"SECRET": &existing},
+			},
+			"web": {
+				Name:        "web",
+				DependsOn:   types.DependsOnConfig{"api": {}},
+				Environment: types.MappingWithEquals{},
+			},
+			"other": {Name: "other", Environment: types.MappingWithEquals{}},
+		},
+	}
+
+	injectProviderVariables(project, "database", "DATABASE_", pluginVariables{
+		prefixed: types.Mapping{"URL": "https://magic.cloud/db"},
+		raw:      types.Mapping{"SECRET": "xxx"},
+	})
+
+	for _, name := range []string{"api", "web"} {
+		env := project.Services[name].Environment
+		assert.Equal(t, *env["DATABASE_URL"], "https://magic.cloud/db", name)
+		assert.Equal(t, *env["SECRET"], "xxx", name)
+	}
+	assert.Equal(t, len(project.Services["database"].Environment), 0)
+	assert.Equal(t, len(project.Services["other"].Environment), 0)
+}
+
+func TestTransitiveDependents(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			"database": {Name: "database"},
+			"api":      {Name: "api", DependsOn: types.DependsOnConfig{"database": {}}},
+			"worker":   {Name: "worker", DependsOn: types.DependsOnConfig{"database": {}, "api": {}}},
+			"web":      {Name: "web", DependsOn: types.DependsOnConfig{"api": {}}},
+			"other":    {Name: "other"},
+		},
+	}
+	assert.DeepEqual(t, transitiveDependents(project, "database"), []string{"api", "web", "worker"})
+	assert.DeepEqual(t, transitiveDependents(project, "api"), []string{"web", "worker"})
+	assert.Equal(t, len(transitiveDependents(project, "web")), 0)
+}
+
+func TestProviderEnvPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		options types.MultiOptions
+		want    string
+		wantErr string
+	}{
+		{
+			name: "derived from service name",
+			want: "MY-DB_",
+		},
+		{
+			name:    "custom prefix",
+			options: types.MultiOptions{"env_prefix": {"DB_"}},
+			want:    "DB_",
+		},
+		{
+			name:    "no prefix",
+			options: types.MultiOptions{"env_prefix": {""}},
+			want:    "",
+		},
+		{
+			name:    "invalid prefix",
+			options: types.MultiOptions{"env_prefix": {"1; rm"}},
+			wantErr: `"1; rm" is not a valid environment variable prefix`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, err := providerEnvPrefix(types.ServiceConfig{
+				Name:     "my-db",
+				Provider: &types.ServiceProviderConfig{Type: "cloud", Options: tc.options},
+			})
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, prefix, tc.want)
+		})
+	}
+}
+
+func TestProviderRetryPolicy(t *testing.T) {
+	policy, err := providerRetryPolicy(types.ServiceProviderConfig{Type: "cloud"})
+	assert.NilError(t, err)
+	assert.Equal(t, policy, retryPolicy{retries: 0, interval: time.Second})
+
+	policy, err = providerRetryPolicy(types.ServiceProviderConfig{Type: "cloud", Options: types.MultiOptions{
+		"retries":        {"3"},
+		"retry_interval": {"2s"},
+	}})
+	assert.NilError(t, err)
+	assert.Equal(t, policy, retryPolicy{retries: 3, interval: 2 * time.Second})
+
+	_, err = providerRetryPolicy(types.ServiceProviderConfig{Type: "cloud", Options: types.MultiOptions{"retries": {"-1"}}})
+	assert.ErrorContains(t, err, `invalid retries option for provider "cloud": expected a positive integer, got "-1"`)
+
+	_, err = providerRetryPolicy(types.ServiceProviderConfig{Type: "cloud", Options: types.MultiOptions{"retry_interval": {"soon"}}})
+	assert.ErrorContains(t, err, "invalid retry_interval option")
+}
+
+func TestValidateProviderOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options types.MultiOptions
+		wantErr string
+	}{
+		{
+			name:    "valid options",
+			options: types.MultiOptions{"type": {"mysql"}, "size_gb": {"10"}, "db.name-1": {"my db; with spaces"}},
+		},
+		{
+			name:    "shell metacharacters in key",
+			options: types.MultiOptions{"; rm -rf": {"x"}},
+			wantErr: `invalid option "; rm -rf" for provider "cloud"`,
+		},
+		{
+			name:    "space in key",
+			options: types.MultiOptions{"db name": {"x"}},
+			wantErr: `invalid option "db name" for provider "cloud"`,
+		},
+		{
+			name:    "leading dash in key",
+			options: types.MultiOptions{"-type": {"x"}},
+			wantErr: `invalid option "-type" for provider "cloud"`,
+		},
+		{
+			name:    "newline in value",
+			options: types.MultiOptions{"name": {"db\n--admin=true"}},
+			wantErr: `invalid value for option "name" of provider "cloud": control characters are not allowed`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateProviderOptions(types.ServiceProviderConfig{Type: "cloud", Options: tc.options})
+			if tc.wantErr == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestJsonMessageMergeVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    types.Mapping
+		wantErr string
+	}{
+		{
+			name:    "single KEY=VALUE message",
+			message: `{"type":"setenv","message":"URL=https://magic.cloud/db?a=b"}`,
+			want:    types.Mapping{"URL": "https://magic.cloud/db?a=b"},
+		},
+		{
+			name:    "variables",
+			message: `{"type":"setenv","variables":{"URL":"https://magic.cloud/db","USER":"admin"}}`,
+			want:    types.Mapping{"URL": "https://magic.cloud/db", "USER": "admin"},
+		},
+		{
+			name:    "both forms",
+			message: `{"type":"rawsetenv","message":"TOKEN=xxx","variables":{"USER":"admin"}}`,
+			want:    types.Mapping{"TOKEN": "xxx", "USER": "admin"},
+		},
+		{
+			name:    "invalid message",
+			message: `{"type":"setenv","message":"URL"}`,
+			wantErr: "invalid response from plugin: URL",
+		},
+		{
+			name:    "no variables",
+			message: `{"type":"setenv"}`,
+			wantErr: "invalid response from plugin: setenv message without variables",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var msg JsonMessage
+			assert.NilError(t, json.Unmarshal([]byte(tc.message), &msg))
+			variables := types.Mapping{}
+			err := msg.mergeVariables(variables)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, variables, tc.want)
+		})
+	}
+}
+
+func TestProviderMetadata_RequiresDesktop(t *testing.T) {
+	var metadata ProviderMetadata
+	err := json.Unmarshal([]byte(`{"description":"x"}`), &metadata)
+	assert.NilError(t, err)
+	assert.Assert(t, !metadata.RequiresDesktop, "Docker Desktop should not be required by default")
+
+	err = json.Unmarshal([]byte(`{"description":"x","requires_desktop":true}`), &metadata)
+	assert.NilError(t, err)
+	assert.Assert(t, metadata.RequiresDesktop)
+}
+
+func TestRecordProviderSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("")
+
+	_, span := tracer.Start(t.Context(), "provider/awesomecloud/up")
+	recordProviderSpan(span, pluginVariables{
+		prefixed: types.Mapping{"URL": "https://magic.cloud/db"},
+		raw:      types.Mapping{"SECRET_KEY": "xxx"},
+	}, nil)
+	span.End()
+
+	_, span = tracer.Start(t.Context(), "provider/awesomecloud/down")
+	recordProviderSpan(span, pluginVariables{}, &api.ProviderError{Service: "db", Action: "down", ExitCode: 3, Err: errors.New("exit status 3")})
+	span.End()
+
+	spans := recorder.Ended()
+	assert.Equal(t, len(spans), 2)
+	assert.Equal(t, spans[0].Status().Code, codes.Ok)
+	assert.DeepEqual(t, spanAttributes(spans[0]), map[string]string{"provider.variables": "2", "provider.exit_code": "0"})
+	assert.Equal(t, spans[1].Status().Code, codes.Error)
+	assert.DeepEqual(t, spanAttributes(spans[1]), map[string]string{"provider.variables": "0", "provider.exit_code": "3"})
+	assert.Equal(t, len(spans[1].Events()), 1)
+	assert.Equal(t, spans[1].Events()[0].Name, "exception")
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[string]string {
+	attrs := map[string]string{}
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	return attrs
+}
@@ -0,0 +1,233 @@
+//go:build !windows
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/cli/cli/config"
+	"github.com/jonboulle/clockwork"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+// fakePlugin returns a command writing the given JSON lines to stdout, as a provider plugin would do
+func fakePlugin(t *testing.T, lines string) *exec.Cmd {
+	t.Helper()
+	return exec.CommandContext(t.Context(), "sh", "-c", "printf '%s' \"$0\"", lines)
+}
+
+func TestExecutePluginWarning(t *testing.T) {
+	events := &capturingEvents{}
+	s := &composeService{events: events}
+	logs := &bytes.Buffer{}
+
+	cmd := fakePlugin(t, `{"type":"warning","message":"using a deprecated region"}
+{"type":"setenv","message":"URL=https://magic.cloud/db"}
+`)
+	variables, err := s.executePlugin(cmd, "up", types.ServiceConfig{Name: "db"}, logs)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, variables.prefixed, types.Mapping{"URL": "https://magic.cloud/db"})
+	assert.Equal(t, logs.String(), "using a deprecated region\n")
+
+	assert.Equal(t, len(events.resources), 3)
+	assert.Equal(t, events.resources[1].Status, api.Warning)
+	assert.Equal(t, events.resources[1].Text, api.StatusWarning)
+	assert.Equal(t, events.resources[1].Details, "using a deprecated region")
+	assert.Equal(t, events.resources[2].Status, api.Done)
+}
+
+func TestExecutePluginInfo(t *testing.T) {
+	events := &capturingEvents{}
+	s := &composeService{events: events}
+	logs := &bytes.Buffer{}
+
+	cmd := fakePlugin(t, `{"type":"info","message":"database is being provisioned"}
+{"type":"progress","percent":40,"message":"provisioning"}
+`)
+	_, err := s.executePlugin(cmd, "up", types.ServiceConfig{Name: "db"}, logs)
+	assert.NilError(t, err)
+	assert.Equal(t, logs.String(), "database is being provisioned\n")
+
+	assert.Equal(t, len(events.resources), 4)
+	// info messages are reported as details of an Info status, so they are not mistaken for progress
+	assert.DeepEqual(t, events.resources[1], api.Resource{ID: "db", Status: api.Working, Text: api.StatusInfo, Details: "database is being provisioned"})
+	assert.DeepEqual(t, events.resources[2], api.Resource{ID: "db", Status: api.Working, Text: "provisioning", Details: "40%", Percent: 40})
+}
+
+func TestExecutePluginUnknownType(t *testing.T) {
+	s := &composeService{events: &capturingEvents{}}
+	cmd := fakePlugin(t, `{"type":"notice","message":"hello"}`)
+	_, err := s.executePlugin(cmd, "up", types.ServiceConfig{Name: "db"}, &bytes.Buffer{})
+	assert.Error(t, err, "invalid response from plugin: notice")
+}
+
+func TestExecutePluginProgress(t *testing.T) {
+	events := &capturingEvents{}
+	s := &composeService{events: events}
+
+	cmd := fakePlugin(t, `{"type":"progress","percent":40,"message":"provisioning"}
+{"type":"progress","percent":150}
+{"type":"progress","percent":"much"}
+{"type":"progress","percent":-3.5,"message":"rolling back"}
+`)
+	_, err := s.executePlugin(cmd, "up", types.ServiceConfig{Name: "db"}, &bytes.Buffer{})
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(events.resources), 5)
+	assert.DeepEqual(t, events.resources[1], api.Resource{ID: "db", Status: api.Working, Text: "provisioning", Details: "40%", Percent: 40})
+	assert.DeepEqual(t, events.resources[2], api.Resource{ID: "db", Status: api.Working, Text: api.StatusCreating, Details: "100%", Percent: 100})
+	assert.DeepEqual(t, events.resources[3], api.Resource{ID: "db", Status: api.Working, Text: "rolling back", Details: "0%", Percent: 0})
+	assert.Equal(t, events.resources[4].Status, api.Done)
+}
+
+func TestExecutePluginStartRestart(t *testing.T) {
+	for command, texts := range map[string][2]string{
+		"start":   {api.StatusStarting, api.StatusStarted},
+		"restart": {api.StatusRestarting, api.StatusRestarted},
+	} {
+		t.Run(command, func(t *testing.T) {
+			events := &capturingEvents{}
+			s := &composeService{events: events}
+			_, err := s.executePlugin(fakePlugin(t, ""), command, types.ServiceConfig{Name: "db"}, &bytes.Buffer{})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, events.resources, []api.Resource{
+				{ID: "db", Status: api.Working, Text: texts[0]},
+				{ID: "db", Status: api.Done, Text: texts[1]},
+			})
+		})
+	}
+}
+
+// flakyPlugin returns the setup function of a command failing until it has been run the given number of
+// times, and the number of commands it created
+func flakyPlugin(t *testing.T, failures int) (func() (*exec.Cmd, error), *int) {
+	t.Helper()
+	counter := filepath.Join(t.TempDir(), "attempts")
+	script := `n=$(cat "$0" 2>/dev/null || echo 0); n=$((n+1)); echo $n > "$0"
+if [ $n -le "$1" ]; then echo '{"type":"error","message":"transient failure"}'; exit 1; fi
+echo '{"type":"setenv","message":"URL=https://magic.cloud/db"}'`
+	created := 0
+	return func() (*exec.Cmd, error) {
+		created++
+		return exec.CommandContext(t.Context(), "sh", "-c", script, counter, fmt.Sprint(failures)), nil
+	}, &created
+}
+
+// executeFlakyPlugin runs the command created by setup with the given retry policy
+func executeFlakyPlugin(t *testing.T, s *composeService, setup func() (*exec.Cmd, error), retry retryPolicy) (pluginVariables, error) {
+	t.Helper()
+	cmd, err := setup()
+	assert.NilError(t, err)
+	return s.executePluginWithRetry(t.Context(), cmd, setup, "up", types.ServiceConfig{Name: "db"}, &bytes.Buffer{}, retry)
+}
+
+func TestExecutePluginWithRetry(t *testing.T) {
+	events := &capturingEvents{}
+	s := &composeService{events: events, clock: clockwork.NewRealClock()}
+
+	setup, created := flakyPlugin(t, 2)
+	variables, err := executeFlakyPlugin(t, s, setup, retryPolicy{retries: 3, interval: time.Millisecond})
+	assert.NilError(t, err)
+	assert.Equal(t, variables.prefixed["URL"], "https://magic.cloud/db")
+	assert.Equal(t, *created, 3, "each attempt must run a new command")
+
+	var retrying []string
+	for _, e := range events.resources {
+		if e.Text == "Retrying" {
+			retrying = append(retrying, e.Details)
+		}
+	}
+	assert.DeepEqual(t, retrying, []string{
+		"attempt 1/4 failed, retrying in 1ms",
+		"attempt 2/4 failed, retrying in 2ms",
+	})
+
+	setup, _ = flakyPlugin(t, 5)
+	_, err = executeFlakyPlugin(t, s, setup, retryPolicy{retries: 2, interval: time.Millisecond})
+	assert.Error(t, err, "transient failure (after 3 attempts)")
+
+	setup, _ = flakyPlugin(t, 1)
+	_, err = executeFlakyPlugin(t, s, setup, retryPolicy{})
+	assert.Error(t, err, "transient failure")
+}
+
+// metadataPlugin writes a provider executable reporting the given metadata
+func metadataPlugin(t *testing.T, metadata string) string {
+	t.Helper()
+	previous := config.Dir()
+	config.SetDir(t.TempDir())
+	t.Cleanup(func() { config.SetDir(previous) })
+
+	path := filepath.Join(t.TempDir(), "provider")
+	script := fmt.Sprintf("#!/bin/sh\nif [ \"$2\" = metadata ]; then echo '%s'; fi\n", metadata)
+	assert.NilError(t, os.WriteFile(path, []byte(script), 0o700))
+	return path
+}
+
+func TestSetupPluginCommandDryRun(t *testing.T) {
+	project := &types.Project{Name: "myproject"}
+	service := types.ServiceConfig{
+		Name:     "db",
+		Provider: &types.ServiceProviderConfig{Type: "cloud", Options: types.MultiOptions{"size": {"10"}}},
+	}
+
+	t.Run("provider supports dry-run", func(t *testing.T) {
+		path := metadataPlugin(t, `{"up":{"parameters":[{"name":"size"},{"name":"dry-run","type":"boolean"}]}}`)
+		s := &composeService{events: &capturingEvents{}, dryRun: true}
+		cmd, err := s.setupPluginCommand(t.Context(), project, service, path, "up")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, cmd.Args[1:], []string{"compose", "--project-name=myproject", "up", "--dry-run", "--size=10", "db"})
+	})
+
+	t.Run("provider doesn't support dry-run", func(t *testing.T) {
+		path := metadataPlugin(t, `{"up":{"parameters":[{"name":"size"}]}}`)
+		events := &capturingEvents{}
+		s := &composeService{events: events, dryRun: true}
+		cmd, err := s.setupPluginCommand(t.Context(), project, service, path, "up")
+		assert.NilError(t, err)
+		assert.Assert(t, cmd == nil, "provider must not run")
+		assert.Equal(t, len(events.resources), 1)
+		assert.Equal(t, events.resources[0].Text, "Skipped: provider doesn't support dry-run")
+	})
+}
+
+func TestExecutePluginExitCode(t *testing.T) {
+	s := &composeService{events: &capturingEvents{}}
+	cmd := exec.CommandContext(t.Context(), "sh", "-c", "exit 3")
+	_, err := s.executePlugin(cmd, "down", types.ServiceConfig{Name: "db"}, &bytes.Buffer{})
+	assert.Error(t, err, "failed to remove service provider: exit status 3")
+
+	var providerErr *api.ProviderError
+	assert.Assert(t, errors.As(err, &providerErr))
+	assert.Equal(t, providerErr.Service, "db")
+	assert.Equal(t, providerErr.ExitCode, 3)
+
+	var exitErr *exec.ExitError
+	assert.Assert(t, errors.As(err, &exitErr))
+}
@@ -19,9 +19,12 @@ package compose
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
 
 	"github.com/docker/compose/v5/pkg/api"
 )
@@ -40,6 +43,77 @@ func (s *composeService) Port(ctx context.Context, projectName string, service s
 	return "", 0, portNotFoundError(options.Protocol, port, ctr)
 }
 
+func (s *composeService) Ports(ctx context.Context, projectName string, service string, options api.PortOptions) ([]api.ContainerPortPublisher, error) {
+	projectName = strings.ToLower(projectName)
+	containers, err := s.getContainers(ctx, projectName, oneOffExclude, false, service)
+	if err != nil {
+		return nil, err
+	}
+	if options.Index > 0 {
+		containers = containers.filter(func(c container.Summary) bool {
+			return c.Labels[api.ContainerNumberLabel] == strconv.Itoa(options.Index)
+		})
+		if len(containers) == 0 {
+			return nil, s.missingContainerIndexError(ctx, projectName, oneOffExclude, false, service, options.Index)
+		}
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("service %q is not running", service)
+	}
+
+	var ports []api.ContainerPortPublisher
+	for _, ctr := range containers {
+		res, err := s.apiClient().ContainerInspect(ctx, ctr.ID, client.ContainerInspectOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if res.Container.NetworkSettings == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(ctr.Labels[api.ContainerNumberLabel])
+		for port, bindings := range res.Container.NetworkSettings.Ports {
+			if options.Protocol != "" && string(port.Proto()) != options.Protocol {
+				continue
+			}
+			for _, binding := range bindings {
+				published, err := strconv.Atoi(binding.HostPort)
+				if err != nil {
+					continue
+				}
+				var url string
+				if binding.HostIP.IsValid() {
+					url = binding.HostIP.String()
+				}
+				ports = append(ports, api.ContainerPortPublisher{
+					PortPublisher: api.PortPublisher{
+						URL:           url,
+						TargetPort:    int(port.Num()),
+						PublishedPort: published,
+						Protocol:      string(port.Proto()),
+					},
+					Container: getCanonicalContainerName(ctr),
+					Number:    number,
+				})
+			}
+		}
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		left, right := ports[i], ports[j]
+		if left.Number != right.Number {
+			return left.Number < right.Number
+		}
+		if left.TargetPort != right.TargetPort {
+			return left.TargetPort < right.TargetPort
+		}
+		if left.Protocol != right.Protocol {
+			return left.Protocol < right.Protocol
+		}
+		return left.URL < right.URL
+	})
+	return ports, nil
+}
+
 func portNotFoundError(protocol string, port uint16, ctr container.Summary) error {
 	formatPort := func(protocol string, port uint16) string {
 		return fmt.Sprintf("%d/%s", port, protocol)
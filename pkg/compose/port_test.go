@@ -0,0 +1,109 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestPorts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	web1 := portsTestContainer("web", 1)
+	web2 := portsTestContainer("web", 2)
+	listOpts := client.ContainerListOptions{
+		Filters: projectFilter(strings.ToLower(testProject)).Add("label", serviceFilter("web"), api.ConfigHashLabel, oneOffFilter(false)),
+	}
+	apiClient.EXPECT().ContainerList(gomock.Any(), listOpts).Return(client.ContainerListResult{
+		Items: []container.Summary{web2, web1},
+	}, nil).Times(4)
+	apiClient.EXPECT().ContainerInspect(gomock.Any(), web1.ID, gomock.Any()).Return(client.ContainerInspectResult{
+		Container: container.InspectResponse{
+			NetworkSettings: &container.NetworkSettings{
+				Ports: network.PortMap{
+					network.MustParsePort("80/tcp"):   {{HostIP: netip.MustParseAddr("0.0.0.0"), HostPort: "8080"}},
+					network.MustParsePort("53/udp"):   {{HostIP: netip.MustParseAddr("127.0.0.1"), HostPort: "5353"}},
+					network.MustParsePort("9000/tcp"): nil,
+				},
+			},
+		},
+	}, nil).Times(2)
+	apiClient.EXPECT().ContainerInspect(gomock.Any(), web2.ID, gomock.Any()).Return(client.ContainerInspectResult{
+		Container: container.InspectResponse{
+			NetworkSettings: &container.NetworkSettings{
+				Ports: network.PortMap{
+					network.MustParsePort("80/tcp"): {{HostIP: netip.MustParseAddr("0.0.0.0"), HostPort: "8081"}},
+				},
+			},
+		},
+	}, nil)
+
+	ports, err := tested.Ports(t.Context(), testProject, "web", api.PortOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ports, []api.ContainerPortPublisher{
+		{
+			PortPublisher: api.PortPublisher{URL: "127.0.0.1", TargetPort: 53, PublishedPort: 5353, Protocol: "udp"},
+			Container:     "web-1",
+			Number:        1,
+		},
+		{
+			PortPublisher: api.PortPublisher{URL: "0.0.0.0", TargetPort: 80, PublishedPort: 8080, Protocol: "tcp"},
+			Container:     "web-1",
+			Number:        1,
+		},
+		{
+			PortPublisher: api.PortPublisher{URL: "0.0.0.0", TargetPort: 80, PublishedPort: 8081, Protocol: "tcp"},
+			Container:     "web-2",
+			Number:        2,
+		},
+	})
+
+	ports, err = tested.Ports(t.Context(), testProject, "web", api.PortOptions{Protocol: "tcp", Index: 1})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ports, []api.ContainerPortPublisher{
+		{
+			PortPublisher: api.PortPublisher{URL: "0.0.0.0", TargetPort: 80, PublishedPort: 8080, Protocol: "tcp"},
+			Container:     "web-1",
+			Number:        1,
+		},
+	})
+
+	_, err = tested.Ports(t.Context(), testProject, "web", api.PortOptions{Index: 3})
+	assert.Error(t, err, `service "web" is not running container #3, available indices: 1, 2`)
+}
+
+func portsTestContainer(service string, number int) container.Summary {
+	ctr := testContainer(service, service+"-"+strconv.Itoa(number), false)
+	ctr.Labels[api.ContainerNumberLabel] = strconv.Itoa(number)
+	return ctr
+}
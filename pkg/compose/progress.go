@@ -47,6 +47,37 @@ func errorEventf(id string, msg string, args ...any) api.Resource {
 	return errorEvent(id, fmt.Sprintf(msg, args...))
 }
 
+// warningEvent creates a new Warning Resource with message
+func warningEvent(id string, msg string) api.Resource {
+	return api.Resource{
+		ID:      id,
+		Status:  api.Warning,
+		Text:    api.StatusWarning,
+		Details: msg,
+	}
+}
+
+// infoEvent creates a new in progress Info Resource with message
+func infoEvent(id string, msg string) api.Resource {
+	return api.Resource{
+		ID:      id,
+		Status:  api.Working,
+		Text:    api.StatusInfo,
+		Details: msg,
+	}
+}
+
+// progressEvent creates a new in progress Resource reporting a completion percentage
+func progressEvent(id string, text string, percent int) api.Resource {
+	return api.Resource{
+		ID:      id,
+		Status:  api.Working,
+		Text:    text,
+		Details: fmt.Sprintf("%d%%", percent),
+		Percent: percent,
+	}
+}
+
 // creatingEvent creates a new Create in progress Resource
 func creatingEvent(id string) api.Resource {
 	return newEvent(id, api.Working, api.StatusCreating)
@@ -57,6 +88,26 @@ func createdEvent(id string) api.Resource {
 	return newEvent(id, api.Done, api.StatusCreated)
 }
 
+// startingEvent creates a new Starting in progress Resource
+func startingEvent(id string) api.Resource {
+	return newEvent(id, api.Working, api.StatusStarting)
+}
+
+// startedEvent creates a new Started (done) Resource
+func startedEvent(id string) api.Resource {
+	return newEvent(id, api.Done, api.StatusStarted)
+}
+
+// restartingEvent creates a new Restarting in progress Resource
+func restartingEvent(id string) api.Resource {
+	return newEvent(id, api.Working, api.StatusRestarting)
+}
+
+// restartedEvent creates a new Restarted (done) Resource
+func restartedEvent(id string) api.Resource {
+	return newEvent(id, api.Done, api.StatusRestarted)
+}
+
 // stoppingEvent creates a new Stopping in progress Resource
 func stoppingEvent(id string) api.Resource {
 	return newEvent(id, api.Working, api.StatusStopping)
@@ -130,5 +130,14 @@ func (s *composeService) Ps(ctx context.Context, projectName string, options api
 			return nil
 		})
 	}
-	return summary, eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// provider services have no container, report their last known state
+	providers, err := providerSummaries(s.providerKey(projectName, options.Project), options.Services, options.All)
+	if err != nil {
+		return nil, err
+	}
+	return append(summary, providers...), nil
 }
@@ -46,7 +46,13 @@ import (
 
 func (s *composeService) Pull(ctx context.Context, project *types.Project, options api.PullOptions) error {
 	return Run(ctx, func(ctx context.Context) error {
-		return s.pull(ctx, project, options)
+		if err := s.pull(ctx, project, options); err != nil {
+			return err
+		}
+		if options.ImagesLock != nil {
+			return s.verifyImagesLock(ctx, project, options.ImagesLock)
+		}
+		return nil
 	}, "pull", s.events)
 }
 
@@ -57,7 +63,7 @@ func (s *composeService) pull(ctx context.Context, project *types.Project, opts
 	}
 
 	eg, ctx := errgroup.WithContext(ctx)
-	eg.SetLimit(s.maxConcurrency)
+	slots := newPullSlots(s.maxConcurrency)
 
 	var (
 		mustBuild         []string
@@ -115,7 +121,12 @@ func (s *composeService) pull(ctx context.Context, project *types.Project, opts
 
 		idx := i
 		eg.Go(func() error {
-			_, err := s.pullServiceImage(ctx, service, opts.Quiet, project.Environment["DOCKER_DEFAULT_PLATFORM"])
+			release, err := s.waitPullSlot(ctx, slots, service.Image)
+			if err != nil {
+				return err
+			}
+			defer release()
+			_, err = s.pullServiceImage(ctx, service, opts.Quiet, project.Environment["DOCKER_DEFAULT_PLATFORM"])
 			if err != nil {
 				pullErrors[idx] = err
 				if service.Build != nil {
@@ -173,6 +184,35 @@ func getUnwrappedErrorMessage(err error) string {
 	return err.Error()
 }
 
+// newPullSlots returns a semaphore limiting concurrent pulls to limit, or nil if pulls are not limited
+func newPullSlots(limit int) chan struct{} {
+	if limit <= 0 {
+		return nil
+	}
+	return make(chan struct{}, limit)
+}
+
+// waitPullSlot blocks until a pull slot is available, reporting image as queued while all slots are
+// in use. The returned function must be called to release the slot once the pull completes.
+func (s *composeService) waitPullSlot(ctx context.Context, slots chan struct{}, image string) (func(), error) {
+	if slots == nil {
+		return func() {}, nil
+	}
+	release := func() { <-slots }
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	s.events.On(newEvent("Image "+image, api.Working, api.StatusWaiting, fmt.Sprintf("Queued, %d pulls already in progress", cap(slots))))
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 func (s *composeService) pullServiceImage(ctx context.Context, service types.ServiceConfig, quietPull bool, defaultPlatform string) (string, error) {
 	resource := "Image " + service.Image
 	s.events.On(newEvent(resource, api.Working, api.StatusPulling))
@@ -214,19 +254,8 @@ func (s *composeService) pullServiceImage(ctx context.Context, service types.Ser
 		return "", nil
 	}
 
-	// check if it has an error and the service has a build section
-	// then the status should be warning instead of error
-	if err != nil && service.Build != nil {
-		s.events.On(api.Resource{
-			ID:     resource,
-			Status: api.Warning,
-			Text:   getUnwrappedErrorMessage(err),
-		})
-		return "", err
-	}
-
 	if err != nil {
-		s.events.On(errorEvent(resource, getUnwrappedErrorMessage(err)))
+		s.events.On(pullFailedEvent(resource, service, getUnwrappedErrorMessage(err)))
 		return "", err
 	}
 
@@ -240,8 +269,10 @@ func (s *composeService) pullServiceImage(ctx context.Context, service types.Ser
 			return "", err
 		}
 		if jm.Error != nil {
+			s.events.On(pullFailedEvent(resource, service, jm.Error.Message))
 			return "", errors.New(jm.Error.Message)
 		}
+		// with quietPull, only the Pulling/Pulled events and errors are reported, not layers progress
 		if !quietPull {
 			toPullProgressEvent(resource, jm, s.events)
 		}
@@ -255,6 +286,19 @@ func (s *composeService) pullServiceImage(ctx context.Context, service types.Ser
 	return inspected.ID, nil
 }
 
+// pullFailedEvent reports a failure to pull the image of service. When the service has a build section,
+// the image can still be built, so the status is a warning instead of an error.
+func pullFailedEvent(resource string, service types.ServiceConfig, message string) api.Resource {
+	if service.Build != nil {
+		return api.Resource{
+			ID:     resource,
+			Status: api.Warning,
+			Text:   message,
+		}
+	}
+	return errorEvent(resource, message)
+}
+
 // ImageDigestResolver creates a func able to resolve image digest from a docker ref,
 func ImageDigestResolver(ctx context.Context, file *configfile.ConfigFile, apiClient client.APIClient) func(named reference.Named) (digest.Digest, error) {
 	return func(named reference.Named) (digest.Digest, error) {
@@ -319,11 +363,16 @@ func (s *composeService) pullRequiredImages(ctx context.Context, project *types.
 	}
 
 	eg, ctx := errgroup.WithContext(ctx)
-	eg.SetLimit(s.maxConcurrency)
+	slots := newPullSlots(s.maxConcurrency)
 	pulledImages := map[string]api.ImageSummary{}
 	var mutex sync.Mutex
 	for name, service := range needPull {
 		eg.Go(func() error {
+			release, err := s.waitPullSlot(ctx, slots, service.Image)
+			if err != nil {
+				return err
+			}
+			defer release()
 			id, err := s.pullServiceImage(ctx, service, quietPull, project.Environment["DOCKER_DEFAULT_PLATFORM"])
 			mutex.Lock()
 			defer mutex.Unlock()
@@ -363,7 +412,12 @@ func mustPull(service types.ServiceConfig, images map[string]api.ImageSummary) (
 	case types.PullPolicyAlways:
 		// force pull
 		return true, nil
-	case types.PullPolicyNever, types.PullPolicyBuild:
+	case types.PullPolicyNever:
+		if _, ok := images[service.Image]; !ok && service.Build == nil {
+			return false, fmt.Errorf("image %q for service %q is not available locally and pull policy is %q", service.Image, service.Name, policy)
+		}
+		return false, nil
+	case types.PullPolicyBuild:
 		return false, nil
 	case types.PullPolicyRefresh:
 		img, ok := images[service.Image]
@@ -0,0 +1,230 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"io"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/jsonstream"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestWaitPullSlot(t *testing.T) {
+	events := &queuedEvents{resources: make(chan api.Resource, 1)}
+	s := &composeService{events: events}
+
+	release, err := s.waitPullSlot(t.Context(), newPullSlots(-1), "alpine")
+	assert.NilError(t, err)
+	release()
+
+	slots := newPullSlots(1)
+	release, err = s.waitPullSlot(t.Context(), slots, "alpine")
+	assert.NilError(t, err)
+	assert.Equal(t, len(events.resources), 0)
+
+	acquired := make(chan struct{})
+	go func() {
+		defer close(acquired)
+		next, err := s.waitPullSlot(t.Context(), slots, "nginx")
+		assert.Check(t, err)
+		next()
+	}()
+	assert.DeepEqual(t, <-events.resources, newEvent("Image nginx", api.Working, api.StatusWaiting, "Queued, 1 pulls already in progress"))
+	release()
+	<-acquired
+
+	ctx, cancel := context.WithCancel(t.Context())
+	slots <- struct{}{}
+	cancel()
+	_, err = s.waitPullSlot(ctx, slots, "redis")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+type queuedEvents struct {
+	noopEventProcessor
+	resources chan api.Resource
+}
+
+func (q *queuedEvents) On(events ...api.Resource) {
+	for _, e := range events {
+		q.resources <- e
+	}
+}
+
+func TestMustPull(t *testing.T) {
+	images := map[string]api.ImageSummary{
+		"local": {ID: "sha256:123", LastTagTime: time.Now()},
+	}
+	tests := []struct {
+		name    string
+		service types.ServiceConfig
+		pull    bool
+		err     string
+	}{
+		{
+			name:    "missing image is pulled",
+			service: types.ServiceConfig{Name: "web", Image: "remote"},
+			pull:    true,
+		},
+		{
+			name:    "local image is not pulled",
+			service: types.ServiceConfig{Name: "web", Image: "local"},
+		},
+		{
+			name:    "always pulls local image",
+			service: types.ServiceConfig{Name: "web", Image: "local", PullPolicy: types.PullPolicyAlways},
+			pull:    true,
+		},
+		{
+			name:    "never uses local image",
+			service: types.ServiceConfig{Name: "web", Image: "local", PullPolicy: types.PullPolicyNever},
+		},
+		{
+			name:    "never fails on missing image",
+			service: types.ServiceConfig{Name: "web", Image: "remote", PullPolicy: types.PullPolicyNever},
+			err:     `image "remote" for service "web" is not available locally and pull policy is "never"`,
+		},
+		{
+			name:    "never lets missing image be built",
+			service: types.ServiceConfig{Name: "web", Image: "remote", PullPolicy: types.PullPolicyNever, Build: &types.BuildConfig{Context: "."}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pull, err := mustPull(tt.service, images)
+			if tt.err != "" {
+				assert.Error(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, pull, tt.pull)
+		})
+	}
+}
+
+// fakeStreamResponse is a client.ImagePullResponse or client.ImagePushResponse streaming the given content
+type fakeStreamResponse struct {
+	io.ReadCloser
+}
+
+func (fakeStreamResponse) JSONMessages(context.Context) iter.Seq2[jsonstream.Message, error] {
+	return nil
+}
+
+func (fakeStreamResponse) Wait(context.Context) error {
+	return nil
+}
+
+func TestPullServiceImageQuiet(t *testing.T) {
+	const progress = `{"status":"Downloading","id":"layer1","progressDetail":{"current":10,"total":100}}
+{"status":"Pull complete","id":"layer1","progressDetail":{}}
+`
+	tests := []struct {
+		name      string
+		quiet     bool
+		stream    string
+		wantErr   string
+		wantIDs   []string
+		wantTexts []string
+	}{
+		{
+			name:      "progress",
+			stream:    progress,
+			wantIDs:   []string{"Image alpine", "layer1", "layer1", "Image alpine"},
+			wantTexts: []string{api.StatusPulling, "Downloading", "Pull complete", api.StatusPulled},
+		},
+		{
+			name:      "quiet",
+			quiet:     true,
+			stream:    progress,
+			wantIDs:   []string{"Image alpine", "Image alpine"},
+			wantTexts: []string{api.StatusPulling, api.StatusPulled},
+		},
+		{
+			name:      "quiet error",
+			quiet:     true,
+			stream:    `{"errorDetail":{"message":"manifest unknown"},"error":"manifest unknown"}`,
+			wantErr:   "manifest unknown",
+			wantIDs:   []string{"Image alpine", "Image alpine"},
+			wantTexts: []string{api.StatusPulling, "Error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			apiClient, cli := prepareMocks(mockCtrl)
+			cli.EXPECT().ConfigFile().Return(configfile.New("")).AnyTimes()
+			events := &capturingEvents{}
+			service, err := NewComposeService(cli, WithEventProcessor(events))
+			assert.NilError(t, err)
+
+			apiClient.EXPECT().ImagePull(gomock.Any(), "alpine", gomock.Any()).
+				Return(fakeStreamResponse{io.NopCloser(strings.NewReader(tt.stream))}, nil)
+			if tt.wantErr == "" {
+				apiClient.EXPECT().ImageInspect(gomock.Any(), "alpine").
+					Return(client.ImageInspectResult{InspectResponse: image.InspectResponse{ID: "sha256:123"}}, nil)
+			}
+
+			id, err := service.(*composeService).pullServiceImage(t.Context(), types.ServiceConfig{Name: "app", Image: "alpine"}, tt.quiet, "")
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, id, "sha256:123")
+			}
+			var ids, texts []string
+			for _, e := range events.resources {
+				ids = append(ids, e.ID)
+				texts = append(texts, e.Text)
+			}
+			assert.DeepEqual(t, ids, tt.wantIDs)
+			assert.DeepEqual(t, texts, tt.wantTexts)
+		})
+	}
+}
+
+func TestPullServiceImageBuildableError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	apiClient, cli := prepareMocks(mockCtrl)
+	cli.EXPECT().ConfigFile().Return(configfile.New("")).AnyTimes()
+	events := &capturingEvents{}
+	service, err := NewComposeService(cli, WithEventProcessor(events))
+	assert.NilError(t, err)
+
+	apiClient.EXPECT().ImagePull(gomock.Any(), "myapp", gomock.Any()).
+		Return(fakeStreamResponse{io.NopCloser(strings.NewReader(`{"errorDetail":{"message":"pull access denied"},"error":"pull access denied"}`))}, nil)
+
+	app := types.ServiceConfig{Name: "app", Image: "myapp", Build: &types.BuildConfig{Context: "."}}
+	_, err = service.(*composeService).pullServiceImage(t.Context(), app, true, "")
+	assert.Error(t, err, "pull access denied")
+	// the image can still be built, so the failure is a warning, as when ImagePull fails
+	last := events.resources[len(events.resources)-1]
+	assert.Equal(t, last.Status, api.Warning)
+	assert.Equal(t, last.Text, "pull access denied")
+}
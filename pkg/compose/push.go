@@ -69,7 +69,15 @@ func (s *composeService) push(ctx context.Context, project *types.Project, optio
 		for _, tag := range tags {
 			eg.Go(func() error {
 				s.events.On(newEvent(tag, api.Working, "Pushing"))
-				err := s.pushServiceImage(ctx, tag, options.Quiet)
+				digest, err := s.pushServiceImage(ctx, tag, options.Quiet)
+				if options.Results != nil {
+					options.Results(api.PushResult{
+						Service: service.Name,
+						Image:   tag,
+						Digest:  digest,
+						Error:   err,
+					})
+				}
 				if err != nil {
 					if !options.IgnoreFailures {
 						s.events.On(newEvent(tag, api.Error, err.Error()))
@@ -86,28 +94,29 @@ func (s *composeService) push(ctx context.Context, project *types.Project, optio
 	return eg.Wait()
 }
 
-func (s *composeService) pushServiceImage(ctx context.Context, tag string, quietPush bool) error {
+func (s *composeService) pushServiceImage(ctx context.Context, tag string, quietPush bool) (string, error) {
 	ref, err := reference.ParseNormalizedNamed(tag)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	authConfig, err := s.configFile().GetAuthConfig(registry.GetAuthConfigKey(reference.Domain(ref)))
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	buf, err := json.Marshal(authConfig)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	stream, err := s.apiClient().ImagePush(ctx, tag, client.ImagePushOptions{
 		RegistryAuth: base64.URLEncoding.EncodeToString(buf),
 	})
 	if err != nil {
-		return err
+		return "", err
 	}
+	var digest string
 	dec := json.NewDecoder(stream)
 	for {
 		var jm jsonstream.Message
@@ -115,10 +124,14 @@ func (s *composeService) pushServiceImage(ctx context.Context, tag string, quiet
 			if errors.Is(err, io.EOF) {
 				break
 			}
-			return err
+			return "", err
 		}
 		if jm.Error != nil {
-			return errors.New(jm.Error.Message)
+			return "", errors.New(jm.Error.Message)
+		}
+
+		if d := pushedDigest(jm); d != "" {
+			digest = d
 		}
 
 		if !quietPush {
@@ -126,7 +139,21 @@ func (s *composeService) pushServiceImage(ctx context.Context, tag string, quiet
 		}
 	}
 
-	return nil
+	return digest, nil
+}
+
+// pushedDigest extracts the digest of the pushed manifest, reported by the engine as an aux message
+func pushedDigest(jm jsonstream.Message) string {
+	if jm.Aux == nil {
+		return ""
+	}
+	var aux struct {
+		Digest string `json:"Digest"`
+	}
+	if err := json.Unmarshal(*jm.Aux, &aux); err != nil {
+		return ""
+	}
+	return aux.Digest
 }
 
 func toPushProgressEvent(prefix string, jm jsonstream.Message, events api.EventProcessor) {
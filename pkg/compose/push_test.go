@@ -0,0 +1,119 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/cli/cli/config/configfile"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestPushResults(t *testing.T) {
+	const pushed = `{"status":"Pushed","id":"layer1","progressDetail":{}}
+{"status":"latest: digest: sha256:abc size: 528"}
+{"progressDetail":{},"aux":{"Tag":"latest","Digest":"sha256:abc","Size":528}}
+`
+	tests := []struct {
+		name           string
+		ignoreFailures bool
+		wantErr        string
+		want           []api.PushResult
+	}{
+		{
+			name:    "failure",
+			wantErr: "denied",
+			want: []api.PushResult{
+				{Service: "api", Image: "registry.example.com/api:latest", Error: errors.New("denied")},
+				{Service: "web", Image: "registry.example.com/web:latest", Digest: "sha256:abc"},
+			},
+		},
+		{
+			name:           "ignore failures",
+			ignoreFailures: true,
+			want: []api.PushResult{
+				{Service: "api", Image: "registry.example.com/api:latest", Error: errors.New("denied")},
+				{Service: "web", Image: "registry.example.com/web:latest", Digest: "sha256:abc"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			apiClient, cli := prepareMocks(mockCtrl)
+			cli.EXPECT().ConfigFile().Return(configfile.New("")).AnyTimes()
+			service, err := NewComposeService(cli, WithEventProcessor(&capturingEvents{}))
+			assert.NilError(t, err)
+
+			apiClient.EXPECT().ImagePush(gomock.Any(), "registry.example.com/web:latest", gomock.Any()).
+				Return(fakeStreamResponse{io.NopCloser(strings.NewReader(pushed))}, nil)
+			apiClient.EXPECT().ImagePush(gomock.Any(), "registry.example.com/api:latest", gomock.Any()).
+				Return(fakeStreamResponse{io.NopCloser(strings.NewReader(`{"errorDetail":{"message":"denied"},"error":"denied"}`))}, nil)
+
+			project := &types.Project{
+				Name: "test",
+				Services: types.Services{
+					"web": {Name: "web", Image: "registry.example.com/web:latest", Build: &types.BuildConfig{Context: "."}},
+					"api": {Name: "api", Image: "registry.example.com/api:latest", Build: &types.BuildConfig{Context: "."}},
+				},
+			}
+
+			var (
+				mux     sync.Mutex
+				results []api.PushResult
+			)
+			err = service.Push(t.Context(), project, api.PushOptions{
+				Quiet:          true,
+				IgnoreFailures: tt.ignoreFailures,
+				Results: func(result api.PushResult) {
+					mux.Lock()
+					defer mux.Unlock()
+					results = append(results, result)
+				},
+			})
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			slices.SortFunc(results, func(a, b api.PushResult) int {
+				return strings.Compare(a.Service, b.Service)
+			})
+			assert.Equal(t, len(results), len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, results[i].Service, want.Service)
+				assert.Equal(t, results[i].Image, want.Image)
+				assert.Equal(t, results[i].Digest, want.Digest)
+				if want.Error != nil {
+					assert.Error(t, results[i].Error, want.Error.Error())
+				} else {
+					assert.NilError(t, results[i].Error)
+				}
+			}
+		})
+	}
+}
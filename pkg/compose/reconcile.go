@@ -37,6 +37,8 @@ func toReconcileOptions(options api.CreateOptions) ReconcileOptions {
 		Services:             options.Services,
 		Recreate:             options.Recreate,
 		RecreateDependencies: options.RecreateDependencies,
+		ForceRecreate:        options.ForceRecreate,
+		RenewAnonVolumes:     options.RenewAnonVolumes,
 		Inherit:              options.Inherit,
 		Timeout:              options.Timeout,
 		RemoveOrphans:        options.RemoveOrphans,
@@ -49,6 +51,8 @@ type ReconcileOptions struct {
 	Services             []string       // targeted services (empty = all)
 	Recreate             string         // "diverged", "force", "never" for targeted services
 	RecreateDependencies string         // same for non-targeted services
+	ForceRecreate        []string       // services to recreate whatever the strategy
+	RenewAnonVolumes     []string       // services to recreate without inheriting anonymous volumes
 	Inherit              bool           // inherit anonymous volumes on recreate
 	Timeout              *time.Duration // for stop operations
 	RemoveOrphans        bool
@@ -449,6 +453,9 @@ func (r *reconciler) reconcileService(service types.ServiceConfig) error {
 	if slices.Contains(r.options.Services, service.Name) || len(r.options.Services) == 0 {
 		strategy = r.options.Recreate
 	}
+	if slices.Contains(r.options.ForceRecreate, service.Name) || slices.Contains(r.options.RenewAnonVolumes, service.Name) {
+		strategy = api.RecreateForce
+	}
 
 	// Precompute once per service: mustRecreate is called twice per container
 	// (sortContainers + main loop) and the hash/cascade inputs depend on the
@@ -664,7 +671,7 @@ func (r *reconciler) planRecreateContainer(service types.ServiceConfig, oc *Obse
 	allDeps := append(slices.Clone(infraDeps), depStopNodes...)
 
 	var inherited *container.Summary
-	if r.options.Inherit {
+	if r.options.Inherit && !slices.Contains(r.options.RenewAnonVolumes, service.Name) {
 		inherited = &oc.Summary
 	}
 
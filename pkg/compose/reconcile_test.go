@@ -445,6 +445,39 @@ func TestReconcileContainers_ScaleUp(t *testing.T) {
 `)+"\n")
 }
 
+func TestReconcileContainers_ScaleUpDeployReplicas(t *testing.T) {
+	svc := types.ServiceConfig{Name: "web", Deploy: &types.DeployConfig{Replicas: intPtr(3)}}
+	hash := mustServiceHash(t, svc)
+
+	project := &types.Project{
+		Name:     "myproject",
+		Services: types.Services{"web": svc},
+	}
+	observed := &ObservedState{
+		ProjectName: "myproject",
+		Containers: map[string][]ObservedContainer{
+			"web": {{
+				ID: "c1", Number: 1, State: container.StateRunning, ConfigHash: hash,
+				Summary: container.Summary{
+					ID: "c1", State: container.StateRunning,
+					Labels: map[string]string{api.ServiceLabel: "web", api.ContainerNumberLabel: "1", api.ConfigHashLabel: hash},
+				},
+			}},
+		},
+		Networks: map[string]ObservedNetwork{},
+		Volumes:  map[string]ObservedVolume{},
+	}
+
+	plan, err := reconcile(t.Context(), project, observed, defaultReconcileOptions(), noPrompt)
+	assert.NilError(t, err)
+
+	// existing replica is left untouched, only missing ones are created
+	assert.Equal(t, plan.String(), strings.TrimSpace(`
+[] -> #1 service:web:2, CreateContainer, no existing container
+[] -> #2 service:web:3, CreateContainer, no existing container
+`)+"\n")
+}
+
 func TestReconcileContainers_ScaleDown(t *testing.T) {
 	svc := types.ServiceConfig{Name: "web", Scale: intPtr(1)}
 	hash := mustServiceHash(t, svc)
@@ -523,6 +556,100 @@ func TestReconcileContainers_ForceRecreate(t *testing.T) {
 `)+"\n")
 }
 
+func TestReconcileContainers_ForceRecreateServices(t *testing.T) {
+	db := types.ServiceConfig{Name: "db", Scale: intPtr(1)}
+	web := types.ServiceConfig{
+		Name:  "web",
+		Scale: intPtr(1),
+		DependsOn: types.DependsOnConfig{
+			"db": {Condition: types.ServiceConditionStarted},
+		},
+	}
+	observedContainer := func(svc types.ServiceConfig, id string) ObservedContainer {
+		hash := mustServiceHash(t, svc)
+		return ObservedContainer{
+			ID: id, Number: 1, State: container.StateRunning, ConfigHash: hash,
+			Summary: container.Summary{
+				ID: id, State: container.StateRunning,
+				Labels: map[string]string{api.ServiceLabel: svc.Name, api.ContainerNumberLabel: "1", api.ConfigHashLabel: hash},
+			},
+		}
+	}
+
+	project := &types.Project{
+		Name:     "myproject",
+		Services: types.Services{"db": db, "web": web},
+	}
+	observed := &ObservedState{
+		ProjectName: "myproject",
+		Containers: map[string][]ObservedContainer{
+			"db":  {observedContainer(db, "d1aabbccddee")},
+			"web": {observedContainer(web, "c1aabbccddee")},
+		},
+		Networks: map[string]ObservedNetwork{},
+		Volumes:  map[string]ObservedVolume{},
+	}
+
+	// only web is recreated, its db dependency is left untouched as up-to-date
+	opts := defaultReconcileOptions()
+	opts.ForceRecreate = []string{"web"}
+
+	plan, err := reconcile(t.Context(), project, observed, opts, noPrompt)
+	assert.NilError(t, err)
+
+	assert.Equal(t, plan.String(), strings.TrimSpace(`
+[] -> #1 service:web:1, CreateContainer, config changed (tmpName) [recreate:web:1]
+[1] -> #2 service:web:1, StopContainer, replaced by #1 [recreate:web:1]
+[2] -> #3 service:web:1, RemoveContainer, replaced by #1 [recreate:web:1]
+[3] -> #4 service:web:1, RenameContainer, finalize recreate [recreate:web:1]
+`)+"\n")
+}
+
+func TestReconcileContainers_RenewAnonVolumes(t *testing.T) {
+	db := types.ServiceConfig{Name: "db", Scale: intPtr(1)}
+	web := types.ServiceConfig{Name: "web", Scale: intPtr(1)}
+	observedContainer := func(svc types.ServiceConfig, id string) ObservedContainer {
+		hash := mustServiceHash(t, svc)
+		return ObservedContainer{
+			ID: id, Number: 1, State: container.StateRunning, ConfigHash: hash,
+			Summary: container.Summary{
+				ID: id, State: container.StateRunning,
+				Labels: map[string]string{api.ServiceLabel: svc.Name, api.ContainerNumberLabel: "1", api.ConfigHashLabel: hash},
+			},
+		}
+	}
+
+	project := &types.Project{
+		Name:     "myproject",
+		Services: types.Services{"db": db, "web": web},
+	}
+	observed := &ObservedState{
+		ProjectName: "myproject",
+		Containers: map[string][]ObservedContainer{
+			"db":  {observedContainer(db, "d1aabbccddee")},
+			"web": {observedContainer(web, "c1aabbccddee")},
+		},
+		Networks: map[string]ObservedNetwork{},
+		Volumes:  map[string]ObservedVolume{},
+	}
+
+	opts := defaultReconcileOptions()
+	opts.ForceRecreate = []string{"db"}
+	opts.RenewAnonVolumes = []string{"web"}
+
+	plan, err := reconcile(t.Context(), project, observed, opts, noPrompt)
+	assert.NilError(t, err)
+
+	// both services are recreated, but only db inherits anonymous volumes from its previous container
+	inherited := map[string]bool{}
+	for _, node := range plan.Nodes {
+		if node.Operation.Type == OpCreateContainer {
+			inherited[node.Operation.Service.Name] = node.Operation.Inherited != nil
+		}
+	}
+	assert.DeepEqual(t, inherited, map[string]bool{"db": true, "web": false})
+}
+
 func TestReconcileContainers_NeverRecreate(t *testing.T) {
 	project := &types.Project{
 		Name: "myproject",
@@ -618,6 +745,50 @@ func TestReconcileContainers_DependsOnChain(t *testing.T) {
 `)+"\n")
 }
 
+// TestReconcileProviders_DependencyOrdering verifies that independent provider
+// services get no edge between them, so the executor runs them concurrently,
+// while a provider depending on another one waits for it to complete.
+func TestReconcileProviders_DependencyOrdering(t *testing.T) {
+	project := &types.Project{
+		Name: "myproject",
+		Services: types.Services{
+			"cache": {Name: "cache", Provider: &types.ServiceProviderConfig{Type: "cloud"}},
+			"db":    {Name: "db", Provider: &types.ServiceProviderConfig{Type: "cloud"}},
+			"queue": {
+				Name:     "queue",
+				Provider: &types.ServiceProviderConfig{Type: "cloud"},
+				DependsOn: types.DependsOnConfig{
+					"db": {Condition: types.ServiceConditionStarted},
+				},
+			},
+			"web": {
+				Name:  "web",
+				Scale: intPtr(1),
+				DependsOn: types.DependsOnConfig{
+					"cache": {Condition: types.ServiceConditionStarted},
+					"queue": {Condition: types.ServiceConditionStarted},
+				},
+			},
+		},
+	}
+	observed := &ObservedState{
+		ProjectName: "myproject",
+		Containers:  map[string][]ObservedContainer{},
+		Networks:    map[string]ObservedNetwork{},
+		Volumes:     map[string]ObservedVolume{},
+	}
+
+	plan, err := reconcile(t.Context(), project, observed, defaultReconcileOptions(), noPrompt)
+	assert.NilError(t, err)
+
+	assert.Equal(t, plan.String(), strings.TrimSpace(`
+[] -> #1 provider:cache, RunProvider, provider service
+[] -> #2 provider:db, RunProvider, provider service
+[2] -> #3 provider:queue, RunProvider, provider service
+[1,3] -> #4 service:web:1, CreateContainer, no existing container
+`)+"\n")
+}
+
 // TestReconcileContainers_DependsOnScaleDown verifies that scale-down of a
 // service still propagates through serviceNodes, so a dependent service waits
 // for the scale-down's RemoveContainer to finish before starting its own ops.
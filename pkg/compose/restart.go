@@ -19,13 +19,14 @@ package compose
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/moby/moby/client"
+	"github.com/moby/sys/signal"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/docker/compose/v5/pkg/api"
-	"github.com/docker/compose/v5/pkg/utils"
 )
 
 func (s *composeService) Restart(ctx context.Context, projectName string, options api.RestartOptions) error {
@@ -35,6 +36,12 @@ func (s *composeService) Restart(ctx context.Context, projectName string, option
 }
 
 func (s *composeService) restart(ctx context.Context, projectName string, options api.RestartOptions) error { //nolint:gocyclo
+	if options.Signal != "" {
+		if _, err := signal.ParseSignal(options.Signal); err != nil {
+			return err
+		}
+	}
+
 	containers, err := s.getContainers(ctx, projectName, oneOffExclude, true)
 	if err != nil {
 		return err
@@ -82,6 +89,10 @@ func (s *composeService) restart(ctx context.Context, projectName string, option
 			return err
 		}
 
+		if config.Provider != nil {
+			return s.runPlugin(ctx, project, config, "restart")
+		}
+
 		eg, ctx := errgroup.WithContext(ctx)
 		for _, ctr := range containers.filter(isService(service)) {
 			eg.Go(func() error {
@@ -94,9 +105,7 @@ func (s *composeService) restart(ctx context.Context, projectName string, option
 				}
 				eventName := getContainerProgressName(ctr)
 				s.events.On(newEvent(eventName, api.Working, api.StatusRestarting))
-				_, err = s.apiClient().ContainerRestart(ctx, ctr.ID, client.ContainerRestartOptions{
-					Timeout: utils.DurationSecondToInt(options.Timeout),
-				})
+				_, err = s.apiClient().ContainerRestart(ctx, ctr.ID, containerRestartOptions(&def, options.Timeout, options.Signal))
 				if err != nil {
 					return err
 				}
@@ -113,3 +122,16 @@ func (s *composeService) restart(ctx context.Context, projectName string, option
 		return eg.Wait()
 	})
 }
+
+// containerRestartOptions returns the options to restart a container for service. As for stop, the stop_signal and
+// stop_grace_period declared by the service apply unless signal or timeout are explicitly set
+func containerRestartOptions(service *types.ServiceConfig, timeout *time.Duration, signal string) client.ContainerRestartOptions {
+	stop := containerStopOptions(service, timeout)
+	if signal == "" {
+		signal = stop.Signal
+	}
+	return client.ContainerRestartOptions{
+		Signal:  signal,
+		Timeout: stop.Timeout,
+	}
+}
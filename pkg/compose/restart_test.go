@@ -0,0 +1,172 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	compose "github.com/docker/compose/v5/pkg/api"
+)
+
+func TestRestartNoDeps(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	events := &capturingEvents{}
+	tested, err := NewComposeService(cli, WithEventProcessor(events))
+	assert.NilError(t, err)
+
+	restartOnChange := types.ServiceDependency{Condition: types.ServiceConditionStarted, Restart: true, Required: true}
+	project := &types.Project{
+		Name: strings.ToLower(testProject),
+		Services: types.Services{
+			"service1": {Name: "service1"},
+			"service2": {Name: "service2", DependsOn: types.DependsOnConfig{"service1": restartOnChange}},
+			"service3": {Name: "service3", DependsOn: types.DependsOnConfig{"service2": restartOnChange}},
+		},
+	}
+
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt(false)).Return(
+		client.ContainerListResult{
+			Items: []container.Summary{
+				testContainer("service1", "123", false),
+				testContainer("service2", "456", false),
+				testContainer("service3", "789", false),
+			},
+		}, nil)
+	api.EXPECT().ContainerRestart(gomock.Any(), "456", gomock.Any()).Return(client.ContainerRestartResult{}, nil)
+
+	err = tested.Restart(t.Context(), strings.ToLower(testProject), compose.RestartOptions{
+		Project:  project,
+		Services: []string{"service2"},
+		NoDeps:   true,
+	})
+	assert.NilError(t, err)
+
+	// only the selected service is reported in progress output
+	assert.Assert(t, len(events.resources) > 0)
+	for _, event := range events.resources {
+		assert.Equal(t, event.ID, "Container 456")
+	}
+}
+
+func TestRestartSignal(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	project := &types.Project{
+		Name: strings.ToLower(testProject),
+		Services: types.Services{
+			"service1": {Name: "service1"},
+		},
+	}
+
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt(false)).Return(
+		client.ContainerListResult{
+			Items: []container.Summary{
+				testContainer("service1", "123", false),
+			},
+		}, nil)
+	api.EXPECT().ContainerRestart(gomock.Any(), "123", client.ContainerRestartOptions{Signal: "SIGHUP"}).Return(client.ContainerRestartResult{}, nil)
+
+	err = tested.Restart(t.Context(), strings.ToLower(testProject), compose.RestartOptions{
+		Project: project,
+		Signal:  "SIGHUP",
+	})
+	assert.NilError(t, err)
+}
+
+func TestRestartInvalidSignal(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	_, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	err = tested.Restart(t.Context(), strings.ToLower(testProject), compose.RestartOptions{
+		Signal: "SIGFOO",
+	})
+	assert.ErrorContains(t, err, "invalid signal: SIGFOO")
+}
+
+func TestRestartTimeout(t *testing.T) {
+	grace := types.Duration(20 * time.Second)
+	project := &types.Project{
+		Name: strings.ToLower(testProject),
+		Services: types.Services{
+			"service1": {Name: "service1", StopGracePeriod: &grace, StopSignal: "SIGINT"},
+			"service2": {Name: "service2"},
+		},
+	}
+	timeout := 10 * time.Second
+	tests := []struct {
+		name    string
+		timeout *time.Duration
+		want1   client.ContainerRestartOptions
+		want2   client.ContainerRestartOptions
+	}{
+		{
+			name:  "stop_grace_period",
+			want1: client.ContainerRestartOptions{Signal: "SIGINT", Timeout: intPtr(20)},
+			want2: client.ContainerRestartOptions{},
+		},
+		{
+			name:    "timeout flag",
+			timeout: &timeout,
+			want1:   client.ContainerRestartOptions{Signal: "SIGINT", Timeout: intPtr(10)},
+			want2:   client.ContainerRestartOptions{Timeout: intPtr(10)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			api, cli := prepareMocks(mockCtrl)
+			tested, err := NewComposeService(cli)
+			assert.NilError(t, err)
+
+			api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt(false)).Return(
+				client.ContainerListResult{
+					Items: []container.Summary{
+						testContainer("service1", "123", false),
+						testContainer("service2", "456", false),
+					},
+				}, nil)
+			api.EXPECT().ContainerRestart(gomock.Any(), "123", tt.want1).Return(client.ContainerRestartResult{}, nil)
+			api.EXPECT().ContainerRestart(gomock.Any(), "456", tt.want2).Return(client.ContainerRestartResult{}, nil)
+
+			err = tested.Restart(t.Context(), strings.ToLower(testProject), compose.RestartOptions{
+				Project: project,
+				Timeout: tt.timeout,
+			})
+			assert.NilError(t, err)
+		})
+	}
+}
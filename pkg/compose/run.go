@@ -25,12 +25,15 @@ import (
 	"slices"
 
 	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/containerd/errdefs"
 	"github.com/docker/cli/cli"
 	cmd "github.com/docker/cli/cli/command/container"
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/client"
 	"github.com/moby/moby/client/pkg/stringid"
+	mobysignal "github.com/moby/sys/signal"
+	"github.com/sirupsen/logrus"
 
 	"github.com/docker/compose/v5/pkg/api"
 )
@@ -52,8 +55,16 @@ func (s *composeService) RunOneOffContainer(ctx context.Context, project *types.
 
 	sigc := make(chan os.Signal, 128)
 	signal.Notify(sigc)
-	go cmd.ForwardAllSignals(ctx, s.apiClient(), result.containerID, sigc)
-	defer signal.Stop(sigc)
+	sigCtx, stopForwarding := context.WithCancel(ctx)
+	var forwarded <-chan os.Signal = sigc
+	if opts.Tty {
+		forwarded = withoutResizeSignals(sigCtx, sigc)
+	}
+	go cmd.ForwardAllSignals(sigCtx, s.apiClient(), result.containerID, forwarded)
+	defer func() {
+		signal.Stop(sigc)
+		stopForwarding()
+	}()
 
 	// If the service has post_start hooks, set up a goroutine that waits for
 	// the container to start and then executes them. This is needed because
@@ -81,6 +92,10 @@ func (s *composeService) RunOneOffContainer(ctx context.Context, project *types.
 		}
 	}
 
+	if !opts.Detach && !opts.AutoRemove {
+		s.disconnectRunNetworks(context.WithoutCancel(ctx), result.containerID, opts.Networks)
+	}
+
 	var stErr cli.StatusError
 	if errors.As(err, &stErr) {
 		return stErr.StatusCode, nil
@@ -88,6 +103,31 @@ func (s *composeService) RunOneOffContainer(ctx context.Context, project *types.
 	return 0, err
 }
 
+// withoutResizeSignals relays signals from in, except SIGWINCH, until ctx is done. With a TTY allocated, terminal
+// resizes are already applied to the container TTY by cmd.RunStart, and must not be sent as signals to the container.
+func withoutResizeSignals(ctx context.Context, in <-chan os.Signal) <-chan os.Signal {
+	out := make(chan os.Signal, cap(in))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-in:
+				if sig == mobysignal.SIGWINCH {
+					continue
+				}
+				select {
+				case out <- sig:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
 // runPostStartHooksOnEvent listens for the container's start event and executes
 // post_start lifecycle hooks once the container is running.
 func (s *composeService) runPostStartHooksOnEvent(ctx context.Context, containerID string, service types.ServiceConfig, ctr container.Summary) error {
@@ -126,6 +166,15 @@ func (s *composeService) prepareRun(ctx context.Context, project *types.Project,
 		return prepareRunResult{}, err
 	}
 
+	for _, name := range opts.Networks {
+		if _, err := s.apiClient().NetworkInspect(ctx, name, client.NetworkInspectOptions{}); err != nil {
+			if errdefs.IsNotFound(err) {
+				return prepareRunResult{}, fmt.Errorf("network %q does not exist", name)
+			}
+			return prepareRunResult{}, err
+		}
+	}
+
 	err = Run(ctx, func(ctx context.Context) error {
 		return s.startDependencies(ctx, project, opts)
 	}, "run", s.events)
@@ -170,7 +219,7 @@ func (s *composeService) prepareRun(ctx context.Context, project *types.Project,
 	}
 
 	if !opts.NoDeps {
-		if err := s.waitDependencies(ctx, project, service.Name, service.DependsOn, observedState, 0); err != nil {
+		if err := s.waitRunDependencies(ctx, project, service, observedState, opts); err != nil {
 			return prepareRunResult{}, err
 		}
 	}
@@ -195,22 +244,55 @@ func (s *composeService) prepareRun(ctx context.Context, project *types.Project,
 		return prepareRunResult{}, err
 	}
 
-	inspect, err := s.apiClient().ContainerInspect(ctx, created.ID, client.ContainerInspectOptions{})
-	if err != nil {
+	if err := s.setupRunContainer(ctx, project, service, created.ID, opts.Networks); err != nil {
 		return prepareRunResult{}, err
 	}
-
-	err = s.injectSecrets(ctx, project, service, inspect.Container.ID)
-	if err != nil {
-		return prepareRunResult{containerID: created.ID}, err
-	}
-
-	err = s.injectConfigs(ctx, project, service, inspect.Container.ID)
 	return prepareRunResult{
 		containerID: created.ID,
 		service:     service,
 		created:     created,
-	}, err
+	}, nil
+}
+
+// setupRunContainer connects a created one-off container to additional networks and injects secrets and configs.
+// On failure, the container, which has not been started, is removed so it isn't left behind.
+func (s *composeService) setupRunContainer(ctx context.Context, project *types.Project, service types.ServiceConfig, containerID string, networks []string) error {
+	err := s.connectRunContainer(ctx, project, service, containerID, networks)
+	if err != nil {
+		if _, removeErr := s.apiClient().ContainerRemove(context.WithoutCancel(ctx), containerID, client.ContainerRemoveOptions{Force: true}); removeErr != nil {
+			logrus.Warnf("failed to remove one-off container %s: %v", containerID, removeErr)
+		}
+	}
+	return err
+}
+
+func (s *composeService) connectRunContainer(ctx context.Context, project *types.Project, service types.ServiceConfig, containerID string, networks []string) error {
+	for _, name := range networks {
+		_, err := s.apiClient().NetworkConnect(ctx, name, client.NetworkConnectOptions{
+			Container: containerID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to connect container to network %q: %w", name, err)
+		}
+	}
+
+	if err := s.injectSecrets(ctx, project, service, containerID); err != nil {
+		return err
+	}
+	return s.injectConfigs(ctx, project, service, containerID)
+}
+
+// disconnectRunNetworks disconnects a one-off container from the additional networks it was connected
+// to. The container might already have been removed, so this is best effort.
+func (s *composeService) disconnectRunNetworks(ctx context.Context, containerID string, networks []string) {
+	for _, name := range networks {
+		_, err := s.apiClient().NetworkDisconnect(ctx, name, client.NetworkDisconnectOptions{
+			Container: containerID,
+		})
+		if err != nil && !errdefs.IsNotFound(err) {
+			logrus.Debugf("failed to disconnect %s from network %s: %v", containerID, name, err)
+		}
+	}
 }
 
 func prepareBuildOptions(opts api.RunOptions) *api.BuildOptions {
@@ -268,6 +350,37 @@ func applyRunOptions(project *types.Project, service *types.ServiceConfig, opts
 	}
 }
 
+// waitRunDependencies waits for the depends_on conditions of the service to run. With Wait, dependencies with
+// condition service_started also have to be running, or healthy if they declare a healthcheck, within WaitTimeout
+func (s *composeService) waitRunDependencies(ctx context.Context, project *types.Project, service types.ServiceConfig, containers Containers, opts api.RunOptions) error {
+	if !opts.Wait {
+		return s.waitDependencies(ctx, project, service.Name, service.DependsOn, containers, 0)
+	}
+	if opts.WaitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.WaitTimeout)
+		defer cancel()
+	}
+	err := s.waitDependencies(ctx, project, service.Name, runningOrHealthyDependencies(service.DependsOn), containers, 0)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("dependencies of service %q not running or healthy after %s", service.Name, opts.WaitTimeout)
+	}
+	return err
+}
+
+// runningOrHealthyDependencies returns the dependencies with condition service_started replaced to wait for them
+// to be running, or healthy if they declare a healthcheck
+func runningOrHealthyDependencies(dependencies types.DependsOnConfig) types.DependsOnConfig {
+	result := make(types.DependsOnConfig, len(dependencies))
+	for name, config := range dependencies {
+		if config.Condition == types.ServiceConditionStarted {
+			config.Condition = ServiceConditionRunningOrHealthy
+		}
+		result[name] = config
+	}
+	return result
+}
+
 func (s *composeService) resolveRunServiceReferences(ctx context.Context, projectName string, service *types.ServiceConfig) error {
 	containersByService, err := s.getContainersByService(ctx, projectName)
 	if err != nil {
@@ -0,0 +1,176 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	mobysignal "github.com/moby/sys/signal"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestRunMissingNetwork(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	apiClient.EXPECT().NetworkInspect(gomock.Any(), "shared", gomock.Any()).Return(client.NetworkInspectResult{}, nil)
+	apiClient.EXPECT().NetworkInspect(gomock.Any(), "missing", gomock.Any()).Return(client.NetworkInspectResult{}, errdefs.ErrNotFound)
+
+	project := &types.Project{
+		Name: "test",
+		Services: types.Services{
+			"app": {Name: "app", Image: "alpine"},
+		},
+	}
+	_, err = tested.RunOneOffContainer(t.Context(), project, api.RunOptions{
+		Service:  "app",
+		Networks: []string{"shared", "missing"},
+	})
+	assert.Error(t, err, `network "missing" does not exist`)
+}
+
+func TestSetupRunContainerRemovesContainerOnFailure(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	apiClient, cli := prepareMocks(mockCtrl)
+	tested, err := NewComposeService(cli)
+	assert.NilError(t, err)
+
+	apiClient.EXPECT().NetworkConnect(gomock.Any(), "shared", client.NetworkConnectOptions{Container: "123"}).
+		Return(client.NetworkConnectResult{}, errors.New("boom"))
+	apiClient.EXPECT().ContainerRemove(gomock.Any(), "123", client.ContainerRemoveOptions{Force: true}).
+		Return(client.ContainerRemoveResult{}, nil)
+
+	project := &types.Project{Name: "test"}
+	err = tested.(*composeService).setupRunContainer(t.Context(), project, types.ServiceConfig{Name: "app"}, "123", []string{"shared"})
+	assert.Error(t, err, `failed to connect container to network "shared": boom`)
+}
+
+func TestDisconnectRunNetworks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	apiClient, cli := prepareMocks(mockCtrl)
+	service, err := NewComposeService(cli)
+	assert.NilError(t, err)
+	tested := service.(*composeService)
+
+	apiClient.EXPECT().NetworkDisconnect(gomock.Any(), "shared", client.NetworkDisconnectOptions{Container: "123"}).
+		Return(client.NetworkDisconnectResult{}, nil)
+	apiClient.EXPECT().NetworkDisconnect(gomock.Any(), "other", client.NetworkDisconnectOptions{Container: "123"}).
+		Return(client.NetworkDisconnectResult{}, errors.New("network is gone"))
+
+	// errors are ignored, as the container or network might have been removed already
+	tested.disconnectRunNetworks(t.Context(), "123", []string{"shared", "other"})
+}
+
+func TestWithoutResizeSignals(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	in := make(chan os.Signal, 3)
+	out := withoutResizeSignals(ctx, in)
+
+	in <- mobysignal.SIGWINCH
+	in <- syscall.SIGTERM
+	select {
+	case sig := <-out:
+		assert.Equal(t, sig, os.Signal(syscall.SIGTERM))
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for signal")
+	}
+
+	// relay stops once context is done
+	cancel()
+	select {
+	case _, ok := <-out:
+		assert.Assert(t, !ok)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to be closed")
+	}
+}
+
+func TestWaitRunDependencies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	apiClient, cli := prepareMocks(mockCtrl)
+	service, err := NewComposeService(cli)
+	assert.NilError(t, err)
+	tested := service.(*composeService)
+
+	one := 1
+	project := &types.Project{
+		Name: "test",
+		Services: types.Services{
+			"app": {Name: "app", DependsOn: types.DependsOnConfig{
+				"db": {Condition: types.ServiceConditionStarted, Required: true},
+			}},
+			"db": {Name: "db", Scale: &one},
+		},
+	}
+	app := project.Services["app"]
+	containers := Containers{testContainer("db", "123", false)}
+
+	t.Run("without wait", func(t *testing.T) {
+		// service_started is managed when dependencies are started, the container is not inspected
+		err := tested.waitRunDependencies(t.Context(), project, app, containers, api.RunOptions{})
+		assert.NilError(t, err)
+	})
+
+	t.Run("wait timeout without wait", func(t *testing.T) {
+		// like up and start, the timeout is ignored when not waiting
+		err := tested.waitRunDependencies(t.Context(), project, app, containers, api.RunOptions{WaitTimeout: time.Second})
+		assert.NilError(t, err)
+	})
+
+	t.Run("wait for healthy dependency", func(t *testing.T) {
+		apiClient.EXPECT().ContainerInspect(gomock.Any(), "123", gomock.Any()).Return(client.ContainerInspectResult{
+			Container: container.InspectResponse{
+				ID:     "123",
+				Name:   "/db",
+				State:  &container.State{Status: container.StateRunning, Health: &container.Health{Status: container.Healthy}},
+				Config: &container.Config{Healthcheck: &container.HealthConfig{Test: []string{"CMD", "true"}}},
+			},
+		}, nil)
+		err := tested.waitRunDependencies(t.Context(), project, app, containers, api.RunOptions{Wait: true})
+		assert.NilError(t, err)
+	})
+
+	t.Run("wait timeout", func(t *testing.T) {
+		apiClient.EXPECT().ContainerInspect(gomock.Any(), "123", gomock.Any()).Return(client.ContainerInspectResult{
+			Container: container.InspectResponse{
+				ID:     "123",
+				Name:   "/db",
+				State:  &container.State{Status: container.StateRunning, Health: &container.Health{Status: container.Starting}},
+				Config: &container.Config{Healthcheck: &container.HealthConfig{Test: []string{"CMD", "true"}}},
+			},
+		}, nil).AnyTimes()
+		err := tested.waitRunDependencies(t.Context(), project, app, containers, api.RunOptions{
+			Wait:        true,
+			WaitTimeout: 300 * time.Millisecond,
+		})
+		assert.Error(t, err, `dependencies of service "app" not running or healthy after 300ms`)
+	})
+}
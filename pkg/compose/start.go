@@ -20,20 +20,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/moby/moby/client"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/docker/compose/v5/pkg/api"
 )
 
 func (s *composeService) Start(ctx context.Context, projectName string, options api.StartOptions) error {
 	return Run(ctx, func(ctx context.Context) error {
+		if options.Project != nil {
+			if err := s.startProviders(ctx, options.Project, options.Services); err != nil {
+				return err
+			}
+		}
 		return s.start(ctx, strings.ToLower(projectName), options, nil)
 	}, "start", s.events)
 }
 
+// startProviders resumes provider services, which have no container to be started. This is not
+// part of start, as `up` already ran the providers while creating the project resources.
+func (s *composeService) startProviders(ctx context.Context, project *types.Project, services []string) error {
+	return InDependencyOrder(ctx, project, func(c context.Context, name string) error {
+		service := project.Services[name]
+		if service.Provider == nil || (len(services) > 0 && !slices.Contains(services, name)) {
+			return nil
+		}
+		return s.runPlugin(ctx, project, service, "start")
+	})
+}
+
 func (s *composeService) start(ctx context.Context, projectName string, options api.StartOptions, listener api.ContainerEventListener) error {
 	project := options.Project
 	if project == nil {
@@ -71,29 +93,76 @@ func (s *composeService) start(ctx context.Context, projectName string, options
 	}
 
 	if options.Wait {
-		depends := types.DependsOnConfig{}
-		for _, s := range project.Services {
-			depends[s.Name] = types.ServiceDependency{
-				Condition: getDependencyCondition(s, project),
-				Required:  true,
-			}
-		}
-		if options.WaitTimeout > 0 {
-			withTimeout, cancel := context.WithTimeout(ctx, options.WaitTimeout)
-			ctx = withTimeout
-			defer cancel()
-		}
+		return s.waitServices(ctx, project, containers, options.WaitTimeout)
+	}
+
+	return nil
+}
 
-		err = s.waitDependencies(ctx, project, project.Name, depends, containers, 0)
+// waitTimeoutExtension is the service extension to override `--wait-timeout` for a service
+const waitTimeoutExtension = "x-wait-timeout"
+
+// waitServices waits for all services to be running or healthy. Each service is waited for within
+// its own timeout, so a service timing out doesn't cancel the others still within their budget.
+func (s *composeService) waitServices(ctx context.Context, project *types.Project, containers Containers, defaultTimeout time.Duration) error {
+	var (
+		mu       sync.Mutex
+		timeouts []error
+	)
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, service := range project.Services {
+		timeout, err := serviceWaitTimeout(service, defaultTimeout)
 		if err != nil {
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return fmt.Errorf("application not healthy after %s", options.WaitTimeout)
-			}
 			return err
 		}
+		depends := types.DependsOnConfig{
+			service.Name: {
+				Condition: getDependencyCondition(service, project),
+				Required:  true,
+			},
+		}
+		eg.Go(func() error {
+			waitCtx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				waitCtx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			err := s.waitDependencies(waitCtx, project, project.Name, depends, containers, 0)
+			if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+				mu.Lock()
+				defer mu.Unlock()
+				timeouts = append(timeouts, fmt.Errorf("service %q not healthy after %s", service.Name, timeout))
+				return nil
+			}
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
 	}
+	return errors.Join(timeouts...)
+}
 
-	return nil
+// serviceWaitTimeout returns the timeout to wait for service to be healthy, as set by the
+// x-wait-timeout extension, either as a duration or a number of seconds, or defaultTimeout
+func serviceWaitTimeout(service types.ServiceConfig, defaultTimeout time.Duration) (time.Duration, error) {
+	value, ok := service.Extensions[waitTimeoutExtension]
+	if !ok {
+		return defaultTimeout, nil
+	}
+	switch v := value.(type) {
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d, nil
+		}
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second, nil
+		}
+	}
+	return 0, fmt.Errorf("service %q has invalid %s %v, must be a duration or a number of seconds", service.Name, waitTimeoutExtension, value)
 }
 
 // getDependencyCondition checks if service is depended on by other services
@@ -0,0 +1,101 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestServiceWaitTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected time.Duration
+	}{
+		{name: "not set", expected: 30 * time.Second},
+		{name: "seconds", value: 90, expected: 90 * time.Second},
+		{name: "duration", value: "2m", expected: 2 * time.Minute},
+		{name: "seconds as string", value: "45", expected: 45 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := types.ServiceConfig{Name: "web"}
+			if tt.value != nil {
+				service.Extensions = types.Extensions{waitTimeoutExtension: tt.value}
+			}
+			timeout, err := serviceWaitTimeout(service, 30*time.Second)
+			assert.NilError(t, err)
+			assert.Equal(t, timeout, tt.expected)
+		})
+	}
+
+	_, err := serviceWaitTimeout(types.ServiceConfig{
+		Name:       "web",
+		Extensions: types.Extensions{waitTimeoutExtension: "soon"},
+	}, 0)
+	assert.Error(t, err, `service "web" has invalid x-wait-timeout soon, must be a duration or a number of seconds`)
+}
+
+func TestWaitServicesPerServiceTimeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	apiClient, cli := prepareMocks(mockCtrl)
+	service, err := NewComposeService(cli)
+	assert.NilError(t, err)
+	tested := service.(*composeService)
+
+	inspect := func(name string, health container.HealthStatus) client.ContainerInspectResult {
+		return client.ContainerInspectResult{
+			Container: container.InspectResponse{
+				Name: "/" + name,
+				State: &container.State{
+					Status: container.StateRunning,
+					Health: &container.Health{Status: health},
+				},
+				Config: &container.Config{
+					Healthcheck: &container.HealthConfig{Test: []string{"CMD", "true"}},
+				},
+			},
+		}
+	}
+	// db times out before being polled, web is still waited for within the default timeout
+	apiClient.EXPECT().ContainerInspect(gomock.Any(), "web1", gomock.Any()).Return(inspect("web1", container.Healthy), nil)
+	apiClient.EXPECT().ContainerInspect(gomock.Any(), "db1", gomock.Any()).Return(inspect("db1", container.Starting), nil).AnyTimes()
+
+	project := &types.Project{
+		Name: "test",
+		Services: types.Services{
+			"web": {Name: "web"},
+			"db": {
+				Name:       "db",
+				Extensions: types.Extensions{waitTimeoutExtension: "100ms"},
+			},
+		},
+	}
+	containers := Containers{
+		testContainer("web", "web1", false),
+		testContainer("db", "db1", false),
+	}
+	err = tested.waitServices(t.Context(), project, containers, time.Minute)
+	assert.Error(t, err, `service "db" not healthy after 100ms`)
+}
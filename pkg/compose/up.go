@@ -23,6 +23,7 @@ import (
 	"os"
 	"os/signal"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -42,6 +43,9 @@ import (
 )
 
 func (s *composeService) Up(ctx context.Context, project *types.Project, options api.UpOptions) error { //nolint:gocyclo
+	if options.Events != nil {
+		s = s.withEvents(options.Events)
+	}
 	err := Run(ctx, tracing.SpanWrapFunc("project/up", tracing.ProjectOptions(ctx, project), func(ctx context.Context) error {
 		err := s.create(ctx, project, options.Create)
 		if err != nil {
@@ -130,10 +134,10 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 	}
 
 	eg.Go(func() error {
-		first := true
+		interrupts := 0
 		gracefulTeardown := func() {
-			first = false
-			s.events.On(newEvent(api.ResourceCompose, api.Working, api.StatusStopping, "Gracefully Stopping... press Ctrl+C again to force"))
+			interrupts++
+			s.events.On(newEvent(api.ResourceCompose, api.Working, api.StatusStopping, "Gracefully Stopping... press Ctrl+C again to kill containers"))
 			eg.Go(func() error {
 				err = s.stop(context.WithoutCancel(globalCtx), project.Name, api.StopOptions{
 					Services: options.Create.Services,
@@ -153,15 +157,18 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 				}
 				return nil
 			case <-ctx.Done():
-				if first {
+				if interrupts == 0 {
 					gracefulTeardown()
 				}
 			case <-signalChan:
-				if first {
+				if interrupts == 0 {
 					_ = keyboard.Close()
 					gracefulTeardown()
 					break
 				}
+				s.events.On(newEvent(api.ResourceCompose, api.Working, api.StatusStopping, "Killing containers... press Ctrl+C again to exit immediately"))
+				// stop relaying signals, so that next one terminates the process with the default behavior
+				signal.Stop(signalChan)
 				eg.Go(func() error {
 					err := s.kill(context.WithoutCancel(globalCtx), project.Name, api.KillOptions{
 						Services: options.Create.Services,
@@ -193,46 +200,51 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 	}
 
 	monitor := newMonitor(s.apiClient(), project.Name)
-	if len(options.Start.Services) > 0 {
+	switch {
+	case len(options.Start.Services) > 0:
 		monitor.withServices(options.Start.Services)
-	} else {
+	case options.Start.OnExit != api.CascadeIgnore || options.Start.ExitCodeFrom != "":
+		// services not attached still have their exit honored to abort the application
+		monitor.withServices(project.ServiceNames())
+	default:
 		// Start.AttachTo have been already curated with only the services to monitor
 		monitor.withServices(options.Start.AttachTo)
 	}
 	monitor.withListener(printer.HandleEvent)
 
 	var exitCode int
+	var exitCodes *exitCodeCollector
+	if options.Start.ExitCodeFrom != "" {
+		exitCodes = newExitCodeCollector(options.Start.ExitCodeFrom)
+		// registered first, so the exit of a selected container triggering abort is collected
+		monitor.withListener(exitCodes.handle)
+	}
 	if options.Start.OnExit != api.CascadeIgnore {
 		once := true
 		// detect first container to exit to trigger application shutdown
 		monitor.withListener(func(event api.ContainerEvent) {
-			if once && event.Type == api.ContainerEventExited {
-				if options.Start.OnExit == api.CascadeFail && event.ExitCode == 0 {
-					return
-				}
-				once = false
-				exitCode = event.ExitCode
-				s.events.On(newEvent(api.ResourceCompose, api.Working, api.StatusStopping, "Aborting on container exit..."))
-				eg.Go(func() error {
-					err = s.stop(context.WithoutCancel(globalCtx), project.Name, api.StopOptions{
-						Services: options.Create.Services,
-						Project:  project,
-					}, printer.HandleEvent)
-					appendErr(err)
-					return nil
-				})
+			if !once || !shouldAbortOnExit(options.Start.OnExit, event) {
+				return
 			}
-		})
-	}
-
-	if options.Start.ExitCodeFrom != "" {
-		once := true
-		// capture exit code from first container to exit with selected service
-		monitor.withListener(func(event api.ContainerEvent) {
-			if once && event.Type == api.ContainerEventExited && event.Service == options.Start.ExitCodeFrom {
+			once = false
+			if exitCodes == nil {
 				exitCode = event.ExitCode
-				once = false
+			} else {
+				exitCodes.abort()
+			}
+			reason := "Aborting on container exit..."
+			if options.Start.OnExit == api.CascadeFail {
+				reason = fmt.Sprintf("Aborting on container failure: %s exited with code %d...", event.Source, event.ExitCode)
 			}
+			s.events.On(newEvent(api.ResourceCompose, api.Working, api.StatusStopping, reason))
+			eg.Go(func() error {
+				err = s.stop(context.WithoutCancel(globalCtx), project.Name, api.StopOptions{
+					Services: options.Create.Services,
+					Project:  project,
+				}, printer.HandleEvent)
+				appendErr(err)
+				return nil
+			})
 		})
 	}
 
@@ -292,6 +304,9 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 
 	_ = eg.Wait()
 	err = errors.Join(errs...)
+	if exitCodes != nil {
+		exitCode = exitCodes.exitCode
+	}
 	if exitCode != 0 {
 		errMsg := ""
 		if err != nil {
@@ -302,6 +317,62 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 	return err
 }
 
+// exitCodeCollector collects the exit code of containers for the services selected by --exit-code-from
+type exitCodeCollector struct {
+	services []string
+	// exitCode is the highest exit code collected, so any failure wins over success
+	exitCode int
+	// collected is set once an exit code has been collected
+	collected bool
+	// aborting is set once Compose stops the application
+	aborting bool
+}
+
+// newExitCodeCollector creates an exitCodeCollector for a comma-separated list of services
+func newExitCodeCollector(exitCodeFrom string) *exitCodeCollector {
+	c := &exitCodeCollector{}
+	for _, name := range strings.Split(exitCodeFrom, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(c.services, name) {
+			c.services = append(c.services, name)
+		}
+	}
+	return c
+}
+
+// handle collects the exit code of selected containers. Exits of restarting containers are ignored. Once aborting,
+// containers stopped by Compose only report their exit code if none was collected before.
+func (c *exitCodeCollector) handle(event api.ContainerEvent) {
+	if event.Type != api.ContainerEventExited || event.Restarting || !slices.Contains(c.services, event.Service) {
+		return
+	}
+	if c.aborting && c.collected {
+		return
+	}
+	c.collected = true
+	c.exitCode = max(c.exitCode, event.ExitCode)
+}
+
+// abort records the application is being stopped by Compose
+func (c *exitCodeCollector) abort() {
+	c.aborting = true
+}
+
+// shouldAbortOnExit tells if event is a container exit which aborts the application according to onExit.
+// With api.CascadeFail, containers which complete successfully are ignored.
+func shouldAbortOnExit(onExit api.Cascade, event api.ContainerEvent) bool {
+	if event.Type != api.ContainerEventExited {
+		return false
+	}
+	switch onExit {
+	case api.CascadeStop:
+		return true
+	case api.CascadeFail:
+		return event.ExitCode != 0
+	default:
+		return false
+	}
+}
+
 func shouldFollowStartEvent(event api.ContainerEvent, attached []string, attachTo []string) bool {
 	if event.Type != api.ContainerEventStarted {
 		return false
@@ -102,3 +102,83 @@ func TestShouldFollowStartEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestWithEvents(t *testing.T) {
+	configured := &capturingEvents{}
+	s := &composeService{events: configured, dryRun: true, maxConcurrency: 4, runtimeAPIVersion: &runtimeVersionCache{val: "1.44"}}
+
+	sink := &capturingEvents{}
+	observed := s.withEvents(sink)
+	assert.Equal(t, observed.dryRun, true)
+	assert.Equal(t, observed.maxConcurrency, 4)
+	assert.Equal(t, observed.runtimeAPIVersion, s.runtimeAPIVersion)
+
+	observed.events.On(api.Resource{ID: "web", Status: api.Done, Text: api.StatusStarted})
+	assert.DeepEqual(t, sink.resources, []api.Resource{{ID: "web", Status: api.Done, Text: api.StatusStarted}})
+	// default rendering is not impacted by the additional processor
+	assert.DeepEqual(t, configured.resources, sink.resources)
+	assert.Equal(t, s.events, api.EventProcessor(configured))
+}
+
+func TestShouldAbortOnExit(t *testing.T) {
+	exited := func(code int) api.ContainerEvent {
+		return api.ContainerEvent{Type: api.ContainerEventExited, Service: "web", ExitCode: code}
+	}
+	started := api.ContainerEvent{Type: api.ContainerEventStarted, Service: "web"}
+
+	assert.Assert(t, !shouldAbortOnExit(api.CascadeIgnore, exited(1)))
+	assert.Assert(t, shouldAbortOnExit(api.CascadeStop, exited(0)))
+	assert.Assert(t, shouldAbortOnExit(api.CascadeStop, exited(1)))
+	assert.Assert(t, !shouldAbortOnExit(api.CascadeStop, started))
+	assert.Assert(t, !shouldAbortOnExit(api.CascadeFail, exited(0)))
+	assert.Assert(t, shouldAbortOnExit(api.CascadeFail, exited(137)))
+	assert.Assert(t, !shouldAbortOnExit(api.CascadeFail, started))
+}
+
+func TestExitCodeCollector(t *testing.T) {
+	exited := func(service string, code int) api.ContainerEvent {
+		return api.ContainerEvent{Type: api.ContainerEventExited, Service: service, ExitCode: code}
+	}
+
+	t.Run("highest exit code", func(t *testing.T) {
+		c := newExitCodeCollector("test-a, test-b")
+		c.handle(exited("test-a", 0))
+		c.handle(exited("db", 137))
+		c.handle(exited("test-b", 1))
+		assert.Equal(t, c.exitCode, 1)
+	})
+
+	t.Run("restarting containers are ignored", func(t *testing.T) {
+		c := newExitCodeCollector("test-a,test-b")
+		c.handle(api.ContainerEvent{Type: api.ContainerEventExited, Service: "test-b", ExitCode: 3, Restarting: true})
+		assert.Assert(t, !c.collected)
+		assert.Equal(t, c.exitCode, 0)
+	})
+
+	t.Run("containers stopped by Compose are ignored", func(t *testing.T) {
+		c := newExitCodeCollector("test-a,test-b")
+		c.handle(exited("test-a", 0))
+		c.abort()
+		c.handle(exited("test-b", 143))
+		assert.Equal(t, c.exitCode, 0)
+	})
+
+	t.Run("replicas stopped by Compose are ignored", func(t *testing.T) {
+		c := newExitCodeCollector("test-a")
+		c.handle(exited("test-a", 3))
+		c.abort()
+		c.handle(exited("test-a", 143))
+		assert.Equal(t, c.exitCode, 3)
+	})
+
+	t.Run("another service exiting first", func(t *testing.T) {
+		c := newExitCodeCollector("test-a,test-b")
+		db := exited("db", 0)
+		c.handle(db)
+		assert.Assert(t, shouldAbortOnExit(api.CascadeStop, db))
+		c.abort()
+		c.handle(exited("test-a", 143))
+		c.handle(exited("test-b", 137))
+		assert.Equal(t, c.exitCode, 143)
+	})
+}
@@ -18,8 +18,13 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/compose-spec/compose-go/v2/types"
 
@@ -30,6 +35,23 @@ import (
 type vizGraph map[*types.ServiceConfig][]*types.ServiceConfig
 
 func (s *composeService) Viz(_ context.Context, project *types.Project, opts api.VizOptions) (string, error) {
+	// a dependency cycle, which can be introduced by extends, would produce an invalid graph
+	if _, err := NewGraph(project, ServiceStopped); err != nil {
+		return "", err
+	}
+
+	switch opts.Format {
+	case "", api.VizFormatDot:
+		return dotGraph(project, &opts), nil
+	case api.VizFormatMermaid:
+		return mermaidGraph(project, &opts), nil
+	default:
+		return "", fmt.Errorf("unsupported graph format %q, expected %s or %s", opts.Format, api.VizFormatDot, api.VizFormatMermaid)
+	}
+}
+
+// dotGraph renders the services dependency graph using the graphviz DOT language
+func dotGraph(project *types.Project, opts *api.VizOptions) string {
 	graph := make(vizGraph)
 	for _, service := range project.Services {
 		graph[&service] = make([]*types.ServiceConfig, 0, len(service.DependsOn))
@@ -52,13 +74,19 @@ func (s *composeService) Viz(_ context.Context, project *types.Project, opts api
 	// dot is the perfect layout for this use case since graph is directed and hierarchical
 	graphBuilder.WriteString(opts.Indentation + "layout=dot;\n")
 
-	addNodes(&graphBuilder, graph, project.Name, &opts)
+	addNodes(&graphBuilder, graph, project.Name, opts)
+	if opts.IncludeResources {
+		addResourceNodes(&graphBuilder, project, opts)
+	}
 	graphBuilder.WriteByte('\n')
 
-	addEdges(&graphBuilder, graph, &opts)
+	addEdges(&graphBuilder, graph, opts)
+	if opts.IncludeResources {
+		addResourceEdges(&graphBuilder, project, opts)
+	}
 	graphBuilder.WriteString("}\n")
 
-	return graphBuilder.String(), nil
+	return graphBuilder.String()
 }
 
 // addNodes adds the corresponding graphviz representation of all the nodes in the given graph to the graphBuilder
@@ -126,6 +154,11 @@ func addEdges(graphBuilder *strings.Builder, graph vizGraph, opts *api.VizOption
 			writeQuoted(graphBuilder, parent.Name)
 			graphBuilder.WriteString(" -> ")
 			writeQuoted(graphBuilder, child.Name)
+			if condition := parent.DependsOn[child.Name].Condition; opts.IncludeConditions && condition != "" {
+				graphBuilder.WriteString(" [label=")
+				writeQuoted(graphBuilder, condition)
+				graphBuilder.WriteString("]")
+			}
 			graphBuilder.WriteString(";\n")
 		}
 	}
@@ -133,6 +166,139 @@ func addEdges(graphBuilder *strings.Builder, graph vizGraph, opts *api.VizOption
 	return graphBuilder
 }
 
+// addResourceNodes adds the networks and volumes used by services to the graphBuilder, with a distinct shape
+// returns the same graphBuilder
+func addResourceNodes(graphBuilder *strings.Builder, project *types.Project, opts *api.VizOptions) *strings.Builder {
+	networks, volumes := vizResources(project)
+	for _, network := range networks {
+		graphBuilder.WriteString(opts.Indentation)
+		writeQuoted(graphBuilder, "network:"+network)
+		graphBuilder.WriteString(" [shape=\"ellipse\" label=")
+		writeQuoted(graphBuilder, network)
+		graphBuilder.WriteString("];\n")
+	}
+	for _, volume := range volumes {
+		graphBuilder.WriteString(opts.Indentation)
+		writeQuoted(graphBuilder, "volume:"+volume)
+		graphBuilder.WriteString(" [shape=\"cylinder\" label=")
+		writeQuoted(graphBuilder, volume)
+		graphBuilder.WriteString("];\n")
+	}
+	return graphBuilder
+}
+
+// addResourceEdges links services to the networks and volumes they use in the graphBuilder
+// returns the same graphBuilder
+func addResourceEdges(graphBuilder *strings.Builder, project *types.Project, opts *api.VizOptions) *strings.Builder {
+	for _, name := range slices.Sorted(maps.Keys(project.Services)) {
+		networks, volumes := serviceResources(project, project.Services[name])
+		for _, resource := range append(prefixed("network:", networks), prefixed("volume:", volumes)...) {
+			graphBuilder.WriteString(opts.Indentation)
+			writeQuoted(graphBuilder, name)
+			graphBuilder.WriteString(" -> ")
+			writeQuoted(graphBuilder, resource)
+			graphBuilder.WriteString(" [style=\"dashed\" arrowhead=\"none\"];\n")
+		}
+	}
+	return graphBuilder
+}
+
+// mermaidGraph renders the services dependency graph as a mermaid flowchart
+func mermaidGraph(project *types.Project, opts *api.VizOptions) string {
+	var graphBuilder strings.Builder
+	graphBuilder.WriteString("flowchart TD\n")
+
+	names := slices.Sorted(maps.Keys(project.Services))
+	for _, name := range names {
+		fmt.Fprintf(&graphBuilder, "%s%s[\"%s\"]\n", opts.Indentation, mermaidID("service", name), name)
+	}
+	if opts.IncludeResources {
+		networks, volumes := vizResources(project)
+		for _, network := range networks {
+			fmt.Fprintf(&graphBuilder, "%s%s([\"%s\"])\n", opts.Indentation, mermaidID("network", network), network)
+		}
+		for _, volume := range volumes {
+			fmt.Fprintf(&graphBuilder, "%s%s[(\"%s\")]\n", opts.Indentation, mermaidID("volume", volume), volume)
+		}
+	}
+
+	for _, name := range names {
+		service := project.Services[name]
+		for _, dependency := range slices.Sorted(maps.Keys(service.DependsOn)) {
+			arrow := "-->"
+			if condition := service.DependsOn[dependency].Condition; opts.IncludeConditions && condition != "" {
+				arrow += "|" + condition + "|"
+			}
+			fmt.Fprintf(&graphBuilder, "%s%s %s %s\n", opts.Indentation, mermaidID("service", name), arrow, mermaidID("service", dependency))
+		}
+		if opts.IncludeResources {
+			networks, volumes := serviceResources(project, service)
+			for _, network := range networks {
+				fmt.Fprintf(&graphBuilder, "%s%s -.- %s\n", opts.Indentation, mermaidID("service", name), mermaidID("network", network))
+			}
+			for _, volume := range volumes {
+				fmt.Fprintf(&graphBuilder, "%s%s -.- %s\n", opts.Indentation, mermaidID("service", name), mermaidID("volume", volume))
+			}
+		}
+	}
+	return graphBuilder.String()
+}
+
+// mermaidID returns a node identifier for a resource, as mermaid doesn't support all characters allowed in
+// names. Other characters are escaped as `_<hex code>_` and `_` as `__`, so distinct names get distinct
+// identifiers, like `a-b` and `a_b`.
+func mermaidID(kind, name string) string {
+	var sb strings.Builder
+	sb.WriteString(kind + "_")
+	for _, r := range name {
+		switch {
+		case r == '_':
+			sb.WriteString("__")
+		case r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			sb.WriteRune(r)
+		default:
+			fmt.Fprintf(&sb, "_%x_", r)
+		}
+	}
+	return sb.String()
+}
+
+// vizResources returns the sorted names of networks and volumes used by services
+func vizResources(project *types.Project) ([]string, []string) {
+	var networks, volumes []string
+	for _, service := range project.Services {
+		n, v := serviceResources(project, service)
+		networks = append(networks, n...)
+		volumes = append(volumes, v...)
+	}
+	slices.Sort(networks)
+	slices.Sort(volumes)
+	return slices.Compact(networks), slices.Compact(volumes)
+}
+
+// serviceResources returns the networks and named volumes used by a service
+func serviceResources(project *types.Project, service types.ServiceConfig) ([]string, []string) {
+	networks := service.NetworksByPriority()
+	var volumes []string
+	for _, volume := range service.Volumes {
+		if volume.Type != types.VolumeTypeVolume || volume.Source == "" {
+			continue
+		}
+		if _, ok := project.Volumes[volume.Source]; ok && !slices.Contains(volumes, volume.Source) {
+			volumes = append(volumes, volume.Source)
+		}
+	}
+	return networks, volumes
+}
+
+func prefixed(prefix string, names []string) []string {
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = prefix + name
+	}
+	return result
+}
+
 // writeQuoted writes "str" to builder
 func writeQuoted(builder *strings.Builder, str string) {
 	builder.WriteByte('"')
@@ -215,3 +215,100 @@ func TestViz(t *testing.T) {
 		}
 	})
 }
+
+func TestVizFormats(t *testing.T) {
+	project := &types.Project{
+		Name: "viz-test",
+		Services: types.Services{
+			"web": {
+				Name:  "web",
+				Image: "nginx",
+				DependsOn: types.DependsOnConfig{
+					"db": {Condition: types.ServiceConditionHealthy, Required: true},
+				},
+				Networks: map[string]*types.ServiceNetworkConfig{"front-end": nil},
+			},
+			"db": {
+				Name:  "db",
+				Image: "postgres",
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeVolume, Source: "data", Target: "/var/lib/postgresql/data"},
+					{Type: types.VolumeTypeBind, Source: "/tmp", Target: "/tmp"},
+				},
+			},
+		},
+		Networks: types.Networks{"front-end": types.NetworkConfig{}},
+		Volumes:  types.Volumes{"data": types.VolumeConfig{}},
+	}
+
+	tested, err := NewComposeService(nil)
+	assert.NilError(t, err)
+
+	t.Run("dot", func(t *testing.T) {
+		graph, err := tested.Viz(t.Context(), project, compose.VizOptions{
+			Indentation:       " ",
+			IncludeResources:  true,
+			IncludeConditions: true,
+		})
+		assert.NilError(t, err)
+		assert.Check(t, is.Contains(graph, ` "web" -> "db" [label="service_healthy"];`))
+		assert.Check(t, is.Contains(graph, ` "network:front-end" [shape="ellipse" label="front-end"];`))
+		assert.Check(t, is.Contains(graph, ` "volume:data" [shape="cylinder" label="data"];`))
+		assert.Check(t, is.Contains(graph, ` "web" -> "network:front-end" [style="dashed" arrowhead="none"];`))
+		assert.Check(t, is.Contains(graph, ` "db" -> "volume:data" [style="dashed" arrowhead="none"];`))
+		assert.Check(t, !is.Contains(graph, "/tmp")().Success())
+	})
+
+	t.Run("mermaid", func(t *testing.T) {
+		graph, err := tested.Viz(t.Context(), project, compose.VizOptions{
+			Indentation:       "  ",
+			IncludeResources:  true,
+			IncludeConditions: true,
+			Format:            compose.VizFormatMermaid,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, graph, `flowchart TD
+  service_db["db"]
+  service_web["web"]
+  network_front_2d_end(["front-end"])
+  volume_data[("data")]
+  service_db -.- volume_data
+  service_web -->|service_healthy| service_db
+  service_web -.- network_front_2d_end
+`)
+	})
+
+	t.Run("without conditions", func(t *testing.T) {
+		graph, err := tested.Viz(t.Context(), project, compose.VizOptions{Indentation: " "})
+		assert.NilError(t, err)
+		assert.Check(t, is.Contains(graph, ` "web" -> "db";`))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := tested.Viz(t.Context(), project, compose.VizOptions{Format: "svg"})
+		assert.ErrorContains(t, err, `unsupported graph format "svg"`)
+	})
+}
+
+func TestMermaidID(t *testing.T) {
+	assert.Equal(t, mermaidID("service", "web"), "service_web")
+	assert.Equal(t, mermaidID("service", "a-b"), "service_a_2d_b")
+	assert.Equal(t, mermaidID("service", "a_b"), "service_a__b")
+	assert.Equal(t, mermaidID("volume", "a.b"), "volume_a_2e_b")
+	assert.Equal(t, mermaidID("network", "café"), "network_caf_e9_")
+}
+
+func TestVizCycle(t *testing.T) {
+	project := &types.Project{
+		Name: "viz-test",
+		Services: types.Services{
+			"a": {Name: "a", DependsOn: types.DependsOnConfig{"b": {Required: true}}},
+			"b": {Name: "b", DependsOn: types.DependsOnConfig{"a": {Required: true}}},
+		},
+	}
+	tested, err := NewComposeService(nil)
+	assert.NilError(t, err)
+
+	_, err = tested.Viz(t.Context(), project, compose.VizOptions{Format: compose.VizFormatMermaid})
+	assert.ErrorContains(t, err, "cycle found")
+}
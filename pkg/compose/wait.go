@@ -18,8 +18,12 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 
+	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/moby/moby/client"
 	"golang.org/x/sync/errgroup"
 
@@ -27,6 +31,13 @@ import (
 )
 
 func (s *composeService) Wait(ctx context.Context, projectName string, options api.WaitOptions) (int64, error) {
+	switch options.Condition {
+	case "", types.ServiceConditionHealthy, types.ServiceConditionCompletedSuccessfully:
+	default:
+		return 0, fmt.Errorf("unsupported condition %q, must be one of: %s, %s", options.Condition,
+			types.ServiceConditionHealthy, types.ServiceConditionCompletedSuccessfully)
+	}
+
 	containers, err := s.getContainers(ctx, projectName, oneOffInclude, false, options.Services...)
 	if err != nil {
 		return 0, err
@@ -35,8 +46,43 @@ func (s *composeService) Wait(ctx context.Context, projectName string, options a
 		return 0, fmt.Errorf("no containers for project %q", projectName)
 	}
 
-	eg, waitCtx := errgroup.WithContext(ctx)
+	waitCtx := ctx
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
 	var statusCode int64
+	if options.Condition != "" {
+		err = s.waitCondition(waitCtx, projectName, containers, options)
+	} else {
+		statusCode, err = s.waitExit(waitCtx, containers)
+	}
+	if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+		return 0, fmt.Errorf("services did not reach expected state within %s: %w", options.Timeout, api.ErrTimeout)
+	}
+	if err != nil {
+		return 42, err // Ignore abort flag in case of error in wait
+	}
+
+	if options.DownProjectOnContainerExit {
+		return statusCode, s.Down(ctx, projectName, api.DownOptions{
+			RemoveOrphans: true,
+		})
+	}
+
+	return statusCode, err
+}
+
+// waitExit waits for all containers to exit, and returns the exit code of the first one to exit
+func (s *composeService) waitExit(ctx context.Context, containers Containers) (int64, error) {
+	eg, waitCtx := errgroup.WithContext(ctx)
+	var (
+		mu         sync.Mutex
+		exited     bool
+		statusCode int64
+	)
 	for _, ctr := range containers {
 		eg.Go(func() error {
 			var err error
@@ -44,23 +90,41 @@ func (s *composeService) Wait(ctx context.Context, projectName string, options a
 			select {
 			case result := <-res.Result:
 				_, _ = fmt.Fprintf(s.stdout(), "container %q exited with status code %d\n", ctr.ID, result.StatusCode)
-				statusCode = result.StatusCode
+				mu.Lock()
+				if !exited {
+					exited, statusCode = true, result.StatusCode
+				}
+				mu.Unlock()
 			case err = <-res.Error:
 			}
 			return err
 		})
 	}
+	err := eg.Wait()
+	return statusCode, err
+}
 
-	err = eg.Wait()
-	if err != nil {
-		return 42, err // Ignore abort flag in case of error in wait
+// waitCondition waits for services to reach condition, using the same logic as `depends_on`
+func (s *composeService) waitCondition(ctx context.Context, projectName string, containers Containers, options api.WaitOptions) error {
+	services := options.Services
+	if len(services) == 0 {
+		for _, ctr := range containers {
+			if service := ctr.Labels[api.ServiceLabel]; !slices.Contains(services, service) {
+				services = append(services, service)
+			}
+		}
 	}
-
-	if options.DownProjectOnContainerExit {
-		return statusCode, s.Down(ctx, projectName, api.DownOptions{
-			RemoveOrphans: true,
-		})
+	project := &types.Project{
+		Name:     projectName,
+		Services: types.Services{},
 	}
-
-	return statusCode, err
+	dependencies := types.DependsOnConfig{}
+	for _, service := range services {
+		project.Services[service] = types.ServiceConfig{Name: service}
+		dependencies[service] = types.ServiceDependency{
+			Condition: options.Condition,
+			Required:  true,
+		}
+	}
+	return s.waitDependencies(ctx, project, projectName, dependencies, containers, 0)
 }
@@ -0,0 +1,98 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/pkg/api"
+)
+
+func TestWaitCondition(t *testing.T) {
+	healthState := func(status container.HealthStatus) client.ContainerInspectResult {
+		return client.ContainerInspectResult{
+			Container: container.InspectResponse{
+				Name: "/web-1",
+				State: &container.State{
+					Status: container.StateRunning,
+					Health: &container.Health{Status: status},
+				},
+				Config: &container.Config{
+					Healthcheck: &container.HealthConfig{Test: []string{"CMD", "true"}},
+				},
+			},
+		}
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		apiClient, cli := prepareMocks(mockCtrl)
+		tested, err := NewComposeService(cli)
+		assert.NilError(t, err)
+
+		apiClient.EXPECT().ContainerList(gomock.Any(), gomock.Any()).Return(client.ContainerListResult{
+			Items: []container.Summary{testContainer("web", "123", false)},
+		}, nil)
+		apiClient.EXPECT().ContainerInspect(gomock.Any(), "123", gomock.Any()).Return(healthState(container.Healthy), nil)
+
+		code, err := tested.Wait(t.Context(), strings.ToLower(testProject), api.WaitOptions{
+			Services:  []string{"web"},
+			Condition: "service_healthy",
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, code, int64(0))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		apiClient, cli := prepareMocks(mockCtrl)
+		tested, err := NewComposeService(cli)
+		assert.NilError(t, err)
+
+		apiClient.EXPECT().ContainerList(gomock.Any(), gomock.Any()).Return(client.ContainerListResult{
+			Items: []container.Summary{testContainer("web", "123", false)},
+		}, nil)
+		apiClient.EXPECT().ContainerInspect(gomock.Any(), "123", gomock.Any()).Return(healthState(container.Starting), nil).AnyTimes()
+
+		_, err = tested.Wait(t.Context(), strings.ToLower(testProject), api.WaitOptions{
+			Services:  []string{"web"},
+			Condition: "service_healthy",
+			Timeout:   100 * time.Millisecond,
+		})
+		assert.ErrorIs(t, err, api.ErrTimeout)
+	})
+
+	t.Run("unsupported condition", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		_, cli := prepareMocks(mockCtrl)
+		tested, err := NewComposeService(cli)
+		assert.NilError(t, err)
+
+		_, err = tested.Wait(t.Context(), strings.ToLower(testProject), api.WaitOptions{
+			Services:  []string{"web"},
+			Condition: "service_started",
+		})
+		assert.Error(t, err, `unsupported condition "service_started", must be one of: service_healthy, service_completed_successfully`)
+	})
+}
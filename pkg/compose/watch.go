@@ -198,8 +198,9 @@ func (s *composeService) watch(ctx context.Context, project *types.Project, opti
 	eg, ctx := errgroup.WithContext(ctx)
 
 	var (
-		rules []watchRule
-		paths []string
+		rules   []watchRule
+		paths   []string
+		watched []string
 	)
 	for serviceName, service := range project.Services {
 		config, err := loadDevelopmentConfig(service, project)
@@ -262,13 +263,20 @@ func (s *composeService) watch(ctx context.Context, project *types.Project, opti
 			return nil, err
 		}
 		rules = append(rules, serviceWatchRules...)
+		watched = append(watched, serviceName)
 	}
 
 	if len(paths) == 0 {
 		return nil, fmt.Errorf("none of the selected services is configured for watch, consider setting a 'develop' section")
 	}
 
-	watcher, err := watch.NewWatcher(paths)
+	if options.NoUp {
+		if err := s.checkServicesRunning(ctx, project.Name, watched); err != nil {
+			return nil, err
+		}
+	}
+
+	watcher, err := watch.NewWatcher(paths, watchIgnore{rules: rules})
 	if err != nil {
 		return nil, err
 	}
@@ -292,6 +300,26 @@ func (s *composeService) watch(ctx context.Context, project *types.Project, opti
 	}, nil
 }
 
+// checkServicesRunning returns an error if any of services has no running container
+func (s *composeService) checkServicesRunning(ctx context.Context, projectName string, services []string) error {
+	// only running containers are listed
+	containers, err := s.getContainers(ctx, projectName, oneOffExclude, false, services...)
+	if err != nil {
+		return err
+	}
+	var notRunning []string
+	for _, service := range services {
+		if len(containers.filter(isService(service))) == 0 {
+			notRunning = append(notRunning, service)
+		}
+	}
+	if len(notRunning) > 0 {
+		slices.Sort(notRunning)
+		return fmt.Errorf("watched services are not running: %s. Start them or run watch without --no-up", strings.Join(notRunning, ", "))
+	}
+	return nil
+}
+
 func getWatchRules(config *types.DevelopConfig, service types.ServiceConfig) ([]watchRule, error) {
 	var rules []watchRule
 
@@ -314,6 +342,11 @@ func getWatchRules(config *types.DevelopConfig, service types.ServiceConfig) ([]
 			return nil, err
 		}
 
+		syncIgnore, err := watch.LoadSyncIgnore(trigger.Path)
+		if err != nil {
+			return nil, err
+		}
+
 		var include watch.PathMatcher
 		if len(trigger.Include) == 0 {
 			include = watch.AnyMatcher{}
@@ -332,6 +365,7 @@ func getWatchRules(config *types.DevelopConfig, service types.ServiceConfig) ([]
 				watch.EphemeralPathMatcher(),
 				dotGitIgnore,
 				ignore,
+				syncIgnore,
 			),
 			service: service.Name,
 		})
@@ -347,8 +381,14 @@ func (s *composeService) watchEvents(ctx context.Context, project *types.Project
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// debounce and group filesystem events so that we capture IDE saving many files as one "batch" event
-	batchEvents := watch.BatchDebounceEvents(ctx, s.clock, watcher.Events())
+	debounce := options.Debounce
+	if debounce <= 0 {
+		debounce = watch.QuietPeriod
+	}
+	// debounce and group filesystem events per service so that we capture IDE saving many files as one "batch" event
+	batchEvents := watch.BatchDebounceEventsByKey(ctx, s.clock, debounce, watcher.Events(), func(event watch.FileEvent) []string {
+		return watchedServices(event, rules)
+	})
 
 	for {
 		select {
@@ -366,19 +406,20 @@ func (s *composeService) watchEvents(ctx context.Context, project *types.Project
 			}
 			_ = watcher.Close()
 			return err
-		case batch, ok := <-batchEvents:
+		case serviceBatch, ok := <-batchEvents:
 			if !ok {
 				options.LogTo.Log(api.WatchLogger, "Watch disabled")
 				_ = watcher.Close()
 				return nil
 			}
+			batch := serviceBatch.Events
 			if len(batch) > 1000 {
 				logrus.Warnf("Very large batch of file changes detected: %d files. This may impact performance.", len(batch))
 				options.LogTo.Log(api.WatchLogger, "Large batch of file changes detected. If you just switched branches, this is expected.")
 			}
 			start := time.Now()
-			logrus.Debugf("batch start: count[%d]", len(batch))
-			err := s.handleWatchBatch(ctx, project, options, batch, rules, syncer)
+			logrus.Debugf("batch start: service[%s] count[%d]", serviceBatch.Key, len(batch))
+			err := s.handleWatchBatch(ctx, project, options, batch, serviceRules(rules, serviceBatch.Key), syncer)
 			if err != nil {
 				logrus.Warnf("Error handling changed files: %v", err)
 				// If context was canceled, exit immediately
@@ -387,11 +428,62 @@ func (s *composeService) watchEvents(ctx context.Context, project *types.Project
 					return ctx.Err()
 				}
 			}
-			logrus.Debugf("batch complete: duration[%s] count[%d]", time.Since(start), len(batch))
+			logrus.Debugf("batch complete: service[%s] duration[%s] count[%d]", serviceBatch.Key, time.Since(start), len(batch))
 		}
 	}
 }
 
+// watchIgnore matches paths ignored by all the watch rules they are subject to, so the notifier can skip
+// directories no rule would act on
+type watchIgnore struct {
+	rules []watchRule
+}
+
+func (w watchIgnore) Matches(f string) (bool, error) {
+	return w.match(f, watch.PathMatcher.Matches)
+}
+
+func (w watchIgnore) MatchesEntireDir(f string) (bool, error) {
+	return w.match(f, watch.PathMatcher.MatchesEntireDir)
+}
+
+func (w watchIgnore) match(f string, matches func(watch.PathMatcher, string) (bool, error)) (bool, error) {
+	watched := false
+	for _, rule := range w.rules {
+		if !pathutil.IsChild(rule.Path, f) {
+			continue
+		}
+		watched = true
+		ignored, err := matches(rule.ignore, f)
+		if !ignored || err != nil {
+			return false, err
+		}
+	}
+	return watched, nil
+}
+
+// watchedServices returns the services with a watch rule matching the file event
+func watchedServices(event watch.FileEvent, rules []watchRule) []string {
+	var services []string
+	for _, rule := range rules {
+		if !slices.Contains(services, rule.service) && rule.Matches(event) != nil {
+			services = append(services, rule.service)
+		}
+	}
+	return services
+}
+
+// serviceRules returns the watch rules declared by service
+func serviceRules(rules []watchRule, service string) []watchRule {
+	var selected []watchRule
+	for _, rule := range rules {
+		if rule.service == service {
+			selected = append(selected, rule)
+		}
+	}
+	return selected
+}
+
 func loadDevelopmentConfig(service types.ServiceConfig, project *types.Project) (*types.DevelopConfig, error) {
 	var config types.DevelopConfig
 	y, ok := service.Extensions["x-develop"]
@@ -529,14 +621,24 @@ func (t tarDockerClient) Untar(ctx context.Context, id string, archive io.ReadCl
 	return err
 }
 
-//nolint:gocyclo
-func (s *composeService) handleWatchBatch(ctx context.Context, project *types.Project, options api.WatchOptions, batch []watch.FileEvent, rules []watchRule, syncer sync.Syncer) error {
-	var (
-		restart   = map[string]bool{}
-		syncfiles = map[string][]*sync.PathMapping{}
-		exec      = map[string][]int{}
-		rebuild   = map[string]bool{}
-	)
+// watchActions are the actions to apply to services for a batch of file events
+type watchActions struct {
+	restart   map[string]bool
+	syncfiles map[string][]*sync.PathMapping
+	exec      map[string][]int // indexes of the rules with an exec hook to run
+	rebuild   map[string]bool
+}
+
+// collectWatchActions resolves the actions to apply for a batch of file events. When a file matches
+// rules with distinct actions, all of them apply, but a service being rebuilt is not restarted as
+// its containers are recreated anyway.
+func collectWatchActions(batch []watch.FileEvent, rules []watchRule) watchActions {
+	actions := watchActions{
+		restart:   map[string]bool{},
+		syncfiles: map[string][]*sync.PathMapping{},
+		exec:      map[string][]int{},
+		rebuild:   map[string]bool{},
+	}
 	for _, event := range batch {
 		for i, rule := range rules {
 			mapping := rule.Matches(event)
@@ -546,41 +648,48 @@ func (s *composeService) handleWatchBatch(ctx context.Context, project *types.Pr
 
 			switch rule.Action {
 			case types.WatchActionRebuild:
-				rebuild[rule.service] = true
+				actions.rebuild[rule.service] = true
 			case types.WatchActionSync:
-				syncfiles[rule.service] = append(syncfiles[rule.service], mapping)
+				actions.syncfiles[rule.service] = append(actions.syncfiles[rule.service], mapping)
 			case types.WatchActionRestart:
-				restart[rule.service] = true
+				actions.restart[rule.service] = true
 			case types.WatchActionSyncRestart:
-				syncfiles[rule.service] = append(syncfiles[rule.service], mapping)
-				restart[rule.service] = true
+				actions.syncfiles[rule.service] = append(actions.syncfiles[rule.service], mapping)
+				actions.restart[rule.service] = true
 			case types.WatchActionSyncExec:
-				syncfiles[rule.service] = append(syncfiles[rule.service], mapping)
+				actions.syncfiles[rule.service] = append(actions.syncfiles[rule.service], mapping)
 				// We want to run exec hooks only once after syncfiles if multiple file events match
 				// as we can't compare ServiceHook to sort and compact a slice, collect rule indexes
-				exec[rule.service] = append(exec[rule.service], i)
+				actions.exec[rule.service] = append(actions.exec[rule.service], i)
 			}
 		}
 	}
+	for service := range actions.rebuild {
+		delete(actions.restart, service)
+	}
+	return actions
+}
 
-	logrus.Debugf("watch actions: rebuild %d sync %d restart %d", len(rebuild), len(syncfiles), len(restart))
+func (s *composeService) handleWatchBatch(ctx context.Context, project *types.Project, options api.WatchOptions, batch []watch.FileEvent, rules []watchRule, syncer sync.Syncer) error {
+	actions := collectWatchActions(batch, rules)
+	logrus.Debugf("watch actions: rebuild %d sync %d restart %d", len(actions.rebuild), len(actions.syncfiles), len(actions.restart))
 
-	if len(rebuild) > 0 {
-		err := s.rebuild(ctx, project, utils.MapKeys(rebuild), options)
+	if len(actions.rebuild) > 0 {
+		err := s.rebuild(ctx, project, utils.MapKeys(actions.rebuild), options)
 		if err != nil {
 			return err
 		}
 	}
 
-	for serviceName, pathMappings := range syncfiles {
+	for serviceName, pathMappings := range actions.syncfiles {
 		writeWatchSyncMessage(options.LogTo, serviceName, pathMappings)
 		err := syncer.Sync(ctx, serviceName, pathMappings)
 		if err != nil {
 			return err
 		}
 	}
-	if len(restart) > 0 {
-		services := utils.MapKeys(restart)
+	if len(actions.restart) > 0 {
+		services := utils.MapKeys(actions.restart)
 		err := s.restart(ctx, project.Name, api.RestartOptions{
 			Services: services,
 			Project:  project,
@@ -595,7 +704,7 @@ func (s *composeService) handleWatchBatch(ctx context.Context, project *types.Pr
 	}
 
 	eg, ctx := errgroup.WithContext(ctx)
-	for service, rulesToExec := range exec {
+	for service, rulesToExec := range actions.exec {
 		slices.Sort(rulesToExec)
 		for _, i := range slices.Compact(rulesToExec) {
 			err := s.exec(ctx, project, service, rules[i].Exec, eg)
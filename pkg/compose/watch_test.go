@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"slices"
 	"testing"
 	"time"
@@ -194,3 +195,138 @@ func (f *fakeSyncer) Sync(ctx context.Context, service string, paths []*sync.Pat
 	f.synced <- paths
 	return nil
 }
+
+func TestCheckServicesRunning(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient, cli := prepareMocks(mockCtrl)
+	service, err := NewComposeService(cli)
+	assert.NilError(t, err)
+	tested := service.(*composeService)
+
+	web := testContainer("web", "web-1", false)
+	web.State = container.StateRunning
+	apiClient.EXPECT().ContainerList(gomock.Any(), client.ContainerListOptions{
+		Filters: projectFilter(testProject).Add("label", api.ConfigHashLabel, oneOffFilter(false)),
+	}).Return(client.ContainerListResult{Items: []container.Summary{web}}, nil).Times(2)
+
+	err = tested.checkServicesRunning(t.Context(), testProject, []string{"web", "db"})
+	assert.Error(t, err, "watched services are not running: db. Start them or run watch without --no-up")
+
+	apiClient.EXPECT().ContainerList(gomock.Any(), client.ContainerListOptions{
+		Filters: projectFilter(testProject).Add("label", serviceFilter("web"), api.ConfigHashLabel, oneOffFilter(false)),
+	}).Return(client.ContainerListResult{Items: []container.Summary{web}}, nil)
+	err = tested.checkServicesRunning(t.Context(), testProject, []string{"web"})
+	assert.NilError(t, err)
+
+	err = tested.checkServicesRunning(t.Context(), testProject, []string{"web", "worker"})
+	assert.ErrorContains(t, err, "watched services are not running: worker")
+}
+
+func TestCollectWatchActions(t *testing.T) {
+	webRules, err := getWatchRules(&types.DevelopConfig{
+		Watch: []types.Trigger{
+			{Path: "/web/config", Action: types.WatchActionRestart},
+			{Path: "/web", Action: types.WatchActionSync, Target: "/app"},
+		},
+	}, types.ServiceConfig{Name: "web"})
+	assert.NilError(t, err)
+	apiRules, err := getWatchRules(&types.DevelopConfig{
+		Watch: []types.Trigger{
+			{Path: "/api/config", Action: types.WatchActionRestart},
+			{Path: "/api/src", Action: types.WatchActionRebuild},
+		},
+	}, types.ServiceConfig{Name: "api"})
+	assert.NilError(t, err)
+	rules := append(webRules, apiRules...)
+
+	// a file matching both restart and sync rules is synced, then the service restarted
+	actions := collectWatchActions([]watch.FileEvent{watch.NewFileEvent("/web/config/app.conf")}, rules)
+	assert.DeepEqual(t, actions.restart, map[string]bool{"web": true})
+	assert.DeepEqual(t, actions.syncfiles, map[string][]*sync.PathMapping{
+		"web": {{HostPath: "/web/config/app.conf", ContainerPath: "/app/config/app.conf"}},
+	})
+	assert.Equal(t, len(actions.rebuild), 0)
+
+	// rebuild takes precedence over restart
+	actions = collectWatchActions([]watch.FileEvent{
+		watch.NewFileEvent("/api/config/app.conf"),
+		watch.NewFileEvent("/api/src/main.go"),
+	}, rules)
+	assert.DeepEqual(t, actions.rebuild, map[string]bool{"api": true})
+	assert.Equal(t, len(actions.restart), 0)
+}
+
+func TestWatchedServices(t *testing.T) {
+	webRules, err := getWatchRules(&types.DevelopConfig{
+		Watch: []types.Trigger{
+			{Path: "/src/web", Action: types.WatchActionSync, Target: "/app"},
+			{Path: "/src/shared", Action: types.WatchActionRebuild},
+		},
+	}, types.ServiceConfig{Name: "web"})
+	assert.NilError(t, err)
+	apiRules, err := getWatchRules(&types.DevelopConfig{
+		Watch: []types.Trigger{
+			{Path: "/src/shared", Action: types.WatchActionRebuild},
+		},
+	}, types.ServiceConfig{Name: "api"})
+	assert.NilError(t, err)
+	rules := append(webRules, apiRules...)
+
+	assert.DeepEqual(t, watchedServices(watch.NewFileEvent("/src/web/index.html"), rules), []string{"web"})
+	assert.DeepEqual(t, watchedServices(watch.NewFileEvent("/src/shared/lib.go"), rules), []string{"web", "api"})
+	assert.Equal(t, len(watchedServices(watch.NewFileEvent("/src/other"), rules)), 0)
+	assert.Equal(t, len(serviceRules(rules, "web")), 2)
+	assert.Equal(t, len(serviceRules(rules, "api")), 1)
+}
+
+func TestWatchSyncIgnore(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, watch.SyncIgnoreFile), []byte("node_modules/\n!node_modules/my-lib\n"), 0o644)
+	assert.NilError(t, err)
+
+	webRules, err := getWatchRules(&types.DevelopConfig{
+		Watch: []types.Trigger{
+			{Path: dir, Action: types.WatchActionSync, Target: "/app"},
+		},
+	}, types.ServiceConfig{Name: "web"})
+	assert.NilError(t, err)
+
+	actions := collectWatchActions([]watch.FileEvent{
+		watch.NewFileEvent(filepath.Join(dir, "node_modules", "express", "index.js")),
+		watch.NewFileEvent(filepath.Join(dir, "node_modules", "my-lib", "index.js")),
+		watch.NewFileEvent(filepath.Join(dir, "index.js")),
+	}, webRules)
+	assert.DeepEqual(t, actions.syncfiles, map[string][]*sync.PathMapping{
+		"web": {
+			{HostPath: filepath.Join(dir, "node_modules", "my-lib", "index.js"), ContainerPath: "/app/node_modules/my-lib/index.js"},
+			{HostPath: filepath.Join(dir, "index.js"), ContainerPath: "/app/index.js"},
+		},
+	})
+
+	ignore := watchIgnore{rules: webRules}
+	ignored, err := ignore.Matches(filepath.Join(dir, "node_modules", "express"))
+	assert.NilError(t, err)
+	assert.Assert(t, ignored)
+	ignored, err = ignore.MatchesEntireDir(filepath.Join(dir, "node_modules", "express"))
+	assert.NilError(t, err)
+	assert.Assert(t, ignored)
+
+	// a path is only skipped by the notifier when all rules watching it ignore it
+	apiRules, err := getWatchRules(&types.DevelopConfig{
+		Watch: []types.Trigger{
+			{Path: filepath.Join(dir, "node_modules"), Action: types.WatchActionRestart, Ignore: []string{"*.md"}},
+		},
+	}, types.ServiceConfig{Name: "api"})
+	assert.NilError(t, err)
+	ignore = watchIgnore{rules: append(webRules, apiRules...)}
+	ignored, err = ignore.MatchesEntireDir(filepath.Join(dir, "node_modules", "express"))
+	assert.NilError(t, err)
+	assert.Assert(t, !ignored)
+
+	// paths outside watched rules are left to the notifier
+	ignored, err = ignore.MatchesEntireDir(t.TempDir())
+	assert.NilError(t, err)
+	assert.Assert(t, !ignored)
+}
@@ -18,6 +18,8 @@ package e2e
 
 import (
 	"testing"
+
+	"gotest.tools/v3/icmd"
 )
 
 func TestExport(t *testing.T) {
@@ -48,3 +50,18 @@ func TestExportWithReplicas(t *testing.T) {
 	c.RunDockerComposeCmd(t, "--project-name", projectName, "export", "-o", "r1.tar", "--index=1", "service-with-replicas")
 	c.RunDockerComposeCmd(t, "--project-name", projectName, "export", "-o", "r2.tar", "--index=2", "service-with-replicas")
 }
+
+func TestExportWithReplicasRequiresIndex(t *testing.T) {
+	const projectName = "e2e-export-replicas-no-index"
+	c := NewParallelCLI(t)
+
+	cleanup := func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "--timeout=0", "--remove-orphans")
+	}
+	t.Cleanup(cleanup)
+	cleanup()
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/export/compose.yaml", "--project-name", projectName, "up", "-d", "service-with-replicas")
+	res := c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "export", "-o", "r.tar", "service-with-replicas")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "use --index to select one of: 1, 2, 3"})
+}
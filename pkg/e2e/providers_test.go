@@ -41,6 +41,7 @@ func TestProviderStopHook(t *testing.T) {
 	path := fmt.Sprintf("%s%s%s", os.Getenv("PATH"), string(os.PathListSeparator), filepath.Dir(provider))
 	c := NewParallelCLI(t, WithEnv(
 		"PATH="+path,
+		"COMPOSE_PROVIDER_PATH_LOOKUP=true",
 		"PROVIDER_STOP_MARKER="+markerFile,
 	))
 	const projectName = "provider-stop-hook"
@@ -65,7 +66,7 @@ func TestDependsOnMultipleProviders(t *testing.T) {
 	assert.NilError(t, err)
 
 	path := fmt.Sprintf("%s%s%s", os.Getenv("PATH"), string(os.PathListSeparator), filepath.Dir(provider))
-	c := NewParallelCLI(t, WithEnv("PATH="+path))
+	c := NewParallelCLI(t, WithEnv("PATH="+path, "COMPOSE_PROVIDER_PATH_LOOKUP=true"))
 	const projectName = "depends-on-multiple-providers"
 	t.Cleanup(func() {
 		c.cleanupWithDown(t, projectName)
@@ -83,7 +84,7 @@ func TestProviderRawSetEnv(t *testing.T) {
 	assert.NilError(t, err)
 
 	path := fmt.Sprintf("%s%s%s", os.Getenv("PATH"), string(os.PathListSeparator), filepath.Dir(provider))
-	c := NewParallelCLI(t, WithEnv("PATH="+path))
+	c := NewParallelCLI(t, WithEnv("PATH="+path, "COMPOSE_PROVIDER_PATH_LOOKUP=true"))
 	const projectName = "rawsetenv"
 	t.Cleanup(func() {
 		c.cleanupWithDown(t, projectName)
@@ -103,7 +104,7 @@ func TestProviderRawSetEnvOverridesUserEnv(t *testing.T) {
 	assert.NilError(t, err)
 
 	path := fmt.Sprintf("%s%s%s", os.Getenv("PATH"), string(os.PathListSeparator), filepath.Dir(provider))
-	c := NewParallelCLI(t, WithEnv("PATH="+path))
+	c := NewParallelCLI(t, WithEnv("PATH="+path, "COMPOSE_PROVIDER_PATH_LOOKUP=true"))
 	const projectName = "rawsetenv-override"
 	t.Cleanup(func() {
 		c.cleanupWithDown(t, projectName)
@@ -124,7 +125,7 @@ func TestProviderRawSetEnvOverridesInheritedEnv(t *testing.T) {
 	assert.NilError(t, err)
 
 	path := fmt.Sprintf("%s%s%s", os.Getenv("PATH"), string(os.PathListSeparator), filepath.Dir(provider))
-	c := NewParallelCLI(t, WithEnv("PATH="+path))
+	c := NewParallelCLI(t, WithEnv("PATH="+path, "COMPOSE_PROVIDER_PATH_LOOKUP=true"))
 	const projectName = "rawsetenv-inherit"
 	t.Cleanup(func() {
 		c.cleanupWithDown(t, projectName)
@@ -142,7 +143,7 @@ func TestProviderRawSetEnvOverridesInheritedEnvMapForm(t *testing.T) {
 	assert.NilError(t, err)
 
 	path := fmt.Sprintf("%s%s%s", os.Getenv("PATH"), string(os.PathListSeparator), filepath.Dir(provider))
-	c := NewParallelCLI(t, WithEnv("PATH="+path))
+	c := NewParallelCLI(t, WithEnv("PATH="+path, "COMPOSE_PROVIDER_PATH_LOOKUP=true"))
 	const projectName = "rawsetenv-inherit-map"
 	t.Cleanup(func() {
 		c.cleanupWithDown(t, projectName)
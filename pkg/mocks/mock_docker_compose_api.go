@@ -286,6 +286,21 @@ func (mr *MockComposeMockRecorder) Port(ctx, projectName, service, port, options
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Port", reflect.TypeOf((*MockCompose)(nil).Port), ctx, projectName, service, port, options)
 }
 
+// Ports mocks base method.
+func (m *MockCompose) Ports(ctx context.Context, projectName, service string, options api.PortOptions) ([]api.ContainerPortPublisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ports", ctx, projectName, service, options)
+	ret0, _ := ret[0].([]api.ContainerPortPublisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ports indicates an expected call of Ports.
+func (mr *MockComposeMockRecorder) Ports(ctx, projectName, service, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ports", reflect.TypeOf((*MockCompose)(nil).Ports), ctx, projectName, service, options)
+}
+
 // Ps mocks base method.
 func (m *MockCompose) Ps(ctx context.Context, projectName string, options api.PsOptions) ([]api.ContainerSummary, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,211 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/loader"
+	gitutil "github.com/moby/buildkit/frontend/dockerfile/dfgitutil"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	HTTP_REMOTE_ENABLED = "COMPOSE_EXPERIMENTAL_HTTP_REMOTE"
+	// httpRemoteTimeout is the maximum time to download a remote Compose file
+	httpRemoteTimeout = 30 * time.Second
+)
+
+// httpContentTypes are the media types accepted for a remote Compose file. Servers
+// often don't know about YAML, so generic types are accepted as well.
+var httpContentTypes = []string{
+	"application/yaml",
+	"application/x-yaml",
+	"text/yaml",
+	"text/x-yaml",
+	"application/json",
+	"text/plain",
+	"application/octet-stream",
+}
+
+func httpRemoteLoaderEnabled() (bool, error) {
+	if v := os.Getenv(HTTP_REMOTE_ENABLED); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("COMPOSE_EXPERIMENTAL_HTTP_REMOTE environment variable expects boolean value: %w", err)
+		}
+		return enabled, err
+	}
+	return true, nil
+}
+
+// NewHTTPRemoteLoader creates a loader for Compose files downloaded over HTTP(S). The URL can
+// set the expected digest of the file as fragment, e.g. https://example.com/compose.yaml#sha256:<hex>
+func NewHTTPRemoteLoader(offline bool) loader.ResourceLoader {
+	return &httpRemoteLoader{
+		offline: offline,
+		known:   map[string]string{},
+		client:  &http.Client{Timeout: httpRemoteTimeout},
+	}
+}
+
+type httpRemoteLoader struct {
+	offline bool
+	known   map[string]string
+	client  *http.Client
+}
+
+func (h *httpRemoteLoader) Accept(path string) bool {
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		return false
+	}
+	// git repositories also use http(s) URLs, those are managed by gitRemoteLoader
+	_, _, err := gitutil.ParseGitRef(path)
+	return err != nil
+}
+
+func (h *httpRemoteLoader) Load(ctx context.Context, path string) (string, error) {
+	enabled, err := httpRemoteLoaderEnabled()
+	if err != nil {
+		return "", err
+	}
+	if !enabled {
+		return "", fmt.Errorf("HTTP remote resource is disabled by %q", HTTP_REMOTE_ENABLED)
+	}
+
+	if local, ok := h.known[path]; ok {
+		return local, nil
+	}
+
+	location, expected, err := parseHTTPRef(path)
+	if err != nil {
+		return "", err
+	}
+
+	cache, err := cacheDir()
+	if err != nil {
+		return "", fmt.Errorf("initializing remote resource cache: %w", err)
+	}
+
+	name := fileNameFromURL(location)
+	if expected != "" {
+		local := filepath.Join(cache, expected.Encoded(), name)
+		if _, err := os.Stat(local); err == nil {
+			h.known[path] = local
+			return local, nil
+		}
+	}
+	if h.offline {
+		return "", nil
+	}
+
+	content, err := h.download(ctx, location)
+	if err != nil {
+		return "", err
+	}
+	actual := digest.FromBytes(content)
+	if expected != "" && actual != expected {
+		return "", fmt.Errorf("integrity check failed for %s: expected %s, got %s", location, expected, actual)
+	}
+
+	local := filepath.Join(cache, actual.Encoded(), name)
+	if err := os.MkdirAll(filepath.Dir(local), 0o700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(local, content, 0o600); err != nil {
+		return "", err
+	}
+	h.known[path] = local
+	return local, nil
+}
+
+func (h *httpRemoteLoader) Dir(path string) string {
+	local, ok := h.known[path]
+	if !ok {
+		return ""
+	}
+	return filepath.Dir(local)
+}
+
+func (h *httpRemoteLoader) download(ctx context.Context, location string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", location, resp.Status)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !slices.Contains(httpContentTypes, mediaType) {
+			return nil, fmt.Errorf("failed to download %s: unexpected content type %q", location, contentType)
+		}
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+	return content, nil
+}
+
+// parseHTTPRef splits the URL of a remote Compose file from the expected digest set as fragment
+func parseHTTPRef(ref string) (string, digest.Digest, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Fragment == "" {
+		return ref, "", nil
+	}
+	expected, err := digest.Parse(u.Fragment)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid digest %q for %s: %w", u.Fragment, ref, err)
+	}
+	u.Fragment = ""
+	return u.String(), expected, nil
+}
+
+// fileNameFromURL returns the name of the remote file, used for the local copy so messages still refer to it
+func fileNameFromURL(location string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "compose.yaml"
+	}
+	name := path.Base(u.Path)
+	if name == "." || name == "/" {
+		return "compose.yaml"
+	}
+	return name
+}
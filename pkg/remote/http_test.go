@@ -0,0 +1,116 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package remote
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/v3/assert"
+)
+
+const remoteCompose = "services:\n  web:\n    image: nginx\n"
+
+func TestHTTPRemoteLoaderAccept(t *testing.T) {
+	loader := NewHTTPRemoteLoader(false)
+	assert.Assert(t, loader.Accept("https://example.com/compose.yaml"))
+	assert.Assert(t, loader.Accept("http://example.com/compose.yaml#sha256:abc"))
+	assert.Assert(t, !loader.Accept("https://github.com/docker/compose.git"))
+	assert.Assert(t, !loader.Accept("oci://docker.io/user/app"))
+	assert.Assert(t, !loader.Accept("compose.yaml"))
+}
+
+func TestHTTPRemoteLoaderLoad(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/base.yaml":
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write([]byte(remoteCompose))
+		case "/login":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("download", func(t *testing.T) {
+		loader := NewHTTPRemoteLoader(false)
+		local, err := loader.Load(t.Context(), server.URL+"/base.yaml")
+		assert.NilError(t, err)
+		assert.Equal(t, filepath.Base(local), "base.yaml")
+		assert.Equal(t, loader.Dir(server.URL+"/base.yaml"), filepath.Dir(local))
+		content, err := os.ReadFile(local)
+		assert.NilError(t, err)
+		assert.Equal(t, string(content), remoteCompose)
+	})
+
+	t.Run("integrity", func(t *testing.T) {
+		expected := digest.FromString(remoteCompose)
+		local, err := NewHTTPRemoteLoader(false).Load(t.Context(), server.URL+"/base.yaml#"+expected.String())
+		assert.NilError(t, err)
+		assert.Equal(t, filepath.Base(filepath.Dir(local)), expected.Encoded())
+
+		// once cached, a file pinned by digest is available offline
+		cached, err := NewHTTPRemoteLoader(true).Load(t.Context(), server.URL+"/base.yaml#"+expected.String())
+		assert.NilError(t, err)
+		assert.Equal(t, cached, local)
+
+		unexpected := digest.FromString("something else")
+		_, err = NewHTTPRemoteLoader(false).Load(t.Context(), server.URL+"/base.yaml#"+unexpected.String())
+		assert.ErrorContains(t, err, "integrity check failed")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := NewHTTPRemoteLoader(false).Load(t.Context(), server.URL+"/missing.yaml")
+		assert.Error(t, err, "failed to download "+server.URL+"/missing.yaml: 404 Not Found")
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		_, err := NewHTTPRemoteLoader(false).Load(t.Context(), server.URL+"/login")
+		assert.Error(t, err, "failed to download "+server.URL+`/login: unexpected content type "text/html; charset=utf-8"`)
+	})
+}
+
+func TestHTTPRemoteLoaderTimeout(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	loader := NewHTTPRemoteLoader(false).(*httpRemoteLoader)
+	loader.client.Timeout = 10 * time.Millisecond
+	_, err := loader.Load(t.Context(), server.URL+"/base.yaml")
+	assert.ErrorContains(t, err, "failed to download "+server.URL+"/base.yaml")
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
+
+func TestHTTPRemoteLoaderDisabled(t *testing.T) {
+	t.Setenv(HTTP_REMOTE_ENABLED, "false")
+	_, err := NewHTTPRemoteLoader(false).Load(t.Context(), "https://example.com/compose.yaml")
+	assert.Error(t, err, `HTTP remote resource is disabled by "COMPOSE_EXPERIMENTAL_HTTP_REMOTE"`)
+}
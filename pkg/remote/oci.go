@@ -123,10 +123,6 @@ func (g *ociRemoteLoader) Load(ctx context.Context, path string) (string, error)
 		return "", fmt.Errorf("OCI remote resource is disabled by %q", OCI_REMOTE_ENABLED)
 	}
 
-	if g.offline {
-		return "", nil
-	}
-
 	local, ok := g.known[path]
 	if !ok {
 		ref, err := reference.ParseDockerRef(path[len(OciPrefix):])
@@ -134,6 +130,16 @@ func (g *ociRemoteLoader) Load(ctx context.Context, path string) (string, error)
 			return "", err
 		}
 
+		// an artifact referenced by digest can't change, so there's no need to pull it again once cached
+		if cached, ok := cachedOCIArtifact(ref); ok {
+			g.known[path] = cached
+			return filepath.Join(cached, "compose.yaml"), nil
+		}
+
+		if g.offline {
+			return "", nil
+		}
+
 		resolver := oci.NewResolver(g.dockerCli.ConfigFile(), g.httpTransport(ctx), g.insecureRegistries...)
 
 		descriptor, content, err := oci.Get(ctx, resolver, ref)
@@ -203,10 +209,15 @@ func (g *ociRemoteLoader) pullComposeFiles(ctx context.Context, local string, ma
 	if err != nil {
 		return err
 	}
-	if (manifest.ArtifactType != "" && manifest.ArtifactType != oci.ComposeProjectArtifactType) ||
-		(manifest.ArtifactType == "" && manifest.Config.MediaType != oci.ComposeEmptyConfigMediaType) {
+	if manifest.ArtifactType != "" && manifest.ArtifactType != oci.ComposeProjectArtifactType {
 		return fmt.Errorf("%s is not a compose project OCI artifact, but %s", ref.String(), manifest.ArtifactType)
 	}
+	if manifest.ArtifactType == "" && manifest.Config.MediaType != oci.ComposeEmptyConfigMediaType {
+		return fmt.Errorf("%s is not a compose project OCI artifact, config media type is %s", ref.String(), manifest.Config.MediaType)
+	}
+	if err := validateComposeLayers(manifest, ref); err != nil {
+		return err
+	}
 
 	for i, layer := range manifest.Layers {
 		digested, err := reference.WithDigest(ref, layer.Digest)
@@ -234,6 +245,42 @@ func (g *ociRemoteLoader) pullComposeFiles(ctx context.Context, local string, ma
 	return nil
 }
 
+// validateComposeLayers checks manifest only has layers Compose knows about, including at least one Compose file
+func validateComposeLayers(manifest spec.Manifest, ref reference.Named) error {
+	found := false
+	for _, layer := range manifest.Layers {
+		switch layer.MediaType {
+		case oci.ComposeYAMLMediaType:
+			found = true
+		case oci.ComposeEnvFileMediaType, oci.ComposeEmptyConfigMediaType:
+		default:
+			return fmt.Errorf("%s has a layer with unsupported media type %q, expected %s or %s",
+				ref.String(), layer.MediaType, oci.ComposeYAMLMediaType, oci.ComposeEnvFileMediaType)
+		}
+	}
+	if !found {
+		return fmt.Errorf("%s doesn't have a %s layer", ref.String(), oci.ComposeYAMLMediaType)
+	}
+	return nil
+}
+
+// cachedOCIArtifact returns the local copy of an artifact referenced by digest, if it has already been pulled
+func cachedOCIArtifact(ref reference.Named) (string, bool) {
+	digested, ok := ref.(reference.Digested)
+	if !ok {
+		return "", false
+	}
+	cache, err := cacheDir()
+	if err != nil {
+		return "", false
+	}
+	local := filepath.Join(cache, digested.Digest().Encoded())
+	if _, err := os.Stat(filepath.Join(local, "compose.yaml")); err != nil {
+		return "", false
+	}
+	return local, true
+}
+
 func writeComposeFile(layer spec.Descriptor, i int, local string, content []byte) error {
 	file := "compose.yaml"
 	if _, ok := layer.Annotations["com.docker.compose.extends"]; ok {
@@ -17,11 +17,15 @@
 package remote
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/distribution/reference"
 	spec "github.com/opencontainers/image-spec/specs-go/v1"
 	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v5/internal/oci"
 )
 
 func TestValidatePathInBase(t *testing.T) {
@@ -137,3 +141,54 @@ func TestWriteComposeFileWithExtendsPathTraversal(t *testing.T) {
 	err := writeComposeFile(layer, 0, tmpDir, content)
 	assert.Error(t, err, "invalid OCI artifact")
 }
+
+func TestValidateComposeLayers(t *testing.T) {
+	ref, err := reference.ParseDockerRef("registry.example.com/myapp/compose:v1")
+	assert.NilError(t, err)
+
+	manifest := spec.Manifest{Layers: []spec.Descriptor{
+		{MediaType: oci.ComposeYAMLMediaType},
+		{MediaType: oci.ComposeEnvFileMediaType},
+	}}
+	assert.NilError(t, validateComposeLayers(manifest, ref))
+
+	manifest = spec.Manifest{Layers: []spec.Descriptor{
+		{MediaType: oci.ComposeYAMLMediaType},
+		{MediaType: spec.MediaTypeImageLayerGzip},
+	}}
+	assert.Error(t, validateComposeLayers(manifest, ref),
+		`registry.example.com/myapp/compose:v1 has a layer with unsupported media type "application/vnd.oci.image.layer.v1.tar+gzip", `+
+			`expected application/vnd.docker.compose.file+yaml or application/vnd.docker.compose.envfile`)
+
+	manifest = spec.Manifest{Layers: []spec.Descriptor{
+		{MediaType: oci.ComposeEnvFileMediaType},
+	}}
+	assert.Error(t, validateComposeLayers(manifest, ref),
+		"registry.example.com/myapp/compose:v1 doesn't have a application/vnd.docker.compose.file+yaml layer")
+}
+
+func TestCachedOCIArtifact(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+
+	const hex = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tagged, err := reference.ParseDockerRef("registry.example.com/myapp/compose:v1")
+	assert.NilError(t, err)
+	digested, err := reference.ParseDockerRef("registry.example.com/myapp/compose@sha256:" + hex)
+	assert.NilError(t, err)
+
+	_, ok := cachedOCIArtifact(digested)
+	assert.Assert(t, !ok)
+
+	local := filepath.Join(cache, "docker-compose", hex)
+	assert.NilError(t, os.MkdirAll(local, 0o700))
+	assert.NilError(t, os.WriteFile(filepath.Join(local, "compose.yaml"), []byte("services: {}\n"), 0o600))
+
+	cached, ok := cachedOCIArtifact(digested)
+	assert.Assert(t, ok)
+	assert.Equal(t, cached, local)
+
+	// tags can be moved, so they are always resolved against the registry
+	_, ok = cachedOCIArtifact(tagged)
+	assert.Assert(t, !ok)
+}
@@ -16,6 +16,7 @@ package watch
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/jonboulle/clockwork"
@@ -24,13 +25,14 @@ import (
 	"github.com/docker/compose/v5/pkg/utils"
 )
 
-const QuietPeriod = 500 * time.Millisecond
+// QuietPeriod is the default time window without file events before a batch is flushed
+const QuietPeriod = 200 * time.Millisecond
 
 // BatchDebounceEvents groups identical file events within a sliding time window and writes the results to the returned
 // channel.
 //
 // The returned channel is closed when the debouncer is stopped via context cancellation or by closing the input channel.
-func BatchDebounceEvents(ctx context.Context, clock clockwork.Clock, input <-chan FileEvent) <-chan []FileEvent {
+func BatchDebounceEvents(ctx context.Context, clock clockwork.Clock, period time.Duration, input <-chan FileEvent) <-chan []FileEvent {
 	out := make(chan []FileEvent)
 	go func() {
 		defer close(out)
@@ -49,7 +51,7 @@ func BatchDebounceEvents(ctx context.Context, clock clockwork.Clock, input <-cha
 			seen = utils.Set[FileEvent]{}
 		}
 
-		t := clock.NewTicker(QuietPeriod)
+		t := clock.NewTicker(period)
 		defer t.Stop()
 		for {
 			select {
@@ -66,7 +68,69 @@ func BatchDebounceEvents(ctx context.Context, clock clockwork.Clock, input <-cha
 				if _, ok := seen[e]; !ok {
 					seen.Add(e)
 				}
-				t.Reset(QuietPeriod)
+				t.Reset(period)
+			}
+		}
+	}()
+	return out
+}
+
+// KeyedBatch is a batch of file events debounced for a key
+type KeyedBatch struct {
+	Key    string
+	Events []FileEvent
+}
+
+// BatchDebounceEventsByKey dispatches file events to the keys returned by keysFn and debounces them independently
+// for each key, so that activity for one key neither delays nor merges into batches for the others. Events without
+// a key are dropped.
+//
+// The returned channel is closed when the debouncer is stopped via context cancellation or by closing the input channel.
+func BatchDebounceEventsByKey(ctx context.Context, clock clockwork.Clock, period time.Duration, input <-chan FileEvent, keysFn func(FileEvent) []string) <-chan KeyedBatch {
+	out := make(chan KeyedBatch)
+	go func() {
+		var wg sync.WaitGroup
+		inputs := map[string]chan FileEvent{}
+		defer func() {
+			for _, in := range inputs {
+				close(in)
+			}
+			wg.Wait()
+			close(out)
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-input:
+				if !ok {
+					return
+				}
+				for _, key := range keysFn(e) {
+					in, ok := inputs[key]
+					if !ok {
+						in = make(chan FileEvent)
+						inputs[key] = in
+						batches := BatchDebounceEvents(ctx, clock, period, in)
+						wg.Add(1)
+						go func() {
+							defer wg.Done()
+							for events := range batches {
+								select {
+								case out <- KeyedBatch{Key: key, Events: events}:
+								case <-ctx.Done():
+									// keep draining until the debouncer is stopped
+								}
+							}
+						}()
+					}
+					select {
+					case in <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
 			}
 		}
 	}()
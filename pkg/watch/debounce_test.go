@@ -16,7 +16,9 @@ package watch
 
 import (
 	"context"
+	"fmt"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,7 +32,7 @@ func Test_BatchDebounceEvents(t *testing.T) {
 	ctx, stop := context.WithCancel(t.Context())
 	t.Cleanup(stop)
 
-	eventBatchCh := BatchDebounceEvents(ctx, clock, ch)
+	eventBatchCh := BatchDebounceEvents(ctx, clock, QuietPeriod, ch)
 	for i := range 100 {
 		path := "/a"
 		if i%2 == 0 {
@@ -64,3 +66,67 @@ func Test_BatchDebounceEvents(t *testing.T) {
 		// channel is empty
 	}
 }
+
+func Test_BatchDebounceEventsByKey(t *testing.T) {
+	ch := make(chan FileEvent)
+	clock := clockwork.NewFakeClock()
+	ctx, stop := context.WithCancel(t.Context())
+	t.Cleanup(stop)
+
+	const period = 100 * time.Millisecond
+	eventBatchCh := BatchDebounceEventsByKey(ctx, clock, period, ch, func(e FileEvent) []string {
+		switch {
+		case strings.HasPrefix(string(e), "/web/"):
+			return []string{"web"}
+		case strings.HasPrefix(string(e), "/shared/"):
+			return []string{"web", "api"}
+		case strings.HasPrefix(string(e), "/api/"):
+			return []string{"api"}
+		}
+		return nil
+	})
+
+	// a burst of events, as a formatter rewriting many files would trigger
+	for i := range 200 {
+		ch <- FileEvent(fmt.Sprintf("/web/file%d", i%50))
+	}
+	ch <- FileEvent("/shared/lib")
+	ch <- FileEvent("/unknown")
+	// one debouncer per key
+	err := clock.BlockUntilContext(ctx, 2)
+	assert.NilError(t, err)
+	clock.Advance(period)
+
+	batches := map[string][]FileEvent{}
+	for range 2 {
+		select {
+		case batch := <-eventBatchCh:
+			_, seen := batches[batch.Key]
+			assert.Assert(t, !seen, "unexpected second batch for %s", batch.Key)
+			batches[batch.Key] = batch.Events
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+	assert.Equal(t, len(batches["web"]), 51)
+	assert.DeepEqual(t, batches["api"], []FileEvent{"/shared/lib"})
+
+	err = clock.BlockUntilContext(ctx, 2)
+	assert.NilError(t, err)
+	clock.Advance(period)
+	// there should only be a single batch per key
+	select {
+	case batch := <-eventBatchCh:
+		t.Fatalf("unexpected events: %v", batch)
+	case <-time.After(50 * time.Millisecond):
+		// channel is empty
+	}
+
+	close(ch)
+	select {
+	case _, ok := <-eventBatchCh:
+		assert.Assert(t, !ok)
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("timed out waiting for channel to be closed")
+	}
+}
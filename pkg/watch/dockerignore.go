@@ -55,7 +55,16 @@ func (i dockerPathMatcher) MatchesEntireDir(f string) (bool, error) {
 			if !pattern.Exclusion() {
 				continue
 			}
-			if paths.IsChild(f, pattern.String()) {
+			// an exclusion pattern with wildcards, like `!**/keep`, may re-include files at any depth under
+			// its static prefix
+			prefix := pattern.String()
+			if i := strings.IndexAny(prefix, "*?["); i >= 0 {
+				prefix = filepath.Dir(prefix[:i+1])
+				if paths.IsChild(prefix, f) {
+					return false, nil
+				}
+			}
+			if paths.IsChild(f, prefix) {
 				// Found an exclusion match -- we don't match this whole dir
 				return false, nil
 			}
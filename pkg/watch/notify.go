@@ -80,8 +80,13 @@ func (EmptyMatcher) MatchesEntireDir(f string) (bool, error) { return false, nil
 
 var _ PathMatcher = EmptyMatcher{}
 
-func NewWatcher(paths []string) (Notify, error) {
-	return newWatcher(paths)
+// NewWatcher creates a Notify for paths. Directories entirely matched by ignore are not watched, which saves
+// watches on large ignored trees such as node_modules.
+func NewWatcher(paths []string, ignore PathMatcher) (Notify, error) {
+	if ignore == nil {
+		ignore = EmptyMatcher{}
+	}
+	return newWatcher(paths, ignore)
 }
 
 const WindowsBufferSizeEnvVar = "COMPOSE_WATCH_WINDOWS_BUFFER_SIZE"
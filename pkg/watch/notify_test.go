@@ -495,6 +495,7 @@ type notifyFixture struct {
 	*TempDirFixture
 	notify Notify
 	paths  []string
+	ignore PathMatcher
 	events []FileEvent
 }
 
@@ -526,7 +527,7 @@ func (f *notifyFixture) rebuildWatcher() {
 	}
 
 	// create a new watcher
-	notify, err := NewWatcher(f.paths)
+	notify, err := NewWatcher(f.paths, f.ignore)
 	if err != nil {
 		f.T().Fatal(err)
 	}
@@ -654,3 +655,29 @@ func (f *notifyFixture) tearDown() {
 	f.closeWatcher()
 	numberOfWatches.Set(0)
 }
+
+func TestIgnoredDirectoriesAreNotWatched(t *testing.T) {
+	if isRecursiveWatcher() {
+		t.Skip("recursive watchers use a single watch")
+	}
+	f := newNotifyFixture(t)
+
+	root := f.TempDir("root")
+	src := f.JoinPath(root, "src")
+	f.MkdirAll(src)
+	for i := range 10 {
+		f.MkdirAll(f.JoinPath(root, "node_modules", fmt.Sprintf("module%d", i)))
+	}
+
+	ignore, err := NewDockerPatternMatcher(root, []string{"**/node_modules"})
+	assert.NilError(t, err)
+	f.ignore = ignore
+	f.watch(root)
+
+	// the fixture directory, root and src
+	assert.Equal(t, 3, int(numberOfWatches.Value()))
+
+	file := f.JoinPath(src, "main.go")
+	f.WriteFile(file, "hello")
+	f.assertEvents(file)
+}
@@ -0,0 +1,88 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package watch
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SyncIgnoreFile is the name of the file declaring gitignore-style patterns for paths watch should not sync
+const SyncIgnoreFile = ".syncignore"
+
+// LoadSyncIgnore loads the .syncignore file from dir, if any, as a PathMatcher
+func LoadSyncIgnore(dir string) (PathMatcher, error) {
+	f, err := os.Open(filepath.Join(dir, SyncIgnoreFile))
+	if err != nil {
+		if os.IsNotExist(err) || isNotDir(dir) {
+			return EmptyMatcher{}, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	patterns, err := readSyncIgnorePatterns(f)
+	if err != nil {
+		return nil, err
+	}
+	return NewDockerPatternMatcher(dir, patterns)
+}
+
+func isNotDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && !fi.IsDir()
+}
+
+// readSyncIgnorePatterns reads gitignore-style patterns and converts them into dockerignore patterns:
+//   - a pattern without a separator, like `node_modules`, matches at any depth
+//   - a pattern with a leading or middle separator is relative to the .syncignore directory
+//   - a trailing separator, like `build/`, is dropped as matching a directory also matches its content
+//   - `!` negates a pattern, re-including paths excluded by a previous one
+func readSyncIgnorePatterns(r io.Reader) ([]string, error) {
+	var patterns []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		exclusion := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+		pattern = strings.TrimSuffix(pattern, "/")
+		switch {
+		case strings.HasPrefix(pattern, "/"):
+			pattern = strings.TrimPrefix(pattern, "/")
+		case !strings.Contains(pattern, "/") && !strings.HasPrefix(pattern, "**"):
+			pattern = "**/" + pattern
+		}
+		if pattern == "" {
+			continue
+		}
+		if exclusion {
+			pattern = "!" + pattern
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", SyncIgnoreFile, err)
+	}
+	return patterns, nil
+}
@@ -0,0 +1,111 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestReadSyncIgnorePatterns(t *testing.T) {
+	patterns, err := readSyncIgnorePatterns(strings.NewReader(`
+# dependencies
+node_modules/
+/build
+docs/*.md
+!docs/README.md
+*.log
+!important.log
+**/tmp
+`))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, patterns, []string{
+		"**/node_modules",
+		"build",
+		"docs/*.md",
+		"!docs/README.md",
+		"**/*.log",
+		"!**/important.log",
+		"**/tmp",
+	})
+}
+
+func TestLoadSyncIgnore(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, SyncIgnoreFile), []byte(`
+node_modules/
+!node_modules/my-lib
+/dist
+*.log
+!important.log
+`), 0o644)
+	assert.NilError(t, err)
+
+	matcher, err := LoadSyncIgnore(dir)
+	assert.NilError(t, err)
+
+	tests := []struct {
+		path    string
+		matches bool
+	}{
+		{path: "node_modules", matches: true},
+		{path: "node_modules/express/index.js", matches: true},
+		{path: "frontend/node_modules/react/index.js", matches: true},
+		{path: "node_modules/my-lib/index.js", matches: false},
+		{path: "dist/app.js", matches: true},
+		{path: "frontend/dist/app.js", matches: false},
+		{path: "debug.log", matches: true},
+		{path: "logs/debug.log", matches: true},
+		{path: "important.log", matches: false},
+		{path: "logs/important.log", matches: false},
+		{path: "src/main.js", matches: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			matches, err := matcher.Matches(filepath.Join(dir, tt.path))
+			assert.NilError(t, err)
+			assert.Equal(t, matches, tt.matches)
+		})
+	}
+
+	// node_modules can't be skipped entirely as my-lib is re-included
+	entireDir, err := matcher.MatchesEntireDir(filepath.Join(dir, "node_modules"))
+	assert.NilError(t, err)
+	assert.Assert(t, !entireDir)
+	// neither dist, as it may contain an important.log
+	entireDir, err = matcher.MatchesEntireDir(filepath.Join(dir, "dist"))
+	assert.NilError(t, err)
+	assert.Assert(t, !entireDir)
+}
+
+func TestLoadSyncIgnoreMissing(t *testing.T) {
+	dir := t.TempDir()
+	matcher, err := LoadSyncIgnore(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, matcher, PathMatcher(EmptyMatcher{}))
+
+	// watched path is a file
+	file := filepath.Join(dir, "file.txt")
+	assert.NilError(t, os.WriteFile(file, []byte("hello"), 0o644))
+	matcher, err = LoadSyncIgnore(file)
+	assert.NilError(t, err)
+	assert.Equal(t, matcher, PathMatcher(EmptyMatcher{}))
+}
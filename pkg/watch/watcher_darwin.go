@@ -115,7 +115,8 @@ func (d *fseventNotify) Errors() chan error {
 	return d.errors
 }
 
-func newWatcher(paths []string) (Notify, error) {
+// FSEvents streams are recursive and can't exclude sub-directories, so ignore is not used.
+func newWatcher(paths []string, _ PathMatcher) (Notify, error) {
 	dw := &fseventNotify{
 		stream: &fsevents.EventStream{
 			Latency: 50 * time.Millisecond,
@@ -27,7 +27,7 @@ import (
 func TestFseventNotifyCloseIdempotent(t *testing.T) {
 	// Create a watcher with a temporary directory
 	tmpDir := t.TempDir()
-	watcher, err := newWatcher([]string{tmpDir})
+	watcher, err := newWatcher([]string{tmpDir}, EmptyMatcher{})
 	assert.NilError(t, err)
 
 	// Start the watcher
@@ -46,6 +46,9 @@ type naiveNotify struct {
 	// structure, so we can filter the list quickly.
 	notifyList map[string]bool
 
+	// Directories matched entirely by ignore are not watched.
+	ignore PathMatcher
+
 	isWatcherRecursive bool
 	watcher            *fsnotify.Watcher
 	events             chan fsnotify.Event
@@ -252,6 +255,12 @@ func (d *naiveNotify) shouldSkipDir(path string) bool {
 	// - A child of a directory that's in our notify list, or
 	// - A parent of a directory that's in our notify list
 	//   (i.e., to cover the "path doesn't exist" case).
+	if ignored, err := d.ignore.MatchesEntireDir(path); err != nil {
+		logrus.Debugf("error matching %q against ignore patterns: %v", path, err)
+	} else if ignored {
+		return true
+	}
+
 	for root := range d.notifyList {
 		if pathutil.IsChild(root, path) || pathutil.IsChild(path, root) {
 			return false
@@ -270,7 +279,7 @@ func (d *naiveNotify) add(path string) error {
 	return nil
 }
 
-func newWatcher(paths []string) (Notify, error) {
+func newWatcher(paths []string, ignore PathMatcher) (Notify, error) {
 	fsw, err := fsnotify.NewWatcher()
 	if err != nil {
 		if strings.Contains(err.Error(), "too many open files") && runtime.GOOS == "linux" {
@@ -300,6 +309,7 @@ func newWatcher(paths []string) (Notify, error) {
 
 	wmw := &naiveNotify{
 		notifyList:         notifyList,
+		ignore:             ignore,
 		watcher:            fsw,
 		events:             fsw.Events,
 		wrappedEvents:      wrappedEvents,